@@ -7,7 +7,9 @@ package gortsplib
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net"
@@ -26,18 +28,31 @@ import (
 	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpulpfec"
 	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
 	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpreceiver"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtptime"
 	"github.com/frostyfridge/gortsplib/v4/pkg/sdp"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 const (
 	clientUserAgent = "gortsplib"
 )
 
+// RFC7826, section 18.33: startup-id is made of up to 8 alphanumeric characters.
+func generatePipelinedRequestsID() (string, error) {
+	byts := make([]byte, 4)
+	_, err := rand.Read(byts)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(byts), nil
+}
+
 // avoid an int64 overflow and preserve resolution by splitting division into two parts:
 // first add the integer part, then the decimal part.
 func multiplyAndDivide(v, m, d time.Duration) time.Duration {
@@ -59,6 +74,10 @@ func canonicalAddr(u *base.URL) string {
 		switch u.Scheme {
 		case "rtsp", "rtspt":
 			port = "554"
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
 		default: // rtsps
 			port = "322"
 		}
@@ -251,7 +270,7 @@ type Client struct {
 	// timeout of write operations.
 	// It defaults to 10 seconds.
 	WriteTimeout time.Duration
-	// a TLS configuration to connect to TLS (RTSPS) servers.
+	// a TLS configuration to connect to TLS (RTSPS) servers and HTTPS tunnels.
 	// It defaults to nil.
 	TLSConfig *tls.Config
 	// enable communication with servers which don't provide UDP server ports
@@ -259,6 +278,12 @@ type Client struct {
 	// This can be a security issue.
 	// It defaults to false.
 	AnyPortEnable bool
+	// allow exchanging SRTP keys over connections that are not encrypted with TLS.
+	// Keys are sent in clear text by SDP and by the KeyMgmt header,
+	// therefore anyone that can read the RTSP connection can decrypt secure medias.
+	// This can be a security issue.
+	// It defaults to false.
+	InsecureSRTPKeyExchange bool
 	// transport protocol (UDP, Multicast or TCP).
 	// If nil, it is chosen automatically (first UDP, then, if it fails, TCP).
 	// It defaults to nil.
@@ -277,6 +302,11 @@ type Client struct {
 	// user agent header.
 	// It defaults to "gortsplib"
 	UserAgent string
+	// RTSP protocol version.
+	// When set to base.Version20, the client falls back to base.Version10
+	// if the server doesn't support it.
+	// It defaults to base.Version10.
+	ProtocolVersion base.Version
 	// disable automatic RTCP sender reports.
 	DisableRTCPSenderReports bool
 	// explicitly request back channels to the server.
@@ -338,11 +368,15 @@ type Client struct {
 	nconn                net.Conn
 	conn                 *conn.Conn
 	session              string
+	pipelinedRequests    string
+	mediaProperties      *headers.MediaProperties
 	sender               *auth.Sender
 	cseq                 int
+	effectiveVersion     base.Version
 	optionsSent          bool
 	useGetParameter      bool
 	lastDescribeURL      *base.URL
+	lastDescribeFEC      []description.SessionFECGroup
 	baseURL              *base.URL
 	effectiveTransport   *Transport
 	backChannelSetupped  bool
@@ -365,6 +399,7 @@ type Client struct {
 	tcpBuffer            []byte
 	bytesReceived        *uint64
 	bytesSent            *uint64
+	goodbyeTracker       goodbyeTracker
 
 	// in
 	chOptions  chan optionsReq
@@ -374,12 +409,15 @@ type Client struct {
 	chPlay     chan playReq
 	chRecord   chan recordReq
 	chPause    chan pauseReq
+	chGoodbye  chan struct{}
 
 	// out
 	done chan struct{}
 }
 
 // Start initializes the connection to a server.
+// Supported schemes are rtsp, rtsps, rtspt and http / https, that enable
+// RTSP-over-HTTP tunneling. When tunneling, requests still use rtsp:// URLs.
 func (c *Client) Start(scheme string, host string) error {
 	// RTSP parameters
 	if c.ReadTimeout == 0 {
@@ -490,6 +528,7 @@ func (c *Client) Start(scheme string, host string) error {
 	c.checkTimeoutTimer = emptyTimer()
 	c.keepAlivePeriod = 30 * time.Second
 	c.keepAliveTimer = emptyTimer()
+	c.effectiveVersion = c.ProtocolVersion
 
 	if c.BytesReceived != nil {
 		c.bytesReceived = c.BytesReceived
@@ -509,6 +548,7 @@ func (c *Client) Start(scheme string, host string) error {
 	c.chPlay = make(chan playReq)
 	c.chRecord = make(chan recordReq)
 	c.chPause = make(chan pauseReq)
+	c.chGoodbye = make(chan struct{}, 1)
 	c.done = make(chan struct{})
 
 	go c.run()
@@ -673,6 +713,9 @@ func (c *Client) runInner() error {
 			}
 			c.keepAliveTimer = time.NewTimer(c.keepAlivePeriod)
 
+		case <-c.chGoodbye:
+			return liberrors.ErrClientGoodbye{}
+
 		case <-chWriterError:
 			return c.writer.stopError
 
@@ -732,7 +775,7 @@ func (c *Client) waitResponse(requestCseqStr string) (*base.Response, error) {
 func (c *Client) handleServerRequest(req *base.Request) error {
 	c.OnServerRequest(req)
 
-	if req.Method != base.Options {
+	if req.Method != base.Options && req.Method != base.PlayNotify {
 		return liberrors.ErrClientUnhandledMethod{Method: req.Method}
 	}
 
@@ -746,6 +789,7 @@ func (c *Client) handleServerRequest(req *base.Request) error {
 
 	res := &base.Response{
 		StatusCode: base.StatusOK,
+		Version:    req.Version,
 		Header:     h,
 	}
 
@@ -797,8 +841,11 @@ func (c *Client) reset() {
 
 	c.state = clientStateInitial
 	c.session = ""
+	c.pipelinedRequests = ""
+	c.mediaProperties = nil
 	c.sender = nil
 	c.cseq = 0
+	c.effectiveVersion = c.ProtocolVersion
 	c.optionsSent = false
 	c.useGetParameter = false
 	c.baseURL = nil
@@ -807,6 +854,7 @@ func (c *Client) reset() {
 	c.stdChannelSetupped = false
 	c.setuppedMedias = nil
 	c.tcpCallbackByChannel = nil
+	c.goodbyeTracker.reset()
 }
 
 func (c *Client) checkState(allowed map[clientState]struct{}) error {
@@ -887,6 +935,10 @@ func (c *Client) startTransportRoutines() {
 	c.timeDecoder = &rtptime.GlobalDecoder2{}
 	c.timeDecoder.Initialize()
 
+	if c.state == clientStatePlay {
+		c.linkFECMedias()
+	}
+
 	for _, cm := range c.setuppedMedias {
 		cm.start()
 	}
@@ -922,6 +974,23 @@ func (c *Client) startTransportRoutines() {
 	}
 }
 
+// linkFECMedias links medias protected by FEC with the media that carries FEC packets.
+func (c *Client) linkFECMedias() {
+	medias := make([]*description.Media, 0, len(c.setuppedMedias))
+	for medi, cm := range c.setuppedMedias {
+		medias = append(medias, medi)
+		cm.fecDecoder = nil
+		cm.fecProtected = nil
+	}
+
+	for protected, fecMedia := range fecMediaPairs(c.lastDescribeFEC, medias) {
+		cm := c.setuppedMedias[protected]
+		cm.fecDecoder = &rtpulpfec.Decoder{}
+		cm.fecDecoder.Init() //nolint:errcheck
+		c.setuppedMedias[fecMedia].fecProtected = cm
+	}
+}
+
 func (c *Client) stopTransportRoutines() {
 	if c.reader != nil {
 		c.reader.setAllowInterleavedFrames(false)
@@ -970,12 +1039,13 @@ func (c *Client) destroyWriter() {
 	c.writerMutex.Unlock()
 }
 
-func (c *Client) connOpen() error {
+func (c *Client) connOpen(u *base.URL) error {
 	if c.nconn != nil {
 		return nil
 	}
 
-	if c.connURL.Scheme != "rtsp" && c.connURL.Scheme != "rtsps" && c.connURL.Scheme != "rtspt" {
+	if c.connURL.Scheme != "rtsp" && c.connURL.Scheme != "rtsps" && c.connURL.Scheme != "rtspt" &&
+		!isHTTPTunnelScheme(c.connURL.Scheme) {
 		return liberrors.ErrClientUnsupportedScheme{Scheme: c.connURL.Scheme}
 	}
 
@@ -983,9 +1053,33 @@ func (c *Client) connOpen() error {
 		return liberrors.ErrClientRTSPSTCP{}
 	}
 
+	if isHTTPTunnelScheme(c.connURL.Scheme) && c.Transport != nil && *c.Transport != TransportTCP {
+		return liberrors.ErrClientHTTPTunnelTCP{}
+	}
+
 	dialCtx, dialCtxCancel := context.WithTimeout(c.ctx, c.ReadTimeout)
 	defer dialCtxCancel()
 
+	if isHTTPTunnelScheme(c.connURL.Scheme) {
+		// the tunnel is opened on the path of the first request
+		tu := &base.URL{
+			Scheme: c.connURL.Scheme,
+			Host:   c.connURL.Host,
+		}
+		if u != nil {
+			tu.Path = u.Path
+			tu.RawQuery = u.RawQuery
+		}
+
+		nconn, err := dialHTTPTunnel(dialCtx, c.DialContext, c.TLSConfig, c.ReadTimeout, c.UserAgent, tu)
+		if err != nil {
+			return err
+		}
+
+		c.setConn(nconn)
+		return nil
+	}
+
 	nconn, err := c.DialContext(dialCtx, "tcp", canonicalAddr(c.connURL))
 	if err != nil {
 		return err
@@ -1001,6 +1095,11 @@ func (c *Client) connOpen() error {
 		nconn = tls.Client(nconn, tlsConfig)
 	}
 
+	c.setConn(nconn)
+	return nil
+}
+
+func (c *Client) setConn(nconn net.Conn) {
 	c.nconn = nconn
 	bc := bytecounter.New(c.nconn, c.bytesReceived, c.bytesSent)
 	c.conn = conn.NewConn(bc)
@@ -1008,8 +1107,6 @@ func (c *Client) connOpen() error {
 		c: c,
 	}
 	c.reader.start()
-
-	return nil
 }
 
 func (c *Client) do(req *base.Request, skipResponse bool) (*base.Response, error) {
@@ -1028,6 +1125,8 @@ func (c *Client) do(req *base.Request, skipResponse bool) (*base.Response, error
 		req.Header["Session"] = base.HeaderValue{c.session}
 	}
 
+	req.Version = c.effectiveVersion
+
 	c.cseq++
 	cseqStr := strconv.FormatInt(int64(c.cseq), 10)
 	req.Header["CSeq"] = base.HeaderValue{cseqStr}
@@ -1177,7 +1276,7 @@ func (c *Client) doOptions(u *base.URL) (*base.Response, error) {
 		return nil, err
 	}
 
-	err = c.connOpen()
+	err = c.connOpen(u)
 	if err != nil {
 		return nil, err
 	}
@@ -1190,6 +1289,16 @@ func (c *Client) doOptions(u *base.URL) (*base.Response, error) {
 		return nil, err
 	}
 
+	// fall back to RTSP 1.0
+	if c.effectiveVersion == base.Version20 && res.Version != base.Version20 {
+		c.effectiveVersion = base.Version10
+
+		if res.StatusCode == base.StatusRTSPVersionNotSupported ||
+			res.StatusCode == base.StatusBadRequest {
+			return c.doOptions(u)
+		}
+	}
+
 	if res.StatusCode != base.StatusOK {
 		// since this method is not implemented by every RTSP server,
 		// return an error only if status code is not 404
@@ -1228,7 +1337,7 @@ func (c *Client) doDescribe(u *base.URL) (*description.Session, *base.Response,
 		return nil, nil, err
 	}
 
-	err = c.connOpen()
+	err = c.connOpen(u)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1309,6 +1418,7 @@ func (c *Client) doDescribe(u *base.URL) (*description.Session, *base.Response,
 	desc.BaseURL = baseURL
 
 	c.lastDescribeURL = u
+	c.lastDescribeFEC = desc.FECGroups
 
 	return &desc, res, nil
 }
@@ -1326,6 +1436,10 @@ func (c *Client) Describe(u *base.URL) (*description.Session, *base.Response, er
 	}
 }
 
+func (c *Client) srtpKeyExchangeAllowed() bool {
+	return c.connURL.Scheme == "rtsps" || c.connURL.Scheme == "https" || c.InsecureSRTPKeyExchange
+}
+
 func (c *Client) doAnnounce(u *base.URL, desc *description.Session) (*base.Response, error) {
 	err := c.checkState(map[clientState]struct{}{
 		clientStateInitial: {},
@@ -1334,13 +1448,22 @@ func (c *Client) doAnnounce(u *base.URL, desc *description.Session) (*base.Respo
 		return nil, err
 	}
 
-	err = c.connOpen()
+	err = c.connOpen(u)
 	if err != nil {
 		return nil, err
 	}
 
 	prepareForAnnounce(desc)
 
+	if hasSecureMedias(desc.Medias) && !c.srtpKeyExchangeAllowed() {
+		return nil, liberrors.ErrClientSRTPWithoutTLS{}
+	}
+
+	err = generateSRTPKeys(desc.Medias)
+	if err != nil {
+		return nil, err
+	}
+
 	byts, err := desc.Marshal(false)
 	if err != nil {
 		return nil, err
@@ -1398,7 +1521,7 @@ func (c *Client) doSetup(
 		return nil, err
 	}
 
-	err = c.connOpen()
+	err = c.connOpen(baseURL)
 	if err != nil {
 		return nil, err
 	}
@@ -1407,7 +1530,18 @@ func (c *Client) doSetup(
 		return nil, liberrors.ErrClientCannotSetupMediasDifferentURLs{}
 	}
 
+	if medi.Secure {
+		if medi.SRTPKey == nil {
+			return nil, liberrors.ErrClientSRTPKeyNotProvided{}
+		}
+
+		if !c.srtpKeyExchangeAllowed() {
+			return nil, liberrors.ErrClientSRTPWithoutTLS{}
+		}
+	}
+
 	th := headers.Transport{
+		Profile: mediaTransportProfile(medi),
 		Mode: func() *headers.TransportMode {
 			if c.state == clientStatePreRecord {
 				v := headers.TransportModeRecord
@@ -1425,7 +1559,7 @@ func (c *Client) doSetup(
 	cm.initialize()
 
 	if c.effectiveTransport == nil {
-		if c.connURL.Scheme == "rtsps" { // always use TCP if encrypted
+		if c.connURL.Scheme == "rtsps" || isHTTPTunnelScheme(c.connURL.Scheme) { // always use TCP if encrypted or tunneled
 			v := TransportTCP
 			c.effectiveTransport = &v
 		} else if c.Transport != nil { // take transport from config
@@ -1464,7 +1598,15 @@ func (c *Client) doSetup(
 		v1 := headers.TransportDeliveryUnicast
 		th.Delivery = &v1
 		th.Protocol = headers.TransportProtocolUDP
-		th.ClientPorts = &[2]int{cm.udpRTPListener.port(), cm.udpRTCPListener.port()}
+
+		if c.effectiveVersion == base.Version20 {
+			th.DestAddr = []headers.TransportAddr{
+				{Port: cm.udpRTPListener.port()},
+				{Port: cm.udpRTCPListener.port()},
+			}
+		} else {
+			th.ClientPorts = &[2]int{cm.udpRTPListener.port(), cm.udpRTCPListener.port()}
+		}
 
 	case TransportUDPMulticast:
 		v1 := headers.TransportDeliveryMulticast
@@ -1493,6 +1635,40 @@ func (c *Client) doSetup(
 		header["Require"] = base.HeaderValue{"www.onvif.org/ver20/backchannel"}
 	}
 
+	// the key of the SDP is used by its author to encrypt outgoing packets.
+	// when playing, generate a key for packets sent to the server.
+	var srtpSentKey *srtp.Key
+
+	if medi.Secure {
+		if c.state == clientStatePreRecord {
+			srtpSentKey = medi.SRTPKey
+		} else {
+			srtpSentKey, err = generateSRTPKeyFor(medi)
+			if err != nil {
+				cm.close()
+				return nil, err
+			}
+		}
+
+		header["KeyMgmt"] = marshalKeyMgmt(mediaURL, srtpSentKey)
+	}
+
+	// RTSP 2.0: requests sent before receiving the session ID
+	// are bound to the session created by the first SETUP request.
+	if c.effectiveVersion == base.Version20 {
+		if c.session == "" && c.pipelinedRequests == "" {
+			c.pipelinedRequests, err = generatePipelinedRequestsID()
+			if err != nil {
+				cm.close()
+				return nil, err
+			}
+		}
+
+		if c.pipelinedRequests != "" {
+			header["Pipelined-Requests"] = base.HeaderValue{c.pipelinedRequests}
+		}
+	}
+
 	res, err := c.do(&base.Request{
 		Method: base.Setup,
 		URL:    mediaURL,
@@ -1518,6 +1694,34 @@ func (c *Client) doSetup(
 		return nil, liberrors.ErrClientBadStatusCode{Code: res.StatusCode, Message: res.StatusMessage}
 	}
 
+	err = c.readMediaProperties(res)
+	if err != nil {
+		cm.close()
+		return nil, err
+	}
+
+	if medi.Secure {
+		var srtpInKey *srtp.Key
+		var srtpOutKey *srtp.Key
+		srtpInKey, srtpOutKey, err = clientSRTPKeys(res, medi, srtpSentKey, c.state == clientStatePreRecord)
+		if err != nil {
+			cm.close()
+			return nil, err
+		}
+
+		cm.srtpInCtx, err = newSRTPContext(srtpInKey)
+		if err != nil {
+			cm.close()
+			return nil, err
+		}
+
+		cm.srtpOutCtx, err = newSRTPContext(srtpOutKey)
+		if err != nil {
+			cm.close()
+			return nil, err
+		}
+	}
+
 	var thRes headers.Transport
 	err = thRes.Unmarshal(res.Header["Transport"])
 	if err != nil {
@@ -1525,6 +1729,11 @@ func (c *Client) doSetup(
 		return nil, liberrors.ErrClientTransportHeaderInvalid{Err: err}
 	}
 
+	if thRes.Profile != th.Profile {
+		cm.close()
+		return nil, liberrors.ErrClientTransportHeaderInvalidProfile{}
+	}
+
 	switch desiredTransport {
 	case TransportUDP, TransportUDPMulticast:
 		if thRes.Protocol == headers.TransportProtocolTCP {
@@ -1548,6 +1757,11 @@ func (c *Client) doSetup(
 			return nil, liberrors.ErrClientTransportHeaderInvalidDelivery{}
 		}
 
+		// RTSP 2.0 servers provide ports through src_addr
+		if thRes.ServerPorts == nil {
+			thRes.ServerPorts = transportAddrPorts(thRes.SrcAddr)
+		}
+
 		serverPortsValid := thRes.ServerPorts != nil && !isAnyPort(thRes.ServerPorts[0]) && !isAnyPort(thRes.ServerPorts[1])
 
 		if (c.state == clientStatePreRecord || !c.AnyPortEnable) && !serverPortsValid {
@@ -1678,6 +1892,22 @@ func (c *Client) doSetup(
 	return res, nil
 }
 
+func (c *Client) readMediaProperties(res *base.Response) error {
+	v, ok := res.Header["Media-Properties"]
+	if !ok {
+		return nil
+	}
+
+	var mp headers.MediaProperties
+	err := mp.Unmarshal(v)
+	if err != nil {
+		return liberrors.ErrClientMediaPropertiesHeaderInvalid{Err: err}
+	}
+
+	c.mediaProperties = &mp
+	return nil
+}
+
 func (c *Client) isChannelPairInUse(channel int) bool {
 	for _, cm := range c.setuppedMedias {
 		if (cm.tcpChannel+1) == channel || cm.tcpChannel == channel || cm.tcpChannel == (channel+1) {
@@ -1761,6 +1991,10 @@ func (c *Client) doPlay(ra *headers.Range) (*base.Response, error) {
 		header["Require"] = base.HeaderValue{"www.onvif.org/ver20/backchannel"}
 	}
 
+	if c.pipelinedRequests != "" {
+		header["Pipelined-Requests"] = base.HeaderValue{c.pipelinedRequests}
+	}
+
 	res, err := c.do(&base.Request{
 		Method: base.Play,
 		URL:    c.baseURL,
@@ -1782,6 +2016,14 @@ func (c *Client) doPlay(ra *headers.Range) (*base.Response, error) {
 		}
 	}
 
+	err = c.readMediaProperties(res)
+	if err != nil {
+		c.destroyWriter()
+		c.stopTransportRoutines()
+		c.state = clientStatePrePlay
+		return nil, err
+	}
+
 	// open the firewall by sending empty packets to the counterpart.
 	// do this before sending the request.
 	// don't do this with multicast, otherwise the RTP packet is going to be broadcasted
@@ -1790,9 +2032,15 @@ func (c *Client) doPlay(ra *headers.Range) (*base.Response, error) {
 		for _, cm := range c.setuppedMedias {
 			if !cm.media.IsBackChannel {
 				byts, _ := (&rtp.Packet{Header: rtp.Header{Version: 2}}).Marshal()
+				if cm.srtpOutCtx != nil {
+					byts, _ = cm.srtpOutCtx.EncryptRTP(byts)
+				}
 				cm.udpRTPListener.write(byts) //nolint:errcheck
 
 				byts, _ = (&rtcp.ReceiverReport{}).Marshal()
+				if cm.srtpOutCtx != nil {
+					byts, _ = cm.srtpOutCtx.EncryptRTCP(byts)
+				}
 				cm.udpRTCPListener.write(byts) //nolint:errcheck
 			}
 		}
@@ -1925,6 +2173,24 @@ func (c *Client) Pause() (*base.Response, error) {
 	}
 }
 
+// processGoodbye is called when the server sends a RTCP BYE packet.
+// The session is closed when all the SSRCs of the server have left.
+func (c *Client) processGoodbye(bye *rtcp.Goodbye) {
+	peerSSRCs := make(map[uint32]struct{})
+	for _, cm := range c.setuppedMedias {
+		cm.addRemoteSSRCs(peerSSRCs)
+	}
+
+	if !c.goodbyeTracker.process(bye, peerSSRCs) {
+		return
+	}
+
+	select {
+	case c.chGoodbye <- struct{}{}:
+	default:
+	}
+}
+
 // Seek asks the server to re-start the stream from a specific timestamp.
 func (c *Client) Seek(ra *headers.Range) (*base.Response, error) {
 	_, err := c.Pause()
@@ -2003,6 +2269,17 @@ func (c *Client) WritePacketRTPWithNTP(medi *description.Media, pkt *rtp.Packet,
 
 	cf.rtcpSender.ProcessPacket(pkt, ntp, cf.format.PTSEqualsDTS(pkt))
 
+	if cf.rtxSender != nil {
+		cf.rtxSender.ProcessPacket(pkt)
+	}
+
+	if cm.srtpOutCtx != nil {
+		byts, err = cm.srtpOutCtx.EncryptRTP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	ok := c.writer.push(func() error {
 		return cf.writePacketRTPInQueue(byts)
 	})
@@ -2035,6 +2312,13 @@ func (c *Client) WritePacketRTCP(medi *description.Media, pkt rtcp.Packet) error
 
 	cm := c.setuppedMedias[medi]
 
+	if cm.srtpOutCtx != nil {
+		byts, err = cm.srtpOutCtx.EncryptRTCP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	ok := c.writer.push(func() error {
 		return cm.writePacketRTCPInQueue(byts)
 	})
@@ -2045,6 +2329,49 @@ func (c *Client) WritePacketRTCP(medi *description.Media, pkt rtcp.Packet) error
 	return nil
 }
 
+// RequestKeyFrame asks the server to send a key frame of a media,
+// by sending a RTCP Picture Loss Indication.
+// This can be called only after Play().
+func (c *Client) RequestKeyFrame(medi *description.Media) error {
+	return c.requestKeyFrame(medi, false)
+}
+
+// RequestKeyFrameFIR asks the server to send a key frame of a media,
+// by sending a RTCP Full Intra Request.
+// This can be called only after Play().
+func (c *Client) RequestKeyFrameFIR(medi *description.Media) error {
+	return c.requestKeyFrame(medi, true)
+}
+
+func (c *Client) requestKeyFrame(medi *description.Media, fir bool) error {
+	cm, ok := c.setuppedMedias[medi]
+	if !ok {
+		return liberrors.ErrClientMediaNotSetup{}
+	}
+
+	sent := false
+
+	// formats that have not received any packet yet are skipped
+	for _, cf := range cm.formats {
+		if cf.rtcpReceiver != nil {
+			err := cf.writeKeyFrameRequest(fir)
+			if err != nil {
+				if _, ok := err.(liberrors.ErrClientRemoteSSRCUnknown); ok {
+					continue
+				}
+				return err
+			}
+			sent = true
+		}
+	}
+
+	if !sent {
+		return liberrors.ErrClientRemoteSSRCUnknown{}
+	}
+
+	return nil
+}
+
 // PacketPTS returns the PTS of an incoming RTP packet.
 // It is computed by decoding the packet timestamp and sychronizing it with other tracks.
 //
@@ -2077,6 +2404,14 @@ func (c *Client) PacketNTP(medi *description.Media, pkt *rtp.Packet) (time.Time,
 	return ct.rtcpReceiver.PacketNTP(pkt.Timestamp)
 }
 
+// MediaProperties returns the properties of the medias that are being read,
+// advertised by RTSP 2.0 servers through the Media-Properties header.
+// It returns nil when they are not available.
+// This can be called only after Setup().
+func (c *Client) MediaProperties() *headers.MediaProperties {
+	return c.mediaProperties
+}
+
 // Stats returns client statistics.
 func (c *Client) Stats() *ClientStats {
 	return &ClientStats{
@@ -2200,6 +2535,12 @@ func (c *Client) Stats() *ClientStats {
 									}
 									return nil
 								}()
+								receiverStats := func() *rtcpsender.ReceiverStats {
+									if sentStats != nil {
+										return latestReceiverStats(sentStats.Receivers)
+									}
+									return nil
+								}()
 
 								ret[fo.format] = StatsSessionFormat{ //nolint:dupl
 									RTPPacketsReceived: atomic.LoadUint64(fo.rtpPacketsReceived),
@@ -2253,6 +2594,30 @@ func (c *Client) Stats() *ClientStats {
 										}
 										return 0
 									}(),
+									RemoteRTPPacketsFractionLost: func() float64 {
+										if receiverStats != nil {
+											return receiverStats.FractionLost
+										}
+										return 0
+									}(),
+									RemoteRTPPacketsLost: func() uint64 {
+										if receiverStats != nil {
+											return uint64(receiverStats.TotalLost)
+										}
+										return 0
+									}(),
+									RemoteRTPPacketsJitter: func() float64 {
+										if receiverStats != nil {
+											return receiverStats.Jitter
+										}
+										return 0
+									}(),
+									RTT: func() time.Duration {
+										if receiverStats != nil {
+											return receiverStats.RTT
+										}
+										return 0
+									}(),
 								}
 							}
 
@@ -8,10 +8,12 @@ import (
 	"github.com/pion/rtp"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpreceiver"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtplossdetector"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtpreorderer"
+	"github.com/frostyfridge/gortsplib/v4/pkg/rtx"
 )
 
 type clientFormat struct {
@@ -19,20 +21,25 @@ type clientFormat struct {
 	format      format.Format
 	onPacketRTP OnPacketRTPFunc
 
+	rtxFormat             *format.RTX                   // retransmission format of this format
+	rtxAssociated         *clientFormat                 // format retransmitted by this format
 	udpReorderer          *rtpreorderer.Reorderer       // play
 	tcpLossDetector       *rtplossdetector.LossDetector // play
 	rtcpReceiver          *rtcpreceiver.RTCPReceiver    // play
 	rtcpSender            *rtcpsender.RTCPSender        // record or back channel
+	rtxSender             *rtx.Sender                   // record or back channel
 	writePacketRTPInQueue func([]byte) error
 	rtpPacketsReceived    *uint64
 	rtpPacketsSent        *uint64
 	rtpPacketsLost        *uint64
+	firSeqNum             *uint32
 }
 
 func (cf *clientFormat) initialize() {
 	cf.rtpPacketsReceived = new(uint64)
 	cf.rtpPacketsSent = new(uint64)
 	cf.rtpPacketsLost = new(uint64)
+	cf.firSeqNum = new(uint32)
 }
 
 func (cf *clientFormat) start() {
@@ -54,9 +61,22 @@ func (cf *clientFormat) start() {
 			},
 		}
 		cf.rtcpSender.Initialize()
+
+		if cf.rtxFormat != nil && *cf.cm.c.effectiveTransport == TransportUDP {
+			cf.rtxSender = &rtx.Sender{
+				PayloadType: cf.rtxFormat.PayloadTyp,
+			}
+			err := cf.rtxSender.Initialize()
+			if err != nil {
+				panic(err)
+			}
+		}
 	} else {
 		if cf.cm.udpRTPListener != nil {
 			cf.udpReorderer = &rtpreorderer.Reorderer{}
+			if cf.rtxFormat != nil && *cf.cm.c.effectiveTransport == TransportUDP {
+				cf.udpReorderer.OnMissing = cf.writeNACK
+			}
 			cf.udpReorderer.Initialize()
 		} else {
 			cf.tcpLossDetector = &rtplossdetector.LossDetector{}
@@ -91,6 +111,15 @@ func (cf *clientFormat) stop() {
 }
 
 func (cf *clientFormat) readPacketRTPUDP(pkt *rtp.Packet) {
+	if cf.rtxAssociated != nil {
+		cf.readRetransmissionUDP(pkt)
+		return
+	}
+
+	if cf.cm.fecDecoder != nil {
+		cf.cm.fecDecoder.AddMedia(pkt)
+	}
+
 	packets, lost := cf.udpReorderer.Process(pkt)
 	if lost != 0 {
 		cf.handlePacketsLost(uint64(lost))
@@ -104,7 +133,64 @@ func (cf *clientFormat) readPacketRTPUDP(pkt *rtp.Packet) {
 	}
 }
 
+func (cf *clientFormat) readRetransmissionUDP(pkt *rtp.Packet) {
+	stats := cf.rtxAssociated.rtcpReceiver.Stats()
+	if stats == nil {
+		return
+	}
+
+	orig, err := rtx.Decode(pkt, cf.rtxAssociated.format.PayloadType(), stats.RemoteSSRC)
+	if err != nil {
+		cf.cm.onPacketRTPDecodeError(err)
+		return
+	}
+
+	cf.rtxAssociated.readPacketRTPUDP(orig)
+}
+
+func (cf *clientFormat) writeNACK(seqNums []uint16) {
+	stats := cf.rtcpReceiver.Stats()
+	if stats == nil {
+		return
+	}
+
+	cf.cm.c.WritePacketRTCP(cf.cm.media, &rtcp.TransportLayerNack{ //nolint:errcheck
+		SenderSSRC: *cf.rtcpReceiver.LocalSSRC,
+		MediaSSRC:  stats.RemoteSSRC,
+		Nacks:      rtcp.NackPairsFromSequenceNumbers(seqNums),
+	})
+}
+
+func (cf *clientFormat) writeKeyFrameRequest(fir bool) error {
+	stats := cf.rtcpReceiver.Stats()
+	if stats == nil {
+		return liberrors.ErrClientRemoteSSRCUnknown{}
+	}
+
+	if fir {
+		// RFC5104, the sequence number is increased by one for each new request
+		seqNum := uint8(atomic.AddUint32(cf.firSeqNum, 1))
+
+		return cf.cm.c.WritePacketRTCP(cf.cm.media, &rtcp.FullIntraRequest{
+			SenderSSRC: *cf.rtcpReceiver.LocalSSRC,
+			FIR: []rtcp.FIREntry{{
+				SSRC:           stats.RemoteSSRC,
+				SequenceNumber: seqNum,
+			}},
+		})
+	}
+
+	return cf.cm.c.WritePacketRTCP(cf.cm.media, &rtcp.PictureLossIndication{
+		SenderSSRC: *cf.rtcpReceiver.LocalSSRC,
+		MediaSSRC:  stats.RemoteSSRC,
+	})
+}
+
 func (cf *clientFormat) readPacketRTPTCP(pkt *rtp.Packet) {
+	if cf.cm.fecDecoder != nil {
+		cf.cm.fecDecoder.AddMedia(pkt)
+	}
+
 	lost := cf.tcpLossDetector.Process(pkt)
 	if lost != 0 {
 		cf.handlePacketsLost(uint64(lost))
@@ -126,6 +212,10 @@ func (cf *clientFormat) handlePacketRTP(pkt *rtp.Packet, now time.Time) {
 	atomic.AddUint64(cf.rtpPacketsReceived, 1)
 
 	cf.onPacketRTP(pkt)
+
+	if cf.cm.fecProtected != nil {
+		cf.cm.fecProtected.readPacketFEC(pkt)
+	}
 }
 
 func (cf *clientFormat) handlePacketsLost(lost uint64) {
@@ -2,6 +2,7 @@ package gortsplib
 
 import (
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -9,7 +10,10 @@ import (
 	"github.com/pion/rtp"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpulpfec"
 	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 type clientMedia struct {
@@ -18,6 +22,11 @@ type clientMedia struct {
 
 	onPacketRTCP           OnPacketRTCPFunc
 	formats                map[uint8]*clientFormat
+	srtpInCtx              *srtp.Context
+	srtpOutCtx             *srtp.Context
+	fecDecoder             *rtpulpfec.Decoder // play, when the media is protected by FEC
+	fecProtected           *clientMedia       // play, when the media carries FEC packets
+	fecMutex               sync.Mutex
 	tcpChannel             int
 	udpRTPListener         *clientUDPListener
 	udpRTCPListener        *clientUDPListener
@@ -50,6 +59,15 @@ func (cm *clientMedia) initialize() {
 		f.initialize()
 		cm.formats[forma.PayloadType()] = f
 	}
+
+	for _, forma := range cm.media.Formats {
+		if rtxFormat, ok := forma.(*format.RTX); ok {
+			if associated, ok2 := cm.formats[rtxFormat.AssociatedPayloadType]; ok2 {
+				associated.rtxFormat = rtxFormat
+				cm.formats[rtxFormat.PayloadTyp].rtxAssociated = associated
+			}
+		}
+	}
 }
 
 func (cm *clientMedia) close() {
@@ -156,6 +174,68 @@ func (cm *clientMedia) findFormatBySSRC(ssrc uint32) *clientFormat {
 	return nil
 }
 
+func (cm *clientMedia) writeRetransmissions(nack *rtcp.TransportLayerNack) {
+	for _, cf := range cm.formats {
+		if cf.rtxSender != nil {
+			for _, pkt := range cf.rtxSender.ProcessNACK(nack) {
+				cm.c.WritePacketRTP(cm.media, pkt) //nolint:errcheck
+			}
+		}
+	}
+}
+
+// addRemoteSSRCs adds the SSRCs used by the server to send or receive packets.
+func (cm *clientMedia) addRemoteSSRCs(ssrcs map[uint32]struct{}) {
+	for _, cf := range cm.formats {
+		if cf.rtcpReceiver != nil {
+			if stats := cf.rtcpReceiver.Stats(); stats != nil {
+				ssrcs[stats.RemoteSSRC] = struct{}{}
+			}
+		}
+
+		if cf.rtcpSender != nil {
+			if stats := cf.rtcpSender.Stats(); stats != nil {
+				for ssrc := range stats.Receivers {
+					ssrcs[ssrc] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+func (cm *clientMedia) processReceiverReport(rr *rtcp.ReceiverReport, now time.Time) {
+	for _, cf := range cm.formats {
+		if cf.rtcpSender != nil {
+			cf.rtcpSender.ProcessReceiverReport(rr, now)
+		}
+	}
+}
+
+func (cm *clientMedia) readPacketFEC(pkt *rtp.Packet) {
+	cm.fecMutex.Lock()
+	defer cm.fecMutex.Unlock()
+
+	recovered, err := cm.fecDecoder.Decode(pkt)
+	if err != nil {
+		cm.onPacketRTPDecodeError(err)
+		return
+	}
+
+	for _, pkt := range recovered {
+		forma, ok := cm.formats[pkt.PayloadType]
+		if !ok {
+			cm.onPacketRTPDecodeError(liberrors.ErrClientRTPPacketUnknownPayloadType{PayloadType: pkt.PayloadType})
+			continue
+		}
+
+		if forma.udpReorderer != nil {
+			forma.readPacketRTPUDP(pkt)
+		} else {
+			forma.readPacketRTPTCP(pkt)
+		}
+	}
+}
+
 func (cm *clientMedia) writePacketRTCPInQueueUDP(payload []byte) error {
 	err := cm.udpRTCPListener.write(payload)
 	if err != nil {
@@ -187,6 +267,11 @@ func (cm *clientMedia) readPacketRTPTCPPlay(payload []byte) bool {
 	now := cm.c.timeNow()
 	atomic.StoreInt64(cm.c.tcpLastFrameTime, now.Unix())
 
+	payload, ok := cm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -200,6 +285,12 @@ func (cm *clientMedia) readPacketRTPTCPPlay(payload []byte) bool {
 		return false
 	}
 
+	// packets of medias protected by FEC are also accessed by the routine of the FEC media
+	if cm.fecDecoder != nil {
+		cm.fecMutex.Lock()
+		defer cm.fecMutex.Unlock()
+	}
+
 	forma.readPacketRTPTCP(pkt)
 
 	return true
@@ -216,6 +307,11 @@ func (cm *clientMedia) readPacketRTCPTCPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := cm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		cm.onPacketRTCPDecodeError(err)
@@ -225,11 +321,18 @@ func (cm *clientMedia) readPacketRTCPTCPPlay(payload []byte) bool {
 	atomic.AddUint64(cm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
-		if sr, ok := pkt.(*rtcp.SenderReport); ok {
-			format := cm.findFormatBySSRC(sr.SSRC)
+		switch pkt := pkt.(type) {
+		case *rtcp.SenderReport:
+			format := cm.findFormatBySSRC(pkt.SSRC)
 			if format != nil {
-				format.rtcpReceiver.ProcessSenderReport(sr, now)
+				format.rtcpReceiver.ProcessSenderReport(pkt, now)
 			}
+
+		case *rtcp.ReceiverReport:
+			cm.processReceiverReport(pkt, now)
+
+		case *rtcp.Goodbye:
+			cm.c.processGoodbye(pkt)
 		}
 
 		cm.onPacketRTCP(pkt)
@@ -250,15 +353,30 @@ func (cm *clientMedia) readPacketRTCPTCPRecord(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := cm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		cm.onPacketRTCPDecodeError(err)
 		return false
 	}
 
+	now := cm.c.timeNow()
+
 	atomic.AddUint64(cm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
+		switch pkt := pkt.(type) {
+		case *rtcp.ReceiverReport:
+			cm.processReceiverReport(pkt, now)
+
+		case *rtcp.Goodbye:
+			cm.c.processGoodbye(pkt)
+		}
+
 		cm.onPacketRTCP(pkt)
 	}
 
@@ -273,6 +391,11 @@ func (cm *clientMedia) readPacketRTPUDPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := cm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -286,6 +409,12 @@ func (cm *clientMedia) readPacketRTPUDPPlay(payload []byte) bool {
 		return false
 	}
 
+	// packets of medias protected by FEC are also accessed by the routine of the FEC media
+	if cm.fecDecoder != nil {
+		cm.fecMutex.Lock()
+		defer cm.fecMutex.Unlock()
+	}
+
 	forma.readPacketRTPUDP(pkt)
 
 	return true
@@ -299,6 +428,11 @@ func (cm *clientMedia) readPacketRTCPUDPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := cm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		cm.onPacketRTCPDecodeError(err)
@@ -310,11 +444,18 @@ func (cm *clientMedia) readPacketRTCPUDPPlay(payload []byte) bool {
 	atomic.AddUint64(cm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
-		if sr, ok := pkt.(*rtcp.SenderReport); ok {
-			format := cm.findFormatBySSRC(sr.SSRC)
+		switch pkt := pkt.(type) {
+		case *rtcp.SenderReport:
+			format := cm.findFormatBySSRC(pkt.SSRC)
 			if format != nil {
-				format.rtcpReceiver.ProcessSenderReport(sr, now)
+				format.rtcpReceiver.ProcessSenderReport(pkt, now)
 			}
+
+		case *rtcp.ReceiverReport:
+			cm.processReceiverReport(pkt, now)
+
+		case *rtcp.Goodbye:
+			cm.c.processGoodbye(pkt)
 		}
 
 		cm.onPacketRTCP(pkt)
@@ -335,21 +476,67 @@ func (cm *clientMedia) readPacketRTCPUDPRecord(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := cm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		cm.onPacketRTCPDecodeError(err)
 		return false
 	}
 
+	now := cm.c.timeNow()
+
 	atomic.AddUint64(cm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
+		switch pkt := pkt.(type) {
+		case *rtcp.TransportLayerNack:
+			cm.writeRetransmissions(pkt)
+
+		case *rtcp.ReceiverReport:
+			cm.processReceiverReport(pkt, now)
+
+		case *rtcp.Goodbye:
+			cm.c.processGoodbye(pkt)
+		}
+
 		cm.onPacketRTCP(pkt)
 	}
 
 	return true
 }
 
+func (cm *clientMedia) decryptPacketRTP(payload []byte) ([]byte, bool) {
+	if cm.srtpInCtx == nil {
+		return payload, true
+	}
+
+	payload, err := cm.srtpInCtx.DecryptRTP(payload)
+	if err != nil {
+		cm.onPacketRTPDecodeError(err)
+		return nil, false
+	}
+
+	return payload, true
+}
+
+func (cm *clientMedia) decryptPacketRTCP(payload []byte) ([]byte, bool) {
+	if cm.srtpInCtx == nil {
+		return payload, true
+	}
+
+	payload, err := cm.srtpInCtx.DecryptRTCP(payload)
+	if err != nil {
+		cm.onPacketRTCPDecodeError(err)
+		return nil, false
+	}
+
+	return payload, true
+}
+
 func (cm *clientMedia) onPacketRTPDecodeError(err error) {
 	atomic.AddUint64(cm.rtpPacketsInError, 1)
 	cm.c.OnDecodeError(err)
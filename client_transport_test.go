@@ -47,7 +47,7 @@ func TestClientTransportSecurityForced(t *testing.T) {
 			}
 
 			// connOpen should fail because non-TCP transport isn't allowed with secure schemes
-			err = client.connOpen()
+			err = client.connOpen(nil)
 			require.Error(t, err)
 			errMsg := err.Error()
 			require.Contains(t, errMsg, "can't be used with a non-TCP transport protocol")
@@ -77,7 +77,7 @@ func TestClientTransportSecurityForced(t *testing.T) {
 		}
 
 		// This should error with connection error, not transport error
-		err = client.connOpen()
+		err = client.connOpen(nil)
 		require.Error(t, err)
 		_, ok := err.(liberrors.ErrClientRTSPSTCP)
 		require.False(t, ok, "Error should not be ErrClientRTSPSTCP")
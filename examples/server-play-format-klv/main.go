@@ -0,0 +1,195 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/frostyfridge/gortsplib/v4"
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+// This example shows how to
+// 1. create a RTSP server which accepts plain connections.
+// 2. generate KLV metadata (a MISB ST 0601 set containing a timestamp).
+// 3. serve the metadata to all connected readers.
+
+// key of MISB ST 0601 UAS Datalink Local Sets.
+var misb0601Key = []byte{
+	0x06, 0x0e, 0x2b, 0x34, 0x02, 0x0b, 0x01, 0x01,
+	0x0e, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00,
+}
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// generateSet generates a KLV set that contains
+// the precision time stamp (tag 2) in microseconds since the UNIX epoch.
+func generateSet(t time.Time) []byte {
+	value := make([]byte, 10)
+	value[0] = 2 // tag
+	value[1] = 8 // length
+	binary.BigEndian.PutUint64(value[2:], uint64(t.UnixMicro()))
+
+	set := append([]byte(nil), misb0601Key...)
+	set = append(set, byte(len(value)))
+	return append(set, value...)
+}
+
+type serverHandler struct {
+	server *gortsplib.Server
+	stream *gortsplib.ServerStream
+	mutex  sync.RWMutex
+}
+
+// called when a connection is opened.
+func (sh *serverHandler) OnConnOpen(ctx *gortsplib.ServerHandlerOnConnOpenCtx) {
+	log.Printf("conn opened")
+}
+
+// called when a connection is closed.
+func (sh *serverHandler) OnConnClose(ctx *gortsplib.ServerHandlerOnConnCloseCtx) {
+	log.Printf("conn closed (%v)", ctx.Error)
+}
+
+// called when a session is opened.
+func (sh *serverHandler) OnSessionOpen(ctx *gortsplib.ServerHandlerOnSessionOpenCtx) {
+	log.Printf("session opened")
+}
+
+// called when a session is closed.
+func (sh *serverHandler) OnSessionClose(ctx *gortsplib.ServerHandlerOnSessionCloseCtx) {
+	log.Printf("session closed")
+}
+
+// called when receiving a DESCRIBE request.
+func (sh *serverHandler) OnDescribe(ctx *gortsplib.ServerHandlerOnDescribeCtx) (*base.Response, *gortsplib.ServerStream, error) {
+	log.Printf("DESCRIBE request")
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, sh.stream, nil
+}
+
+// called when receiving a SETUP request.
+func (sh *serverHandler) OnSetup(ctx *gortsplib.ServerHandlerOnSetupCtx) (*base.Response, *gortsplib.ServerStream, error) {
+	log.Printf("SETUP request")
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, sh.stream, nil
+}
+
+// called when receiving a PLAY request.
+func (sh *serverHandler) OnPlay(ctx *gortsplib.ServerHandlerOnPlayCtx) (*base.Response, error) {
+	log.Printf("PLAY request")
+
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, nil
+}
+
+func main() {
+	h := &serverHandler{}
+
+	// prevent clients from connecting to the server until the stream is properly set up
+	h.mutex.Lock()
+
+	// create the server
+	h.server = &gortsplib.Server{
+		Handler:           h,
+		RTSPAddress:       ":8554",
+		UDPRTPAddress:     ":8000",
+		UDPRTCPAddress:    ":8001",
+		MulticastIPRange:  "224.1.0.0/16",
+		MulticastRTPPort:  8002,
+		MulticastRTCPPort: 8003,
+	}
+
+	// start the server
+	err := h.server.Start()
+	if err != nil {
+		panic(err)
+	}
+	defer h.server.Close()
+
+	// create a RTSP description that contains a KLV format
+	forma := &format.KLV{
+		PayloadTyp: 96,
+		ClockRat:   90000,
+	}
+	desc := &description.Session{
+		Medias: []*description.Media{{
+			Type:    description.MediaTypeApplication,
+			Formats: []format.Format{forma},
+		}},
+	}
+
+	// create a server stream
+	h.stream = &gortsplib.ServerStream{
+		Server: h.server,
+		Desc:   desc,
+	}
+	err = h.stream.Initialize()
+	if err != nil {
+		panic(err)
+	}
+	defer h.stream.Close()
+
+	// setup KLV -> RTP encoder
+	rtpEnc, err := forma.CreateEncoder()
+	if err != nil {
+		panic(err)
+	}
+
+	randomStart, err := randUint32()
+	if err != nil {
+		panic(err)
+	}
+
+	// allow clients to connect
+	h.mutex.Unlock()
+
+	log.Printf("server is ready on %s", h.server.RTSPAddress)
+
+	start := time.Now()
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		// encode the KLV set into RTP packets
+		pkts, err := rtpEnc.Encode([][]byte{generateSet(now)})
+		if err != nil {
+			panic(err)
+		}
+
+		for _, pkt := range pkts {
+			pkt.Timestamp += randomStart + uint32(now.Sub(start).Seconds()*90000)
+
+			// write RTP packets to all connected readers
+			err = h.stream.WritePacketRTPWithNTP(desc.Medias[0], pkt, now)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gortsplib
+
+import (
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+func isFECMedia(medi *description.Media) bool {
+	for _, forma := range medi.Formats {
+		if _, ok := forma.(*format.ULPFEC); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fecGroupContains(group description.SessionFECGroup, id string) bool {
+	for _, cur := range group {
+		if cur == id {
+			return true
+		}
+	}
+	return false
+}
+
+// fecMediaPairs returns the medias protected by FEC, associated with the media that carries FEC packets.
+// FEC packets do not contain the SSRC of the protected stream,
+// therefore only groups that protect a single media are taken into account.
+func fecMediaPairs(
+	groups []description.SessionFECGroup,
+	medias []*description.Media,
+) map[*description.Media]*description.Media {
+	ret := make(map[*description.Media]*description.Media)
+
+	for _, group := range groups {
+		var fecMedia *description.Media
+		var protected []*description.Media
+
+		for _, medi := range medias {
+			if medi.ID == "" || !fecGroupContains(group, medi.ID) {
+				continue
+			}
+
+			if isFECMedia(medi) {
+				fecMedia = medi
+			} else {
+				protected = append(protected, medi)
+			}
+		}
+
+		if fecMedia != nil && len(protected) == 1 {
+			ret[protected[0]] = fecMedia
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,221 @@
+package gortsplib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+func testFECSession() *description.Session {
+	return &description.Session{
+		FECGroups: []description.SessionFECGroup{{"1", "2"}},
+		Medias: []*description.Media{
+			{
+				ID:   "1",
+				Type: description.MediaTypeVideo,
+				Formats: []format.Format{&format.H264{
+					PayloadTyp:        96,
+					PacketizationMode: 1,
+				}},
+			},
+			{
+				ID:   "2",
+				Type: description.MediaTypeApplication,
+				Formats: []format.Format{&format.ULPFEC{
+					PayloadTyp: 97,
+					ClockRat:   90000,
+				}},
+			},
+		},
+	}
+}
+
+func testFECPackets(t *testing.T) ([]*rtp.Packet, *rtp.Packet) {
+	var media []*rtp.Packet
+
+	for i := uint16(0); i < 3; i++ {
+		media = append(media, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 100 + i,
+				Timestamp:      54352,
+				SSRC:           753621,
+			},
+			Payload: []byte{0x05, byte(i)},
+		})
+	}
+
+	enc, err := (&format.ULPFEC{PayloadTyp: 97, ClockRat: 90000}).CreateEncoder()
+	require.NoError(t, err)
+
+	fec, err := enc.Encode(media)
+	require.NoError(t, err)
+
+	return media, fec
+}
+
+func TestFECClientPlay(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:    "localhost:8554",
+		UDPRTPAddress:  "127.0.0.1:8000",
+		UDPRTCPAddress: "127.0.0.1:8001",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc:   testFECSession(),
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	recv := make(chan *rtp.Packet, 3)
+
+	c := Client{
+		Transport: func() *Transport {
+			v := TransportUDP
+			return &v
+		}(),
+	}
+
+	err = readAll(&c, "rtsp://localhost:8554/teststream",
+		func(_ *description.Media, forma format.Format, pkt *rtp.Packet) {
+			if _, ok := forma.(*format.H264); ok {
+				recv <- pkt
+			}
+		})
+	require.NoError(t, err)
+	defer c.Close()
+
+	media, fec := testFECPackets(t)
+
+	// packet 101 is lost
+	for _, i := range []int{0, 2} {
+		err = stream.WritePacketRTP(stream.Desc.Medias[0], media[i])
+		require.NoError(t, err)
+	}
+
+	// wait for packets to be received before sending the FEC packet
+	time.Sleep(100 * time.Millisecond)
+
+	err = stream.WritePacketRTP(stream.Desc.Medias[1], fec)
+	require.NoError(t, err)
+
+	for _, orig := range media {
+		pkt := <-recv
+		require.Equal(t, orig.SequenceNumber, pkt.SequenceNumber)
+		require.Equal(t, orig.Payload, pkt.Payload)
+	}
+}
+
+func TestFECServerRecord(t *testing.T) {
+	recv := make(chan *rtp.Packet, 3)
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onAnnounce: func(_ *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil, nil
+			},
+			onRecord: func(ctx *ServerHandlerOnRecordCtx) (*base.Response, error) {
+				ctx.Session.OnPacketRTPAny(func(_ *description.Media, forma format.Format, pkt *rtp.Packet) {
+					if _, ok := forma.(*format.H264); ok {
+						recv <- pkt
+					}
+				})
+
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:    "localhost:8554",
+		UDPRTPAddress:  "127.0.0.1:8000",
+		UDPRTCPAddress: "127.0.0.1:8001",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	desc := testFECSession()
+
+	c := Client{
+		Transport: func() *Transport {
+			v := TransportUDP
+			return &v
+		}(),
+	}
+
+	u, err := base.ParseURL("rtsp://localhost:8554/teststream")
+	require.NoError(t, err)
+
+	err = c.Start(u.Scheme, u.Host)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Announce(u, desc)
+	require.NoError(t, err)
+
+	err = c.SetupAll(u, desc.Medias)
+	require.NoError(t, err)
+
+	_, err = c.Record()
+	require.NoError(t, err)
+
+	media, fec := testFECPackets(t)
+
+	// packet 101 is lost
+	for _, i := range []int{0, 2} {
+		err = c.WritePacketRTP(desc.Medias[0], media[i])
+		require.NoError(t, err)
+	}
+
+	// wait for packets to be received before sending the FEC packet
+	time.Sleep(100 * time.Millisecond)
+
+	err = c.WritePacketRTP(desc.Medias[1], fec)
+	require.NoError(t, err)
+
+	for _, orig := range media {
+		pkt := <-recv
+		require.Equal(t, orig.SequenceNumber, pkt.SequenceNumber)
+		require.Equal(t, orig.Payload, pkt.Payload)
+	}
+}
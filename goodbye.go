@@ -0,0 +1,48 @@
+package gortsplib
+
+import (
+	"sync"
+
+	"github.com/pion/rtcp"
+)
+
+// goodbyeTracker keeps track of the peer SSRCs that sent a RTCP BYE packet.
+type goodbyeTracker struct {
+	mutex sync.Mutex
+	left  map[uint32]struct{}
+}
+
+// process processes a RTCP BYE packet and returns true when all the SSRCs of the peer have left.
+// Sources that do not belong to the peer are ignored.
+func (t *goodbyeTracker) process(bye *rtcp.Goodbye, peerSSRCs map[uint32]struct{}) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(peerSSRCs) == 0 {
+		return false
+	}
+
+	for _, ssrc := range bye.Sources {
+		if _, ok := peerSSRCs[ssrc]; ok {
+			if t.left == nil {
+				t.left = make(map[uint32]struct{})
+			}
+			t.left[ssrc] = struct{}{}
+		}
+	}
+
+	for ssrc := range peerSSRCs {
+		if _, ok := t.left[ssrc]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *goodbyeTracker) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.left = nil
+}
@@ -0,0 +1,26 @@
+package gortsplib
+
+import (
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoodbyeTracker(t *testing.T) {
+	var tr goodbyeTracker
+
+	peerSSRCs := map[uint32]struct{}{
+		1234: {},
+		5678: {},
+	}
+
+	require.False(t, tr.process(&rtcp.Goodbye{Sources: []uint32{1234}}, nil))
+	require.False(t, tr.process(&rtcp.Goodbye{Sources: []uint32{4567}}, peerSSRCs))
+	require.False(t, tr.process(&rtcp.Goodbye{Sources: []uint32{1234}}, peerSSRCs))
+	require.True(t, tr.process(&rtcp.Goodbye{Sources: []uint32{5678}}, peerSSRCs))
+
+	tr.reset()
+	require.False(t, tr.process(&rtcp.Goodbye{Sources: []uint32{5678}}, peerSSRCs))
+	require.True(t, tr.process(&rtcp.Goodbye{Sources: []uint32{1234, 5678}}, peerSSRCs))
+}
@@ -0,0 +1,322 @@
+package gortsplib
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+)
+
+// RTSP-over-HTTP tunneling, as implemented by QuickTime, Axis and Hikvision devices.
+// A tunnel is made of two HTTP connections that share the same x-sessioncookie:
+// * a GET connection, that carries data from the server to the client, in plain text.
+// * a POST connection, that carries data from the client to the server, in base64.
+
+const (
+	httpTunnelContentType    = "application/x-rtsp-tunnelled"
+	httpTunnelCookieName     = "x-sessioncookie"
+	httpTunnelReadBufferSize = 4096
+)
+
+func isHTTPTunnelScheme(scheme string) bool {
+	return scheme == "http" || scheme == "https"
+}
+
+func generateHTTPTunnelCookie() (string, error) {
+	byts := make([]byte, 16)
+	_, err := rand.Read(byts)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(byts), nil
+}
+
+// httpTunnelDecoder decodes a base64 stream in which every chunk is
+// encoded and padded independently.
+type httpTunnelDecoder struct {
+	r io.Reader
+
+	in  [4]byte
+	inN int
+	buf [512]byte
+	out []byte
+}
+
+func (d *httpTunnelDecoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		n, err := d.r.Read(d.buf[:])
+		if n == 0 && err != nil {
+			return 0, err
+		}
+
+		var out []byte
+
+		for _, b := range d.buf[:n] {
+			// skip line breaks and spaces that might be inserted between chunks
+			if b == '\r' || b == '\n' || b == ' ' || b == '\t' {
+				continue
+			}
+
+			d.in[d.inN] = b
+			d.inN++
+
+			if d.inN == 4 {
+				var dec [3]byte
+				decN, err2 := base64.StdEncoding.Decode(dec[:], d.in[:])
+				if err2 != nil {
+					return 0, fmt.Errorf("invalid tunnel data: %w", err2)
+				}
+				out = append(out, dec[:decN]...)
+				d.inN = 0
+			}
+		}
+
+		d.out = out
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// httpTunnelEncoder encodes every write in base64.
+type httpTunnelEncoder struct {
+	w io.Writer
+}
+
+func (e *httpTunnelEncoder) Write(p []byte) (int, error) {
+	buf := make([]byte, base64.StdEncoding.EncodedLen(len(p)))
+	base64.StdEncoding.Encode(buf, p)
+
+	_, err := e.w.Write(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// httpTunnelConn is a net.Conn that joins the two halves of a HTTP tunnel.
+type httpTunnelConn struct {
+	getConn  net.Conn
+	postConn net.Conn
+	r        io.Reader
+	w        io.Writer
+	readConn net.Conn
+	wrConn   net.Conn
+}
+
+func newClientHTTPTunnelConn(getConn net.Conn, getReader io.Reader, postConn net.Conn) *httpTunnelConn {
+	return &httpTunnelConn{
+		getConn:  getConn,
+		postConn: postConn,
+		r:        getReader,
+		w:        &httpTunnelEncoder{w: postConn},
+		readConn: getConn,
+		wrConn:   postConn,
+	}
+}
+
+func newServerHTTPTunnelConn(getConn net.Conn, postConn net.Conn, postReader io.Reader) *httpTunnelConn {
+	return &httpTunnelConn{
+		getConn:  getConn,
+		postConn: postConn,
+		r:        &httpTunnelDecoder{r: postReader},
+		w:        getConn,
+		readConn: postConn,
+		wrConn:   getConn,
+	}
+}
+
+// Read implements net.Conn.
+func (c *httpTunnelConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// Write implements net.Conn.
+func (c *httpTunnelConn) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+// Close implements net.Conn.
+func (c *httpTunnelConn) Close() error {
+	err1 := c.getConn.Close()
+	err2 := c.postConn.Close()
+	if err1 != nil {
+		return err1
+	}
+	return err2
+}
+
+// LocalAddr implements net.Conn.
+func (c *httpTunnelConn) LocalAddr() net.Addr {
+	return c.getConn.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn.
+func (c *httpTunnelConn) RemoteAddr() net.Addr {
+	return c.getConn.RemoteAddr()
+}
+
+// SetDeadline implements net.Conn.
+func (c *httpTunnelConn) SetDeadline(t time.Time) error {
+	err := c.readConn.SetReadDeadline(t)
+	if err != nil {
+		return err
+	}
+	return c.wrConn.SetWriteDeadline(t)
+}
+
+// SetReadDeadline implements net.Conn.
+func (c *httpTunnelConn) SetReadDeadline(t time.Time) error {
+	return c.readConn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements net.Conn.
+func (c *httpTunnelConn) SetWriteDeadline(t time.Time) error {
+	return c.wrConn.SetWriteDeadline(t)
+}
+
+func dialHTTPTunnel(
+	ctx context.Context,
+	dialContext func(ctx context.Context, network, address string) (net.Conn, error),
+	tlsConfig *tls.Config,
+	readTimeout time.Duration,
+	userAgent string,
+	u *base.URL,
+) (net.Conn, error) {
+	cookie, err := generateHTTPTunnelCookie()
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/"
+	if u != nil && u.Path != "" {
+		path = u.Path
+		if u.RawQuery != "" {
+			path += "?" + u.RawQuery
+		}
+	}
+
+	dial := func() (net.Conn, error) {
+		nconn, err2 := dialContext(ctx, "tcp", canonicalAddr(u))
+		if err2 != nil {
+			return nil, err2
+		}
+
+		if u.Scheme == "https" {
+			if tlsConfig == nil {
+				tlsConfig = &tls.Config{}
+			}
+			tlsConfig.ServerName = u.Hostname()
+
+			nconn = tls.Client(nconn, tlsConfig)
+		}
+
+		return nconn, nil
+	}
+
+	getConn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+
+	getConn.SetDeadline(time.Now().Add(readTimeout))
+
+	_, err = getConn.Write([]byte("GET " + path + " HTTP/1.0\r\n" +
+		"User-Agent: " + userAgent + "\r\n" +
+		httpTunnelCookieName + ": " + cookie + "\r\n" +
+		"Accept: " + httpTunnelContentType + "\r\n" +
+		"Pragma: no-cache\r\n" +
+		"Cache-Control: no-cache\r\n" +
+		"\r\n"))
+	if err != nil {
+		getConn.Close()
+		return nil, err
+	}
+
+	getReader := bufio.NewReaderSize(getConn, httpTunnelReadBufferSize)
+
+	res, err := http.ReadResponse(getReader, nil)
+	if err != nil {
+		getConn.Close()
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		getConn.Close()
+		return nil, fmt.Errorf("bad status code while opening HTTP tunnel: %d", res.StatusCode)
+	}
+
+	if ct := res.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, httpTunnelContentType) {
+		getConn.Close()
+		return nil, fmt.Errorf("unsupported Content-Type while opening HTTP tunnel: '%s'", ct)
+	}
+
+	getConn.SetDeadline(time.Time{})
+
+	postConn, err := dial()
+	if err != nil {
+		getConn.Close()
+		return nil, err
+	}
+
+	postConn.SetWriteDeadline(time.Now().Add(readTimeout))
+
+	_, err = postConn.Write([]byte("POST " + path + " HTTP/1.0\r\n" +
+		"User-Agent: " + userAgent + "\r\n" +
+		httpTunnelCookieName + ": " + cookie + "\r\n" +
+		"Content-Type: " + httpTunnelContentType + "\r\n" +
+		"Pragma: no-cache\r\n" +
+		"Cache-Control: no-cache\r\n" +
+		"Content-Length: 32767\r\n" +
+		"Expires: Sun, 9 Jan 1972 00:00:00 GMT\r\n" +
+		"\r\n"))
+	if err != nil {
+		getConn.Close()
+		postConn.Close()
+		return nil, err
+	}
+
+	postConn.SetWriteDeadline(time.Time{})
+
+	return newClientHTTPTunnelConn(getConn, getReader, postConn), nil
+}
+
+// isHTTPTunnelRequest checks whether the first bytes of a connection
+// belong to one of the two halves of a HTTP tunnel.
+// GET_PARAMETER requests are not affected since they don't begin with "GET ".
+func isHTTPTunnelRequest(byts []byte) bool {
+	return strings.HasPrefix(string(byts), "GET ") || strings.HasPrefix(string(byts), "POST")
+}
+
+func writeHTTPTunnelResponse(w io.Writer, statusCode int) error {
+	var buf string
+	if statusCode == http.StatusOK {
+		buf = "HTTP/1.0 200 OK\r\n" +
+			"Server: " + serverHeader + "\r\n" +
+			"Connection: close\r\n" +
+			"Cache-Control: no-store\r\n" +
+			"Pragma: no-cache\r\n" +
+			"Content-Type: " + httpTunnelContentType + "\r\n" +
+			"\r\n"
+	} else {
+		buf = "HTTP/1.0 " + fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)) + "\r\n" +
+			"Server: " + serverHeader + "\r\n" +
+			"Connection: close\r\n" +
+			"\r\n"
+	}
+
+	_, err := w.Write([]byte(buf))
+	return err
+}
@@ -0,0 +1,143 @@
+package gortsplib
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+)
+
+func TestHTTPTunnelDecoder(t *testing.T) {
+	// chunks are encoded and padded independently
+	enc := []byte("T1BU\r\nSU9OUw==\r\nIHJ0c3A6Ly8=")
+
+	d := &httpTunnelDecoder{r: bytes.NewReader(enc)}
+
+	dec, err := io.ReadAll(d)
+	require.NoError(t, err)
+	require.Equal(t, []byte("OPTIONS rtsp://"), dec)
+}
+
+func TestHTTPTunnelDecoderError(t *testing.T) {
+	d := &httpTunnelDecoder{r: bytes.NewReader([]byte("T1B?"))}
+
+	_, err := io.ReadAll(d)
+	require.EqualError(t, err, "invalid tunnel data: illegal base64 data at input byte 3")
+}
+
+func TestHTTPTunnelEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	e := &httpTunnelEncoder{w: &buf}
+
+	n, err := e.Write([]byte("OPTIONS"))
+	require.NoError(t, err)
+	require.Equal(t, 7, n)
+
+	_, err = e.Write([]byte(" rtsp://"))
+	require.NoError(t, err)
+
+	d := &httpTunnelDecoder{r: &buf}
+	dec, err := io.ReadAll(d)
+	require.NoError(t, err)
+	require.Equal(t, []byte("OPTIONS rtsp://"), dec)
+}
+
+func TestHTTPTunnel(t *testing.T) {
+	for _, scheme := range []string{"http", "https"} {
+		t.Run(scheme, func(t *testing.T) {
+			var stream *ServerStream
+			postJoined := make(chan struct{})
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onConnClose: func(ctx *ServerHandlerOnConnCloseCtx) {
+						// the POST half is the only connection that is closed without errors
+						if ctx.Error == nil {
+							close(postJoined)
+						}
+					},
+					onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+				},
+				RTSPAddress: "localhost:8554",
+			}
+
+			if scheme == "https" {
+				cert, err := tls.X509KeyPair(serverCert, serverKey)
+				require.NoError(t, err)
+				s.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			stream = &ServerStream{
+				Server: s,
+				Desc:   &description.Session{Medias: []*description.Media{testH264Media}},
+			}
+			err = stream.Initialize()
+			require.NoError(t, err)
+			defer stream.Close()
+
+			c := Client{
+				TLSConfig: &tls.Config{InsecureSkipVerify: true},
+			}
+
+			err = c.Start(scheme, "localhost:8554")
+			require.NoError(t, err)
+			defer c.Close()
+
+			desc, _, err := c.Describe(mustParseURL("rtsp://localhost:8554/teststream"))
+			require.NoError(t, err)
+
+			err = c.SetupAll(desc.BaseURL, desc.Medias)
+			require.NoError(t, err)
+			require.Equal(t, TransportTCP, *c.effectiveTransport)
+
+			recv := make(chan *rtp.Packet)
+
+			c.OnPacketRTP(desc.Medias[0], desc.Medias[0].Formats[0], func(pkt *rtp.Packet) {
+				recv <- pkt
+			})
+
+			_, err = c.Play(nil)
+			require.NoError(t, err)
+
+			<-postJoined
+
+			err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 1234,
+				},
+				Payload: []byte{5, 1, 2, 3, 4},
+			})
+			require.NoError(t, err)
+
+			pkt := <-recv
+			require.Equal(t, []byte{5, 1, 2, 3, 4}, pkt.Payload)
+			require.Equal(t, uint16(1234), pkt.SequenceNumber)
+		})
+	}
+}
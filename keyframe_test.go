@@ -0,0 +1,194 @@
+package gortsplib
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+)
+
+func TestKeyFrameRequest(t *testing.T) {
+	for _, transport := range []string{
+		"udp",
+		"tcp",
+	} {
+		t.Run(transport, func(t *testing.T) {
+			var s *Server
+			var mutex sync.Mutex
+			var stream *ServerStream
+			var publisher *ServerSession
+			keyFrameRequested := make(chan *ServerHandlerOnKeyFrameRequestCtx, 1)
+
+			s = &Server{
+				Handler: &testServerHandler{
+					onAnnounce: func(ctx *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						stream = &ServerStream{
+							Server: s,
+							Desc:   ctx.Description,
+						}
+						err := stream.Initialize()
+						require.NoError(t, err)
+
+						publisher = ctx.Session
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						mutex.Lock()
+						defer mutex.Unlock()
+
+						if ctx.Session.State() == ServerSessionStatePreRecord {
+							return &base.Response{
+								StatusCode: base.StatusOK,
+							}, nil, nil
+						}
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onRecord: func(ctx *ServerHandlerOnRecordCtx) (*base.Response, error) {
+						ctx.Session.OnPacketRTPAny(func(medi *description.Media, _ format.Format, pkt *rtp.Packet) {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							stream.WritePacketRTP(medi, pkt) //nolint:errcheck
+						})
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onKeyFrameRequest: func(ctx *ServerHandlerOnKeyFrameRequestCtx) {
+						keyFrameRequested <- ctx
+					},
+				},
+				RTSPAddress:    "localhost:8554",
+				UDPRTPAddress:  "127.0.0.1:8000",
+				UDPRTCPAddress: "127.0.0.1:8001",
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			v := TransportUDP
+			if transport == "tcp" {
+				v = TransportTCP
+			}
+
+			keyFrameRequestReceived := make(chan rtcp.Packet, 1)
+
+			source := Client{Transport: &v}
+
+			medias := []*description.Media{testH264Media}
+
+			err = record(&source, "rtsp://localhost:8554/teststream", medias,
+				func(_ *description.Media, pkt rtcp.Packet) {
+					switch pkt.(type) {
+					case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+						keyFrameRequestReceived <- pkt
+					}
+				})
+			require.NoError(t, err)
+			defer source.Close()
+
+			defer func() {
+				mutex.Lock()
+				defer mutex.Unlock()
+				stream.Close()
+			}()
+
+			packetRecv := make(chan struct{}, 1)
+
+			reader := Client{Transport: &v}
+
+			err = readAll(&reader, "rtsp://localhost:8554/teststream",
+				func(_ *description.Media, _ format.Format, _ *rtp.Packet) {
+					select {
+					case packetRecv <- struct{}{}:
+					default:
+					}
+				})
+			require.NoError(t, err)
+			defer reader.Close()
+
+			err = reader.RequestKeyFrame(&description.Media{})
+			require.Equal(t, liberrors.ErrClientMediaNotSetup{}, err)
+
+			err = publisher.RequestKeyFrame(publisher.AnnouncedDescription().Medias[0])
+			require.Equal(t, liberrors.ErrServerRemoteSSRCUnknown{}, err)
+
+			err = source.WritePacketRTP(medias[0], &testRTPPacket)
+			require.NoError(t, err)
+
+			<-packetRecv
+
+			var readerMedia *description.Media
+			for medi := range reader.setuppedMedias {
+				readerMedia = medi
+			}
+
+			err = reader.RequestKeyFrame(readerMedia)
+			require.NoError(t, err)
+
+			ctx := <-keyFrameRequested
+			require.Equal(t, stream, ctx.Stream)
+			require.Equal(t, stream.Desc.Medias[0], ctx.Media)
+			_, ok := ctx.Packet.(*rtcp.PictureLossIndication)
+			require.True(t, ok)
+
+			err = publisher.RequestKeyFrame(publisher.AnnouncedDescription().Medias[0])
+			require.NoError(t, err)
+
+			pli, ok := (<-keyFrameRequestReceived).(*rtcp.PictureLossIndication)
+			require.True(t, ok)
+			require.Equal(t, testRTPPacket.SSRC, pli.MediaSSRC)
+
+			err = reader.RequestKeyFrameFIR(readerMedia)
+			require.NoError(t, err)
+
+			ctx = <-keyFrameRequested
+			_, ok = ctx.Packet.(*rtcp.FullIntraRequest)
+			require.True(t, ok)
+
+			for i := 1; i <= 2; i++ {
+				err = publisher.RequestKeyFrameFIR(publisher.AnnouncedDescription().Medias[0])
+				require.NoError(t, err)
+
+				var fir *rtcp.FullIntraRequest
+				fir, ok = (<-keyFrameRequestReceived).(*rtcp.FullIntraRequest)
+				require.True(t, ok)
+				require.Equal(t, []rtcp.FIREntry{{
+					SSRC:           testRTPPacket.SSRC,
+					SequenceNumber: uint8(i),
+				}}, fir.FIR)
+			}
+		})
+	}
+}
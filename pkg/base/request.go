@@ -8,7 +8,6 @@ import (
 )
 
 const (
-	rtspProtocol10           = "RTSP/1.0"
 	requestMaxMethodLength   = 64
 	requestMaxURLLength      = 2048
 	requestMaxProtocolLength = 64
@@ -25,6 +24,7 @@ const (
 	Options      Method = "OPTIONS"
 	Pause        Method = "PAUSE"
 	Play         Method = "PLAY"
+	PlayNotify   Method = "PLAY_NOTIFY"
 	Record       Method = "RECORD"
 	Setup        Method = "SETUP"
 	SetParameter Method = "SET_PARAMETER"
@@ -39,6 +39,10 @@ type Request struct {
 	// request url
 	URL *URL
 
+	// protocol version.
+	// It defaults to Version10.
+	Version Version
+
 	// map of header values
 	Header Header
 
@@ -81,8 +85,9 @@ func (req *Request) Unmarshal(br *bufio.Reader) error {
 	}
 	proto := byts[:len(byts)-1]
 
-	if string(proto) != rtspProtocol10 {
-		return fmt.Errorf("expected '%s', got %v", rtspProtocol10, proto)
+	err = req.Version.unmarshal(proto)
+	if err != nil {
+		return err
 	}
 
 	err = readByteEqual(br, '\n')
@@ -113,7 +118,7 @@ func (req Request) MarshalSize() int {
 		n++
 	}
 
-	n += 1 + len(rtspProtocol10) + 2
+	n += 1 + len(req.Version.String()) + 2
 
 	if len(req.Body) != 0 {
 		req.Header["Content-Length"] = HeaderValue{strconv.FormatInt(int64(len(req.Body)), 10)}
@@ -142,7 +147,7 @@ func (req Request) MarshalTo(buf []byte) (int, error) {
 
 	buf[pos] = ' '
 	pos++
-	pos += copy(buf[pos:], rtspProtocol10)
+	pos += copy(buf[pos:], req.Version.String())
 	buf[pos] = '\r'
 	pos++
 	buf[pos] = '\n'
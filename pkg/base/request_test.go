@@ -143,6 +143,24 @@ var casesRequest = []struct {
 			},
 		},
 	},
+	{
+		"play_notify rtsp 2.0",
+		[]byte("PLAY_NOTIFY rtsp://example.com/media.mp4 RTSP/2.0\r\n" +
+			"CSeq: 854\r\n" +
+			"Notify-Reason: end-of-stream\r\n" +
+			"Session: uZ3ci0K+Ld-M\r\n" +
+			"\r\n"),
+		Request{
+			Method:  PlayNotify,
+			URL:     mustParseURL("rtsp://example.com/media.mp4"),
+			Version: Version20,
+			Header: Header{
+				"CSeq":          HeaderValue{"854"},
+				"Notify-Reason": HeaderValue{"end-of-stream"},
+				"Session":       HeaderValue{"uZ3ci0K+Ld-M"},
+			},
+		},
+	},
 }
 
 func TestRequestUnmarshal(t *testing.T) {
@@ -158,6 +176,16 @@ func TestRequestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestRequestUnmarshalUnsupportedVersion(t *testing.T) {
+	byts := []byte("OPTIONS rtsp://example.com/media.mp4 RTSP/3.0\r\n" +
+		"CSeq: 1\r\n" +
+		"\r\n")
+
+	var req Request
+	err := req.Unmarshal(bufio.NewReader(bytes.NewBuffer(byts)))
+	require.EqualError(t, err, "unsupported protocol version: 'RTSP/3.0'")
+}
+
 func TestRequestMarshal(t *testing.T) {
 	for _, ca := range casesRequest {
 		t.Run(ca.name, func(t *testing.T) {
@@ -125,6 +125,10 @@ type Response struct {
 	// status message
 	StatusMessage string
 
+	// protocol version.
+	// It defaults to Version10.
+	Version Version
+
 	// map of header values
 	Header Header
 
@@ -140,8 +144,9 @@ func (res *Response) Unmarshal(br *bufio.Reader) error {
 	}
 	proto := byts[:len(byts)-1]
 
-	if string(proto) != rtspProtocol10 {
-		return fmt.Errorf("expected '%s', got %v", rtspProtocol10, proto)
+	err = res.Version.unmarshal(proto)
+	if err != nil {
+		return err
 	}
 
 	byts, err = readBytesLimited(br, ' ', 4)
@@ -194,7 +199,7 @@ func (res Response) MarshalSize() int {
 		}
 	}
 
-	n += len(rtspProtocol10) + 1 + len(strconv.FormatInt(int64(res.StatusCode), 10)) + 1 + len(res.StatusMessage) + 2
+	n += len(res.Version.String()) + 1 + len(strconv.FormatInt(int64(res.StatusCode), 10)) + 1 + len(res.StatusMessage) + 2
 
 	if len(res.Body) != 0 {
 		res.Header["Content-Length"] = HeaderValue{strconv.FormatInt(int64(len(res.Body)), 10)}
@@ -217,7 +222,7 @@ func (res Response) MarshalTo(buf []byte) (int, error) {
 
 	pos := 0
 
-	pos += copy(buf[pos:], []byte(rtspProtocol10))
+	pos += copy(buf[pos:], []byte(res.Version.String()))
 	buf[pos] = ' '
 	pos++
 	pos += copy(buf[pos:], []byte(strconv.FormatInt(int64(res.StatusCode), 10)))
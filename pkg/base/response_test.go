@@ -90,6 +90,25 @@ var casesResponse = []struct {
 			),
 		},
 	},
+	{
+		"rtsp 2.0",
+		[]byte("RTSP/2.0 200 OK\r\n" +
+			"CSeq: 3\r\n" +
+			"Media-Properties: No-Seeking, Time-Progressing, Time-Duration=0.0\r\n" +
+			"Session: 12345678;timeout=60\r\n" +
+			"\r\n",
+		),
+		Response{
+			StatusCode:    StatusOK,
+			StatusMessage: "OK",
+			Version:       Version20,
+			Header: Header{
+				"CSeq":             HeaderValue{"3"},
+				"Media-Properties": HeaderValue{"No-Seeking, Time-Progressing, Time-Duration=0.0"},
+				"Session":          HeaderValue{"12345678;timeout=60"},
+			},
+		},
+	},
 }
 
 func TestResponseUnmarshal(t *testing.T) {
@@ -0,0 +1,45 @@
+package base
+
+import (
+	"fmt"
+)
+
+const (
+	rtspProtocol10 = "RTSP/1.0"
+	rtspProtocol20 = "RTSP/2.0"
+)
+
+// Version is a RTSP protocol version.
+type Version int
+
+// protocol versions.
+const (
+	// RTSP/1.0 (RFC2326)
+	Version10 Version = iota
+
+	// RTSP/2.0 (RFC7826)
+	Version20
+)
+
+func (v *Version) unmarshal(byts []byte) error {
+	switch string(byts) {
+	case rtspProtocol10:
+		*v = Version10
+		return nil
+
+	case rtspProtocol20:
+		*v = Version20
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported protocol version: '%s'", byts)
+	}
+}
+
+// String implements fmt.Stringer.
+func (v Version) String() string {
+	if v == Version20 {
+		return rtspProtocol20
+	}
+	return rtspProtocol10
+}
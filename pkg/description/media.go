@@ -13,6 +13,7 @@ import (
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/base"
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 func getAttribute(attributes []psdp.Attribute, key string) string {
@@ -61,6 +62,7 @@ const (
 	MediaTypeVideo       MediaType = "video"
 	MediaTypeAudio       MediaType = "audio"
 	MediaTypeApplication MediaType = "application"
+	MediaTypeText        MediaType = "text"
 )
 
 // Media is a media stream.
@@ -78,6 +80,16 @@ type Media struct {
 	// Control attribute.
 	Control string
 
+	// Whether the media is protected with SRTP (RTP/SAVP profile).
+	Secure bool
+
+	// SRTP master key (optional).
+	// If it is not provided, it is generated by Client and ServerStream.
+	SRTPKey *srtp.Key
+
+	// Method used to exchange SRTPKey.
+	KeyMgmt MediaKeyMgmt
+
 	// Formats contained into the media.
 	Formats []format.Format
 }
@@ -94,6 +106,11 @@ func (m *Media) Unmarshal(md *psdp.MediaDescription) error {
 	m.IsBackChannel = isBackChannel(md.Attributes)
 	m.Control = getAttribute(md.Attributes, "control")
 
+	err := m.unmarshalSRTP(md)
+	if err != nil {
+		return err
+	}
+
 	m.Formats = nil
 
 	for _, payloadType := range md.MediaName.Formats {
@@ -121,6 +138,10 @@ func (m Media) Marshal() *psdp.MediaDescription {
 		},
 	}
 
+	if m.Secure {
+		md.MediaName.Protos = []string{"RTP", "SAVP"}
+	}
+
 	if m.ID != "" {
 		md.Attributes = append(md.Attributes, psdp.Attribute{
 			Key:   "mid",
@@ -139,6 +160,10 @@ func (m Media) Marshal() *psdp.MediaDescription {
 		Value: m.Control,
 	})
 
+	if m.Secure && m.SRTPKey != nil {
+		md.Attributes = append(md.Attributes, m.marshalSRTPKey())
+	}
+
 	for _, forma := range m.Formats {
 		typ := strconv.FormatUint(uint64(forma.PayloadType()), 10)
 		md.MediaName.Formats = append(md.MediaName.Formats, typ)
@@ -155,7 +180,11 @@ func (m Media) Marshal() *psdp.MediaDescription {
 		if len(fmtp) != 0 {
 			tmp := make([]string, len(fmtp))
 			for i, key := range sortedKeys(fmtp) {
-				tmp[i] = key + "=" + fmtp[key]
+				if fmtp[key] == format.FMTPValueless {
+					tmp[i] = key
+				} else {
+					tmp[i] = key + "=" + fmtp[key]
+				}
 			}
 
 			md.Attributes = append(md.Attributes, psdp.Attribute{
@@ -163,11 +192,29 @@ func (m Media) Marshal() *psdp.MediaDescription {
 				Value: typ + " " + strings.Join(tmp, "; "),
 			})
 		}
+
+		// retransmissions are requested with NACKs (RFC4585, RFC4588)
+		if m.findRTXFormat(forma.PayloadType()) != nil {
+			md.Attributes = append(md.Attributes, psdp.Attribute{
+				Key:   "rtcp-fb",
+				Value: typ + " nack",
+			})
+		}
 	}
 
 	return md
 }
 
+// findRTXFormat returns the RTX format associated with a payload type.
+func (m Media) findRTXFormat(payloadType uint8) *format.RTX {
+	for _, forma := range m.Formats {
+		if rtx, ok := forma.(*format.RTX); ok && rtx.AssociatedPayloadType == payloadType {
+			return rtx
+		}
+	}
+	return nil
+}
+
 // URL returns the absolute URL of the media.
 func (m Media) URL(contentBase *base.URL) (*base.URL, error) {
 	if contentBase == nil {
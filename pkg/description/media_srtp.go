@@ -0,0 +1,162 @@
+package description
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	psdp "github.com/pion/sdp/v3"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/mikey"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
+)
+
+// MediaKeyMgmt is a method to exchange SRTP keys.
+type MediaKeyMgmt int
+
+// key management methods.
+const (
+	// SDES (a=crypto, RFC4568)
+	MediaKeyMgmtSDES MediaKeyMgmt = iota
+
+	// MIKEY (a=key-mgmt, RFC4567)
+	MediaKeyMgmtMIKEY
+)
+
+func isSecureProto(protos []string) bool {
+	return len(protos) == 2 && protos[0] == "RTP" && protos[1] == "SAVP"
+}
+
+func unmarshalSDES(v string) (*srtp.Key, error) {
+	// <tag> <crypto-suite> <key-params> [<session-params>]
+	parts := strings.Split(v, " ")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid crypto attribute (%v)", v)
+	}
+
+	var profile srtp.ProtectionProfile
+	err := profile.Unmarshal(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// inline:<key||salt>[|lifetime][|MKI:length]
+	// when there are multiple keys, the first one is used.
+	keyParams := strings.Split(parts[2], ";")[0]
+
+	if !strings.HasPrefix(keyParams, "inline:") {
+		return nil, fmt.Errorf("invalid key parameters (%v)", parts[2])
+	}
+
+	keySalt, err := base64.StdEncoding.DecodeString(strings.Split(keyParams[len("inline:"):], "|")[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid key parameters (%v)", parts[2])
+	}
+
+	if len(keySalt) != (profile.KeyLen() + profile.SaltLen()) {
+		return nil, fmt.Errorf("invalid key length: %d", len(keySalt))
+	}
+
+	return &srtp.Key{
+		Profile:    profile,
+		MasterKey:  keySalt[:profile.KeyLen()],
+		MasterSalt: keySalt[profile.KeyLen():],
+	}, nil
+}
+
+func marshalSDES(key *srtp.Key) string {
+	keySalt := append(append([]byte(nil), key.MasterKey...), key.MasterSalt...)
+	return "1 " + key.Profile.String() + " inline:" + base64.StdEncoding.EncodeToString(keySalt)
+}
+
+func unmarshalMIKEY(v string) (*srtp.Key, error) {
+	if !strings.HasPrefix(v, "mikey ") {
+		return nil, fmt.Errorf("unsupported key management protocol (%v)", v)
+	}
+
+	byts, err := base64.StdEncoding.DecodeString(v[len("mikey "):])
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIKEY message: %w", err)
+	}
+
+	var msg mikey.Message
+	err = msg.Unmarshal(byts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIKEY message: %w", err)
+	}
+
+	var key srtp.Key
+	err = key.UnmarshalMIKEY(&msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func marshalMIKEY(key *srtp.Key) string {
+	byts, _ := key.MarshalMIKEY().Marshal()
+	return "mikey " + base64.StdEncoding.EncodeToString(byts)
+}
+
+func (m *Media) unmarshalSRTP(md *psdp.MediaDescription) error {
+	m.Secure = isSecureProto(md.MediaName.Protos)
+	m.SRTPKey = nil
+	m.KeyMgmt = MediaKeyMgmtSDES
+
+	if !m.Secure {
+		return nil
+	}
+
+	var firstErr error
+
+	for _, attr := range md.Attributes {
+		var key *srtp.Key
+		var err error
+
+		switch attr.Key {
+		case "crypto":
+			key, err = unmarshalSDES(attr.Value)
+			m.KeyMgmt = MediaKeyMgmtSDES
+
+		case "key-mgmt":
+			key, err = unmarshalMIKEY(attr.Value)
+			m.KeyMgmt = MediaKeyMgmtMIKEY
+
+		default:
+			continue
+		}
+
+		// multiple crypto attributes can be provided,
+		// pick the first supported one.
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		m.SRTPKey = key
+		return nil
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return nil
+}
+
+func (m Media) marshalSRTPKey() psdp.Attribute {
+	if m.KeyMgmt == MediaKeyMgmtMIKEY {
+		return psdp.Attribute{
+			Key:   "key-mgmt",
+			Value: marshalMIKEY(m.SRTPKey),
+		}
+	}
+
+	return psdp.Attribute{
+		Key:   "crypto",
+		Value: marshalSDES(m.SRTPKey),
+	}
+}
@@ -3,6 +3,7 @@ package description
 import (
 	"testing"
 
+	psdp "github.com/pion/sdp/v3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/base"
@@ -173,3 +174,19 @@ func TestMediaURLError(t *testing.T) {
 	_, err := media.URL(nil)
 	require.EqualError(t, err, "Content-Base header not provided")
 }
+
+func TestMediaMarshalFMTPEmptyValue(t *testing.T) {
+	media := &Media{
+		Type: "application",
+		Formats: []format.Format{&format.Generic{
+			PayloadTyp: 98,
+			RTPMa:      "custom/90000",
+			FMT: map[string]string{
+				"key": "",
+			},
+		}},
+	}
+
+	md := media.Marshal()
+	require.Contains(t, md.Attributes, psdp.Attribute{Key: "fmtp", Value: "98 key="})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
 	"github.com/frostyfridge/gortsplib/v4/pkg/sdp"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 var casesSession = []struct {
@@ -274,7 +275,7 @@ var casesSession = []struct {
 			"a=rtpmap:103 ISAC/16000\r\n" +
 			"a=rtpmap:104 ISAC/32000\r\n" +
 			"a=rtpmap:9 G722/8000\r\n" +
-			"a=rtpmap:102 ILBC/8000\r\n" +
+			"a=rtpmap:102 iLBC/8000\r\n" +
 			"a=rtpmap:0 PCMU/8000\r\n" +
 			"a=rtpmap:8 PCMA/8000\r\n" +
 			"a=rtpmap:106 CN/32000\r\n" +
@@ -289,16 +290,20 @@ var casesSession = []struct {
 			"a=sendonly\r\n" +
 			"a=control\r\n" +
 			"a=rtpmap:96 VP8/90000\r\n" +
+			"a=rtcp-fb:96 nack\r\n" +
 			"a=rtpmap:97 rtx/90000\r\n" +
 			"a=fmtp:97 apt=96\r\n" +
 			"a=rtpmap:98 VP9/90000\r\n" +
+			"a=rtcp-fb:98 nack\r\n" +
 			"a=rtpmap:99 rtx/90000\r\n" +
 			"a=fmtp:99 apt=98\r\n" +
 			"a=rtpmap:100 H264/90000\r\n" +
 			"a=fmtp:100 packetization-mode=1\r\n" +
+			"a=rtcp-fb:100 nack\r\n" +
 			"a=rtpmap:101 rtx/90000\r\n" +
 			"a=fmtp:101 apt=100\r\n" +
 			"a=rtpmap:127 red/90000\r\n" +
+			"a=rtcp-fb:127 nack\r\n" +
 			"a=rtpmap:124 rtx/90000\r\n" +
 			"a=fmtp:124 apt=127\r\na=rtpmap:125 ulpfec/90000\r\n",
 		Session{
@@ -325,10 +330,9 @@ var casesSession = []struct {
 							ClockRat:   32000,
 						},
 						&format.G722{},
-						&format.Generic{
+						&format.ILBC{
 							PayloadTyp: 102,
-							RTPMa:      "ILBC/8000",
-							ClockRat:   8000,
+							Mode:       30,
 						},
 						&format.G711{
 							PayloadTyp:   0,
@@ -357,25 +361,21 @@ var casesSession = []struct {
 							RTPMa:      "CN/8000",
 							ClockRat:   8000,
 						},
-						&format.Generic{
+						&format.TelephoneEvent{
 							PayloadTyp: 110,
-							RTPMa:      "telephone-event/48000",
-							ClockRat:   48000,
+							SampleRate: 48000,
 						},
-						&format.Generic{
+						&format.TelephoneEvent{
 							PayloadTyp: 112,
-							RTPMa:      "telephone-event/32000",
-							ClockRat:   32000,
+							SampleRate: 32000,
 						},
-						&format.Generic{
+						&format.TelephoneEvent{
 							PayloadTyp: 113,
-							RTPMa:      "telephone-event/16000",
-							ClockRat:   16000,
+							SampleRate: 16000,
 						},
-						&format.Generic{
+						&format.TelephoneEvent{
 							PayloadTyp: 126,
-							RTPMa:      "telephone-event/8000",
-							ClockRat:   8000,
+							SampleRate: 8000,
 						},
 					},
 				},
@@ -387,53 +387,39 @@ var casesSession = []struct {
 						&format.VP8{
 							PayloadTyp: 96,
 						},
-						&format.Generic{
-							PayloadTyp: 97,
-							RTPMa:      "rtx/90000",
-							FMT: map[string]string{
-								"apt": "96",
-							},
-							ClockRat: 90000,
+						&format.RTX{
+							PayloadTyp:            97,
+							ClockRat:              90000,
+							AssociatedPayloadType: 96,
 						},
 						&format.VP9{
 							PayloadTyp: 98,
 						},
-						&format.Generic{
-							PayloadTyp: 99,
-							RTPMa:      "rtx/90000",
-							FMT: map[string]string{
-								"apt": "98",
-							},
-							ClockRat: 90000,
+						&format.RTX{
+							PayloadTyp:            99,
+							ClockRat:              90000,
+							AssociatedPayloadType: 98,
 						},
 						&format.H264{
 							PayloadTyp:        100,
 							PacketizationMode: 1,
 						},
-						&format.Generic{
-							PayloadTyp: 101,
-							RTPMa:      "rtx/90000",
-							FMT: map[string]string{
-								"apt": "100",
-							},
-							ClockRat: 90000,
+						&format.RTX{
+							PayloadTyp:            101,
+							ClockRat:              90000,
+							AssociatedPayloadType: 100,
 						},
-						&format.Generic{
+						&format.RED{
 							PayloadTyp: 127,
-							RTPMa:      "red/90000",
 							ClockRat:   90000,
 						},
-						&format.Generic{
-							PayloadTyp: 124,
-							RTPMa:      "rtx/90000",
-							FMT: map[string]string{
-								"apt": "127",
-							},
-							ClockRat: 90000,
+						&format.RTX{
+							PayloadTyp:            124,
+							ClockRat:              90000,
+							AssociatedPayloadType: 127,
 						},
-						&format.Generic{
+						&format.ULPFEC{
 							PayloadTyp: 125,
-							RTPMa:      "ulpfec/90000",
 							ClockRat:   90000,
 						},
 					},
@@ -550,6 +536,54 @@ var casesSession = []struct {
 			},
 		},
 	},
+	{
+		"onvif back channel with dtmf",
+		"v=0\r\n" +
+			"o= 2890842807 IN IP4 192.168.0.1\r\n" +
+			"s=RTSP Session with audiobackchannel\r\n" +
+			"m=audio 0 RTP/AVP 0 101\r\n" +
+			"a=control:rtsp://192.168.0.1/audioback\r\n" +
+			"a=rtpmap:0 PCMU/8000\r\n" +
+			"a=rtpmap:101 telephone-event/8000\r\n" +
+			"a=fmtp:101 0-15\r\n" +
+			"a=sendonly\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s=RTSP Session with audiobackchannel\r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=audio 0 RTP/AVP 0 101\r\n" +
+			"a=sendonly\r\n" +
+			"a=control:rtsp://192.168.0.1/audioback\r\n" +
+			"a=rtpmap:0 PCMU/8000\r\n" +
+			"a=rtpmap:101 telephone-event/8000\r\n" +
+			"a=fmtp:101 0-15\r\n",
+		Session{
+			Title: `RTSP Session with audiobackchannel`,
+			Medias: []*Media{
+				{
+					Type:          MediaTypeAudio,
+					IsBackChannel: true,
+					Control:       "rtsp://192.168.0.1/audioback",
+					Formats: []format.Format{
+						&format.G711{
+							PayloadTyp:   0,
+							MULaw:        true,
+							SampleRate:   8000,
+							ChannelCount: 1,
+						},
+						&format.TelephoneEvent{
+							PayloadTyp: 101,
+							SampleRate: 8000,
+							Events: []format.TelephoneEventRange{
+								{First: 0, Last: 15},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		"ulpfec rfc5109",
 		"v=0\r\n" +
@@ -612,9 +646,8 @@ var casesSession = []struct {
 				{
 					ID:   "2",
 					Type: MediaTypeApplication,
-					Formats: []format.Format{&format.Generic{
+					Formats: []format.Format{&format.ULPFEC{
 						PayloadTyp: 100,
-						RTPMa:      "ulpfec/8000",
 						ClockRat:   8000,
 					}},
 				},
@@ -629,15 +662,142 @@ var casesSession = []struct {
 				{
 					ID:   "4",
 					Type: MediaTypeApplication,
-					Formats: []format.Format{&format.Generic{
+					Formats: []format.Format{&format.ULPFEC{
 						PayloadTyp: 101,
-						RTPMa:      "ulpfec/8000",
 						ClockRat:   8000,
 					}},
 				},
 			},
 		},
 	},
+	{
+		"srtp sdes",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/SAVP 96\r\n" +
+			"a=control\r\n" +
+			"a=crypto:1 AES_CM_128_HMAC_SHA1_32 inline:AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0e|2^31\r\n" +
+			"a=crypto:2 AES_CM_128_HMAC_SHA1_80 inline:AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0e\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/SAVP 96\r\n" +
+			"a=control\r\n" +
+			"a=crypto:1 AES_CM_128_HMAC_SHA1_32 inline:AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0e\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n",
+		Session{
+			Medias: []*Media{
+				{
+					Type:   MediaTypeVideo,
+					Secure: true,
+					SRTPKey: &srtp.Key{
+						Profile:    srtp.ProtectionProfileAESCM128HMACSHA132,
+						MasterKey:  []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+						MasterSalt: []byte{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+					},
+					Formats: []format.Format{&format.H264{
+						PayloadTyp:        96,
+						PacketizationMode: 1,
+					}},
+				},
+			},
+		},
+	},
+	{
+		"srtp mikey",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/SAVP 96\r\n" +
+			"a=control\r\n" +
+			"a=key-mgmt:mikey AQAFAAAAAAAAAAsAAAAAAAAAAAAKAAEAAAASAAEBAQEQAgEBAwEUBAEOCwEKAAAAJAAwABABAgMEBQYHCAkKCwwNDg8QAA4REhMUFRYXGBkaGxwdHgA=\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/SAVP 96\r\n" +
+			"a=control\r\n" +
+			"a=key-mgmt:mikey AQAFAAAAAAAAAAsAAAAAAAAAAAAKAAEAAAASAAEBAQEQAgEBAwEUBAEOCwEKAAAAJAAwABABAgMEBQYHCAkKCwwNDg8QAA4REhMUFRYXGBkaGxwdHgA=\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n",
+		Session{
+			Medias: []*Media{
+				{
+					Type:   MediaTypeVideo,
+					Secure: true,
+					SRTPKey: &srtp.Key{
+						Profile:    srtp.ProtectionProfileAESCM128HMACSHA180,
+						MasterKey:  []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+						MasterSalt: []byte{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+					},
+					KeyMgmt: MediaKeyMgmtMIKEY,
+					Formats: []format.Format{&format.H264{
+						PayloadTyp:        96,
+						PacketizationMode: 1,
+					}},
+				},
+			},
+		},
+	},
+	{
+		"rtx",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/AVP 96 97\r\n" +
+			"a=control\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n" +
+			"a=rtcp-fb:96 nack\r\n" +
+			"a=rtcp-fb:96 nack pli\r\n" +
+			"a=rtpmap:97 rtx/90000\r\n" +
+			"a=fmtp:97 apt=96\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s= \r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=video 0 RTP/AVP 96 97\r\n" +
+			"a=control\r\n" +
+			"a=rtpmap:96 H264/90000\r\n" +
+			"a=fmtp:96 packetization-mode=1\r\n" +
+			"a=rtcp-fb:96 nack\r\n" +
+			"a=rtpmap:97 rtx/90000\r\n" +
+			"a=fmtp:97 apt=96\r\n",
+		Session{
+			Medias: []*Media{
+				{
+					Type: MediaTypeVideo,
+					Formats: []format.Format{
+						&format.H264{
+							PayloadTyp:        96,
+							PacketizationMode: 1,
+						},
+						&format.RTX{
+							PayloadTyp:            97,
+							ClockRat:              90000,
+							AssociatedPayloadType: 96,
+						},
+					},
+				},
+			},
+		},
+	},
 }
 
 func TestSessionUnmarshal(t *testing.T) {
@@ -0,0 +1,192 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpamr"
+)
+
+// AMR is the RTP format for the AMR and AMR-WB codecs.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4867
+// CRCs, robust sorting and interleaving are not supported.
+type AMR struct {
+	PayloadTyp   uint8
+	Wideband     bool
+	ChannelCount int
+	OctetAlign   bool
+	ModeSet      []int
+}
+
+func parseAMRFlag(key string, val string) (bool, error) {
+	switch val {
+	case "0":
+		return false, nil
+
+	case "1":
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("invalid %s value: %v", key, val)
+	}
+}
+
+func (f *AMR) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+	f.Wideband = (ctx.codec == "amr-wb")
+
+	tmp := strings.SplitN(ctx.clock, "/", 2)
+
+	sampleRate, err := strconv.ParseUint(tmp[0], 10, 31)
+	if err != nil || sampleRate != uint64(f.ClockRate()) {
+		return fmt.Errorf("invalid sample rate: '%s'", tmp[0])
+	}
+
+	if len(tmp) >= 2 {
+		channelCount, err := strconv.ParseUint(tmp[1], 10, 31)
+		if err != nil || channelCount == 0 {
+			return fmt.Errorf("invalid channel count: '%s'", tmp[1])
+		}
+		f.ChannelCount = int(channelCount)
+	} else {
+		f.ChannelCount = 1
+	}
+
+	maxMode := 7
+	if f.Wideband {
+		maxMode = 8
+	}
+
+	for key, val := range ctx.fmtp {
+		switch key {
+		case "octet-align":
+			f.OctetAlign, err = parseAMRFlag(key, val)
+			if err != nil {
+				return err
+			}
+
+		case "mode-set":
+			for _, tmp := range strings.Split(val, ",") {
+				mode, err := strconv.ParseUint(strings.TrimSpace(tmp), 10, 31)
+				if err != nil || int(mode) > maxMode {
+					return fmt.Errorf("invalid mode-set: %v", val)
+				}
+				f.ModeSet = append(f.ModeSet, int(mode))
+			}
+
+		case "crc":
+			crc, err := parseAMRFlag(key, val)
+			if err != nil {
+				return err
+			}
+			if crc {
+				return fmt.Errorf("CRCs are not supported")
+			}
+
+		case "robust-sorting":
+			robustSorting, err := parseAMRFlag(key, val)
+			if err != nil {
+				return err
+			}
+			if robustSorting {
+				return fmt.Errorf("robust sorting is not supported")
+			}
+
+		case "interleaving":
+			return fmt.Errorf("interleaving is not supported")
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *AMR) Codec() string {
+	if f.Wideband {
+		return "AMR-WB"
+	}
+	return "AMR"
+}
+
+// ClockRate implements Format.
+func (f *AMR) ClockRate() int {
+	if f.Wideband {
+		return 16000
+	}
+	return 8000
+}
+
+// PayloadType implements Format.
+func (f *AMR) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *AMR) RTPMap() string {
+	ret := f.Codec() + "/" + strconv.FormatInt(int64(f.ClockRate()), 10)
+
+	if f.ChannelCount > 1 {
+		ret += "/" + strconv.FormatInt(int64(f.ChannelCount), 10)
+	}
+
+	return ret
+}
+
+// FMTP implements Format.
+func (f *AMR) FMTP() map[string]string {
+	fmtp := make(map[string]string)
+
+	if f.OctetAlign {
+		fmtp["octet-align"] = "1"
+	}
+
+	if len(f.ModeSet) != 0 {
+		tmp := make([]string, len(f.ModeSet))
+		for i, mode := range f.ModeSet {
+			tmp[i] = strconv.FormatInt(int64(mode), 10)
+		}
+		fmtp["mode-set"] = strings.Join(tmp, ",")
+	}
+
+	return fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (f *AMR) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *AMR) CreateDecoder() (*rtpamr.Decoder, error) {
+	d := &rtpamr.Decoder{
+		Wideband:   f.Wideband,
+		OctetAlign: f.OctetAlign,
+	}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *AMR) CreateEncoder() (*rtpamr.Encoder, error) {
+	e := &rtpamr.Encoder{
+		PayloadType:  f.PayloadTyp,
+		Wideband:     f.Wideband,
+		OctetAlign:   f.OctetAlign,
+		ChannelCount: f.ChannelCount,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
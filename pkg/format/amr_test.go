@@ -0,0 +1,83 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMRAttributes(t *testing.T) {
+	format := &AMR{
+		PayloadTyp:   96,
+		ChannelCount: 1,
+	}
+	require.Equal(t, "AMR", format.Codec())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+
+	format = &AMR{
+		PayloadTyp:   96,
+		Wideband:     true,
+		ChannelCount: 1,
+	}
+	require.Equal(t, "AMR-WB", format.Codec())
+	require.Equal(t, 16000, format.ClockRate())
+}
+
+func TestAMRDecEncoder(t *testing.T) {
+	format := &AMR{
+		PayloadTyp:   96,
+		ChannelCount: 1,
+		OctetAlign:   true,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{{0x44, 0x01, 0x02, 0x03, 0x04, 0x0e}})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	frames, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x44, 0x01, 0x02, 0x03, 0x04, 0x0e}}, frames)
+}
+
+func TestAMRUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		fmtp map[string]string
+		err  string
+	}{
+		{
+			"crc",
+			map[string]string{"crc": "1"},
+			"CRCs are not supported",
+		},
+		{
+			"robust sorting",
+			map[string]string{"robust-sorting": "1"},
+			"robust sorting is not supported",
+		},
+		{
+			"interleaving",
+			map[string]string{"interleaving": "4"},
+			"interleaving is not supported",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var f AMR
+			err := f.unmarshal(&unmarshalContext{
+				payloadType: 96,
+				codec:       "amr",
+				clock:       "8000",
+				fmtp:        ca.fmtp,
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEAC3Attributes(t *testing.T) {
+	format := &EAC3{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+	require.Equal(t, "E-AC-3", format.Codec())
+	require.Equal(t, 48000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestEAC3DecEncoder(t *testing.T) {
+	format := &EAC3{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+
+	// independent syncframe, 48khz, 6 blocks, 128 bytes
+	frame := append([]byte{0x0b, 0x77, 0x00, 0x3f, 0x34, 0x80}, bytes.Repeat([]byte{0x01}, 122)...)
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{frame})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{frame}, byts)
+}
@@ -78,6 +78,29 @@ func decodeFMTP(enc string) map[string]string {
 	return ret
 }
 
+// decodeFMTPValuelessParams returns the parameters of a fmtp attribute that do not have a value,
+// like the event list of telephone-event.
+func decodeFMTPValuelessParams(enc string) []string {
+	var ret []string
+
+	for _, kv := range strings.Split(enc, ";") {
+		kv = strings.Trim(kv, " ")
+
+		if len(kv) == 0 || strings.Contains(kv, "=") {
+			continue
+		}
+
+		ret = append(ret, kv)
+	}
+
+	return ret
+}
+
+// FMTPValueless is the value of fmtp parameters that don't have a value,
+// like the interlace flag of raw video or the event list of telephone-event.
+// These parameters are encoded without "=".
+const FMTPValueless = "\x00"
+
 type unmarshalContext struct {
 	mediaType   string
 	payloadType uint8
@@ -85,6 +108,7 @@ type unmarshalContext struct {
 	codec       string
 	rtpMap      string
 	fmtp        map[string]string
+	fmtpRaw     string
 }
 
 // Format is a media format.
@@ -105,6 +129,7 @@ type Format interface {
 	RTPMap() string
 
 	// FMTP returns the fmtp attribute.
+	// Parameters without value are set to FMTPValueless.
 	FMTP() map[string]string
 
 	// PTSEqualsDTS checks whether PTS is equal to DTS in RTP packets.
@@ -123,7 +148,8 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 	payloadType := uint8(tmp)
 
 	rtpMap := getFormatAttribute(md.Attributes, payloadType, "rtpmap")
-	fmtp := decodeFMTP(getFormatAttribute(md.Attributes, payloadType, "fmtp"))
+	fmtpRaw := getFormatAttribute(md.Attributes, payloadType, "fmtp")
+	fmtp := decodeFMTP(fmtpRaw)
 	codec, clock := getCodecAndClock(rtpMap)
 
 	format := func() Format {
@@ -143,6 +169,9 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		case codec == "vp8" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
 			return &VP8{}
 
+		case codec == "h266" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
+			return &H266{}
+
 		case codec == "h265" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
 			return &H265{}
 
@@ -152,6 +181,46 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		case codec == "mp4v-es" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
 			return &MPEG4Video{}
 
+		case codec == "raw" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
+			return &RawVideo{}
+
+		case codec == "jpeg2000" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
+			return &JPEG2000{}
+
+		case codec == "jxsv" && clock == "90000" && payloadType >= 96 && payloadType <= 127:
+			return &JPEGXS{}
+
+		// retransmissions
+
+		case codec == "rtx" && payloadType >= 96 && payloadType <= 127:
+			return &RTX{}
+
+		// redundancy
+
+		case codec == "red" && payloadType >= 96 && payloadType <= 127:
+			return &RED{}
+
+		// forward error correction
+
+		case codec == "ulpfec" && payloadType >= 96 && payloadType <= 127:
+			return &ULPFEC{}
+
+		// text
+
+		case codec == "t140" && clock == "1000" && payloadType >= 96 && payloadType <= 127:
+			return &T140{}
+
+		// metadata
+
+		case codec == "smpte336m" && payloadType >= 96 && payloadType <= 127:
+			return &KLV{}
+
+		case (codec == "vnd.onvif.metadata" ||
+			codec == "vnd.onvif.metadata.gzip" ||
+			codec == "vnd.onvif.metadata.exi.onvif" ||
+			codec == "vnd.onvif.metadata.exi.ext") && payloadType >= 96 && payloadType <= 127:
+			return &ONVIFMetadata{}
+
 		// audio
 
 		case codec == "opus", codec == "multiopus" && payloadType >= 96 && payloadType <= 127:
@@ -166,6 +235,18 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		case codec == "ac3" && payloadType >= 96 && payloadType <= 127:
 			return &AC3{}
 
+		case codec == "eac3" && payloadType >= 96 && payloadType <= 127:
+			return &EAC3{}
+
+		case codec == "mpa-robust" && payloadType >= 96 && payloadType <= 127:
+			return &MPARobust{}
+
+		case (codec == "amr" || codec == "amr-wb") && payloadType >= 96 && payloadType <= 127:
+			return &AMR{}
+
+		case codec == "telephone-event" && payloadType >= 96 && payloadType <= 127:
+			return &TelephoneEvent{}
+
 		case codec == "speex" && payloadType >= 96 && payloadType <= 127:
 			return &Speex{}
 
@@ -179,6 +260,15 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 			codec == "aal2-g726-40") && clock == "8000" && payloadType >= 96 && payloadType <= 127:
 			return &G726{}
 
+		case codec == "g729" && clock == "8000" && payloadType >= 96 && payloadType <= 127:
+			return &G729{}
+
+		case codec == "ilbc" && clock == "8000" && payloadType >= 96 && payloadType <= 127:
+			return &ILBC{}
+
+		case codec == "gsm" && clock == "8000" && payloadType >= 96 && payloadType <= 127:
+			return &GSM{}
+
 		case codec == "pcma", codec == "pcmu" && payloadType >= 96 && payloadType <= 127:
 			return &G711{}
 
@@ -208,6 +298,12 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		case payloadType == 9:
 			return &G722{}
 
+		case payloadType == 18:
+			return &G729{}
+
+		case payloadType == 3:
+			return &GSM{}
+
 		case payloadType == 0, payloadType == 8:
 			return &G711{}
 
@@ -225,6 +321,7 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		codec:       codec,
 		rtpMap:      rtpMap,
 		fmtp:        fmtp,
+		fmtpRaw:     fmtpRaw,
 	})
 	if err != nil {
 		return nil, err
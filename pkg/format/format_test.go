@@ -96,6 +96,65 @@ var casesFormat = []struct {
 		"G722/8000",
 		nil,
 	},
+	{
+		"audio g729",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 18\n",
+		&G729{
+			PayloadTyp: 18,
+			AnnexB:     true,
+		},
+		18,
+		"G729/8000",
+		nil,
+	},
+	{
+		"audio g729 without annex b",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 18\n" +
+			"a=rtpmap:18 G729/8000\n" +
+			"a=fmtp:18 annexb=no\n",
+		&G729{
+			PayloadTyp: 18,
+			AnnexB:     false,
+		},
+		18,
+		"G729/8000",
+		map[string]string{
+			"annexb": "no",
+		},
+	},
+	{
+		"audio gsm",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 3\n",
+		&GSM{
+			PayloadTyp: 3,
+		},
+		3,
+		"GSM/8000",
+		nil,
+	},
+	{
+		"audio ilbc",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 97\n" +
+			"a=rtpmap:97 iLBC/8000\n" +
+			"a=fmtp:97 mode=20\n",
+		&ILBC{
+			PayloadTyp: 97,
+			Mode:       20,
+		},
+		97,
+		"iLBC/8000",
+		map[string]string{
+			"mode": "20",
+		},
+	},
 	{
 		"audio g726 le 1",
 		"v=0\n" +
@@ -602,6 +661,83 @@ var casesFormat = []struct {
 			"profile-level-id": "30",
 		},
 	},
+	{
+		"audio amr",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 AMR/8000\n" +
+			"a=fmtp:96 octet-align=1; mode-set=0,2,5,7; crc=0; robust-sorting=0\n",
+		&AMR{
+			PayloadTyp:   96,
+			ChannelCount: 1,
+			OctetAlign:   true,
+			ModeSet:      []int{0, 2, 5, 7},
+		},
+		96,
+		"AMR/8000",
+		map[string]string{
+			"octet-align": "1",
+			"mode-set":    "0,2,5,7",
+		},
+	},
+	{
+		"audio amr-wb",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 97\n" +
+			"a=rtpmap:97 AMR-WB/16000/2\n",
+		&AMR{
+			PayloadTyp:   97,
+			Wideband:     true,
+			ChannelCount: 2,
+		},
+		97,
+		"AMR-WB/16000/2",
+		map[string]string{},
+	},
+	{
+		"audio telephone-event",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 101\n" +
+			"a=rtpmap:101 telephone-event/8000\n" +
+			"a=fmtp:101 0-15,66,70-72\n",
+		&TelephoneEvent{
+			PayloadTyp: 101,
+			SampleRate: 8000,
+			Events: []TelephoneEventRange{
+				{First: 0, Last: 15},
+				{First: 66, Last: 66},
+				{First: 70, Last: 72},
+			},
+		},
+		101,
+		"telephone-event/8000",
+		map[string]string{
+			"0-15,66,70-72": FMTPValueless,
+		},
+	},
+	{
+		"audio telephone-event channels",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 101\n" +
+			"a=rtpmap:101 telephone-event/8000/1\n" +
+			"a=fmtp:101 0-15\n",
+		&TelephoneEvent{
+			PayloadTyp: 101,
+			SampleRate: 8000,
+			Events: []TelephoneEventRange{
+				{First: 0, Last: 15},
+			},
+		},
+		101,
+		"telephone-event/8000",
+		map[string]string{
+			"0-15": FMTPValueless,
+		},
+	},
 	{
 		"audio speex",
 		"v=0\n" +
@@ -707,6 +843,49 @@ var casesFormat = []struct {
 		"AC3/48000/6",
 		nil,
 	},
+	{
+		"audio eac3",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 EAC3/48000/2\n",
+		&EAC3{
+			PayloadTyp:   96,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+		96,
+		"EAC3/48000/2",
+		nil,
+	},
+	{
+		"audio eac3 implicit channels",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 97\n" +
+			"a=rtpmap:97 eac3/32000\n",
+		&EAC3{
+			PayloadTyp:   97,
+			SampleRate:   32000,
+			ChannelCount: 6,
+		},
+		97,
+		"EAC3/32000/6",
+		nil,
+	},
+	{
+		"audio mpa-robust",
+		"v=0\n" +
+			"s=\n" +
+			"m=audio 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 mpa-robust/90000\n",
+		&MPARobust{
+			PayloadTyp: 96,
+		},
+		96,
+		"mpa-robust/90000",
+		nil,
+	},
 	{
 		"video jpeg",
 		"v=0\n" +
@@ -793,6 +972,39 @@ var casesFormat = []struct {
 			"profile-level-id":     "64000C",
 		},
 	},
+	{
+		"video h264 interleaved",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 H264/90000\n" +
+			"a=fmtp:96 packetization-mode=2; profile-level-id=64000C; " +
+			"sprop-parameter-sets=Z2QADKw7ULBLQgAAAwACAAADAD0I,aO48gA==; " +
+			"sprop-interleaving-depth=3; sprop-deint-buf-req=64000\n",
+		&H264{
+			PayloadTyp: 96,
+			SPS: []byte{
+				0x67, 0x64, 0x00, 0x0c, 0xac, 0x3b, 0x50, 0xb0,
+				0x4b, 0x42, 0x00, 0x00, 0x03, 0x00, 0x02, 0x00,
+				0x00, 0x03, 0x00, 0x3d, 0x08,
+			},
+			PPS: []byte{
+				0x68, 0xee, 0x3c, 0x80,
+			},
+			PacketizationMode: 2,
+			InterleavingDepth: 3,
+			DeintBufReq:       64000,
+		},
+		96,
+		"H264/90000",
+		map[string]string{
+			"packetization-mode":       "2",
+			"sprop-parameter-sets":     "Z2QADKw7ULBLQgAAAwACAAADAD0I,aO48gA==",
+			"profile-level-id":         "64000C",
+			"sprop-interleaving-depth": "3",
+			"sprop-deint-buf-req":      "64000",
+		},
+	},
 	{
 		"video h264 vlc rtsp server",
 		"v=0\n" +
@@ -1004,6 +1216,25 @@ var casesFormat = []struct {
 			"sprop-max-don-diff": "2",
 		},
 	},
+	{
+		"video h265 depack buf nalus",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 H265/90000\n" +
+			"a=fmtp:96 sprop-max-don-diff=2; sprop-depack-buf-nalus=3\n",
+		&H265{
+			PayloadTyp:     96,
+			MaxDONDiff:     2,
+			DepackBufNALUs: 3,
+		},
+		96,
+		"H265/90000",
+		map[string]string{
+			"sprop-max-don-diff":     "2",
+			"sprop-depack-buf-nalus": "3",
+		},
+	},
 	{
 		"video h265 annexb",
 		"v=0\n" +
@@ -1038,6 +1269,30 @@ var casesFormat = []struct {
 			"sprop-pps": "RAHgdrAmQA==",
 		},
 	},
+	{
+		"video h266",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 H266/90000\n" +
+			"a=fmtp:96 sprop-vps=AHEBAg==; sprop-sps=AHkDBA==; " +
+			"sprop-pps=AIEFBg==; sprop-max-don-diff=2\n",
+		&H266{
+			PayloadTyp: 96,
+			VPS:        []byte{0x00, 0x71, 0x01, 0x02},
+			SPS:        []byte{0x00, 0x79, 0x03, 0x04},
+			PPS:        []byte{0x00, 0x81, 0x05, 0x06},
+			MaxDONDiff: 2,
+		},
+		96,
+		"H266/90000",
+		map[string]string{
+			"sprop-vps":          "AHEBAg==",
+			"sprop-sps":          "AHkDBA==",
+			"sprop-pps":          "AIEFBg==",
+			"sprop-max-don-diff": "2",
+		},
+	},
 	{
 		"video vp8",
 		"v=0\n" +
@@ -1099,6 +1354,88 @@ var casesFormat = []struct {
 			"tier":      "1",
 		},
 	},
+	{
+		"video raw",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 96\n" +
+			"a=rtpmap:96 raw/90000\n" +
+			"a=fmtp:96 sampling=YCbCr-4:2:2; width=1920; height=1080; depth=10; " +
+			"colorimetry=BT709-2; interlace\n",
+		&RawVideo{
+			PayloadTyp:  96,
+			Sampling:    "YCbCr-4:2:2",
+			Width:       1920,
+			Height:      1080,
+			Depth:       10,
+			Colorimetry: "BT709-2",
+			Interlaced:  true,
+		},
+		96,
+		"raw/90000",
+		map[string]string{
+			"sampling":    "YCbCr-4:2:2",
+			"width":       "1920",
+			"height":      "1080",
+			"depth":       "10",
+			"colorimetry": "BT709-2",
+			"interlace":   FMTPValueless,
+		},
+	},
+	{
+		"video jpeg 2000",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 98\n" +
+			"a=rtpmap:98 jpeg2000/90000\n" +
+			"a=fmtp:98 sampling=YCbCr-4:2:0; width=1280; height=720\n",
+		&JPEG2000{
+			PayloadTyp: 98,
+			Sampling:   "YCbCr-4:2:0",
+			Width:      1280,
+			Height:     720,
+		},
+		98,
+		"jpeg2000/90000",
+		map[string]string{
+			"sampling": "YCbCr-4:2:0",
+			"width":    "1280",
+			"height":   "720",
+		},
+	},
+	{
+		"video jpeg xs",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 112\n" +
+			"a=rtpmap:112 jxsv/90000\n" +
+			"a=fmtp:112 packetmode=0; profile=High444.12; level=2k-1; sublevel=Sublev3bpp; " +
+			"sampling=YCbCr-4:2:2; depth=10; width=1920; height=1080; interlace\n",
+		&JPEGXS{
+			PayloadTyp: 112,
+			Profile:    "High444.12",
+			Level:      "2k-1",
+			Sublevel:   "Sublev3bpp",
+			Sampling:   "YCbCr-4:2:2",
+			Depth:      10,
+			Width:      1920,
+			Height:     1080,
+			Interlaced: true,
+		},
+		112,
+		"jxsv/90000",
+		map[string]string{
+			"packetmode": "0",
+			"profile":    "High444.12",
+			"level":      "2k-1",
+			"sublevel":   "Sublev3bpp",
+			"sampling":   "YCbCr-4:2:2",
+			"depth":      "10",
+			"width":      "1920",
+			"height":     "1080",
+			"interlace":  FMTPValueless,
+		},
+	},
 	{
 		"application",
 		"v=0\n" +
@@ -1114,6 +1451,47 @@ var casesFormat = []struct {
 		"MetaData/80000",
 		nil,
 	},
+	{
+		"application klv",
+		"v=0\n" +
+			"s=\n" +
+			"m=application 0 RTP/AVP 97\n" +
+			"a=rtpmap:97 SMPTE336M/90000\n",
+		&KLV{
+			PayloadTyp: 97,
+			ClockRat:   90000,
+		},
+		97,
+		"SMPTE336M/90000",
+		nil,
+	},
+	{
+		"application onvif metadata",
+		"v=0\n" +
+			"s=\n" +
+			"m=application 0 RTP/AVP 107\n" +
+			"a=rtpmap:107 vnd.onvif.metadata/90000\n",
+		&ONVIFMetadata{
+			PayloadTyp: 107,
+		},
+		107,
+		"vnd.onvif.metadata/90000",
+		nil,
+	},
+	{
+		"application onvif metadata gzip",
+		"v=0\n" +
+			"s=\n" +
+			"m=application 0 RTP/AVP 108\n" +
+			"a=rtpmap:108 vnd.onvif.metadata.gzip/90000\n",
+		&ONVIFMetadata{
+			PayloadTyp: 108,
+			Encoding:   "gzip",
+		},
+		108,
+		"vnd.onvif.metadata.gzip/90000",
+		nil,
+	},
 	{
 		"application without clock rate",
 		"v=0\n" +
@@ -1234,6 +1612,75 @@ var casesFormat = []struct {
 			"streamtype":       "5",
 		},
 	},
+	{
+		"video rtx",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 97\n" +
+			"a=rtpmap:97 rtx/90000\n" +
+			"a=fmtp:97 apt=96;rtx-time=3000\n",
+		&RTX{
+			PayloadTyp:            97,
+			ClockRat:              90000,
+			AssociatedPayloadType: 96,
+			RTXTime:               intPtr(3000),
+		},
+		97,
+		"rtx/90000",
+		map[string]string{
+			"apt":      "96",
+			"rtx-time": "3000",
+		},
+	},
+	{
+		"text t140",
+		"v=0\n" +
+			"s=\n" +
+			"m=text 0 RTP/AVP 98\n" +
+			"a=rtpmap:98 t140/1000\n" +
+			"a=fmtp:98 cps=30\n",
+		&T140{
+			PayloadTyp: 98,
+			CPS:        intPtr(30),
+		},
+		98,
+		"t140/1000",
+		map[string]string{
+			"cps": "30",
+		},
+	},
+	{
+		"text red",
+		"v=0\n" +
+			"s=\n" +
+			"m=text 0 RTP/AVP 100\n" +
+			"a=rtpmap:100 red/1000\n" +
+			"a=fmtp:100 98/98/98\n",
+		&RED{
+			PayloadTyp:   100,
+			ClockRat:     1000,
+			PayloadTypes: []uint8{98, 98, 98},
+		},
+		100,
+		"red/1000",
+		map[string]string{
+			"98/98/98": FMTPValueless,
+		},
+	},
+	{
+		"video ulpfec",
+		"v=0\n" +
+			"s=\n" +
+			"m=video 0 RTP/AVP 125\n" +
+			"a=rtpmap:125 ulpfec/90000\n",
+		&ULPFEC{
+			PayloadTyp: 125,
+			ClockRat:   90000,
+		},
+		125,
+		"ulpfec/90000",
+		nil,
+	},
 }
 
 func TestUnmarshal(t *testing.T) {
@@ -1295,6 +1742,9 @@ func FuzzUnmarshal(f *testing.F) {
 		case *AC3:
 			require.NotZero(t, f.ChannelCount)
 
+		case *EAC3:
+			require.NotZero(t, f.ChannelCount)
+
 		case *G711:
 			require.NotZero(t, f.ChannelCount)
 
@@ -0,0 +1,103 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpg729"
+)
+
+// G729 is the RTP format for the G729 codec.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type G729 struct {
+	PayloadTyp uint8
+
+	// whether Annex B (silence suppression) is in use.
+	AnnexB bool
+}
+
+func (f *G729) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+	f.AnnexB = true // default value imposed by specification
+
+	for key, val := range ctx.fmtp {
+		if key == "annexb" {
+			switch strings.ToLower(val) {
+			case "yes":
+				f.AnnexB = true
+
+			case "no":
+				f.AnnexB = false
+
+			default:
+				return fmt.Errorf("invalid annexb: %v", val)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *G729) Codec() string {
+	return "G729"
+}
+
+// ClockRate implements Format.
+func (f *G729) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (f *G729) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *G729) RTPMap() string {
+	return "G729/8000"
+}
+
+// FMTP implements Format.
+func (f *G729) FMTP() map[string]string {
+	if !f.AnnexB {
+		return map[string]string{
+			"annexb": "no",
+		}
+	}
+
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *G729) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *G729) CreateDecoder() (*rtpg729.Decoder, error) {
+	d := &rtpg729.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *G729) CreateEncoder() (*rtpg729.Encoder, error) {
+	e := &rtpg729.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,44 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestG729Attributes(t *testing.T) {
+	format := &G729{
+		PayloadTyp: 18,
+		AnnexB:     true,
+	}
+	require.Equal(t, "G729", format.Codec())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestG729DecEncoder(t *testing.T) {
+	format := &G729{
+		PayloadTyp: 18,
+		AnnexB:     true,
+	}
+
+	frames := [][]byte{
+		{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+		{0x0b, 0x0c},
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkt, err := enc.Encode(frames)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, frames, byts)
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpgsm"
+)
+
+// GSM is the RTP format for the GSM 06.10 codec.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type GSM struct {
+	PayloadTyp uint8
+}
+
+func (f *GSM) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+	return nil
+}
+
+// Codec implements Format.
+func (f *GSM) Codec() string {
+	return "GSM"
+}
+
+// ClockRate implements Format.
+func (f *GSM) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (f *GSM) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *GSM) RTPMap() string {
+	return "GSM/8000"
+}
+
+// FMTP implements Format.
+func (f *GSM) FMTP() map[string]string {
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *GSM) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *GSM) CreateDecoder() (*rtpgsm.Decoder, error) {
+	d := &rtpgsm.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *GSM) CreateEncoder() (*rtpgsm.Encoder, error) {
+	e := &rtpgsm.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,40 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGSMAttributes(t *testing.T) {
+	format := &GSM{
+		PayloadTyp: 3,
+	}
+	require.Equal(t, "GSM", format.Codec())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestGSMDecEncoder(t *testing.T) {
+	format := &GSM{
+		PayloadTyp: 3,
+	}
+
+	frame := append([]byte{0xd1}, bytes.Repeat([]byte{0x02}, 32)...)
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkt, err := enc.Encode([][]byte{frame})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{frame}, byts)
+}
@@ -23,6 +23,10 @@ type H264 struct {
 	PPS               []byte
 	PacketizationMode int
 
+	// interleaved mode (PacketizationMode = 2) parameters.
+	InterleavingDepth int
+	DeintBufReq       int
+
 	mutex sync.RWMutex
 }
 
@@ -67,6 +71,22 @@ func (f *H264) unmarshal(ctx *unmarshalContext) error {
 			}
 
 			f.PacketizationMode = int(tmp)
+
+		case "sprop-interleaving-depth":
+			tmp, err := strconv.ParseUint(val, 10, 15)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-interleaving-depth (%v)", val)
+			}
+
+			f.InterleavingDepth = int(tmp)
+
+		case "sprop-deint-buf-req":
+			tmp, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-deint-buf-req (%v)", val)
+			}
+
+			f.DeintBufReq = int(tmp)
 		}
 	}
 
@@ -104,6 +124,11 @@ func (f *H264) FMTP() map[string]string {
 		fmtp["packetization-mode"] = strconv.FormatInt(int64(f.PacketizationMode), 10)
 	}
 
+	if f.PacketizationMode == 2 {
+		fmtp["sprop-interleaving-depth"] = strconv.FormatInt(int64(f.InterleavingDepth), 10)
+		fmtp["sprop-deint-buf-req"] = strconv.FormatInt(int64(f.DeintBufReq), 10)
+	}
+
 	var tmp []string
 	if f.SPS != nil {
 		tmp = append(tmp, base64.StdEncoding.EncodeToString(f.SPS))
@@ -133,9 +158,17 @@ func (f *H264) PTSEqualsDTS(pkt *rtp.Packet) bool {
 	case h264.NALUTypeIDR, h264.NALUTypeSPS, h264.NALUTypePPS:
 		return true
 
-	case 24: // STAP-A
+	case 24, 25: // STAP-A, STAP-B
 		payload := pkt.Payload[1:]
 
+		// skip DON
+		if typ == 25 {
+			if len(payload) < 2 {
+				return false
+			}
+			payload = payload[2:]
+		}
+
 		for {
 			if len(payload) < 2 {
 				return false
@@ -162,7 +195,7 @@ func (f *H264) PTSEqualsDTS(pkt *rtp.Packet) bool {
 			}
 		}
 
-	case 28: // FU-A
+	case 28, 29: // FU-A, FU-B
 		if len(pkt.Payload) < 2 {
 			return false
 		}
@@ -186,6 +219,8 @@ func (f *H264) PTSEqualsDTS(pkt *rtp.Packet) bool {
 func (f *H264) CreateDecoder() (*rtph264.Decoder, error) {
 	d := &rtph264.Decoder{
 		PacketizationMode: f.PacketizationMode,
+		InterleavingDepth: f.InterleavingDepth,
+		DeintBufReq:       f.DeintBufReq,
 	}
 
 	err := d.Init()
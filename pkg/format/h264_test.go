@@ -5,6 +5,8 @@ import (
 
 	"github.com/pion/rtp"
 	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtph264"
 )
 
 func TestH264Attributes(t *testing.T) {
@@ -42,6 +44,16 @@ func TestH264PTSEqualsDTS(t *testing.T) {
 	require.Equal(t, false, format.PTSEqualsDTS(&rtp.Packet{
 		Payload: []byte{0x01},
 	}))
+
+	// IDR inside STAP-B
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x19, 0x00, 0x00, 0x00, 0x02, 0x65, 0x01},
+	}))
+
+	// IDR inside FU-B
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x7d, 0x85, 0x00, 0x00, 0x01},
+	}))
 }
 
 func TestH264DecEncoder(t *testing.T) {
@@ -62,6 +74,44 @@ func TestH264DecEncoder(t *testing.T) {
 	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
 }
 
+func TestH264DecEncoderInterleaved(t *testing.T) {
+	format := &H264{
+		PacketizationMode: 2,
+		DeintBufReq:       1000,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	var aus []*rtph264.AccessUnit
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+	require.Equal(t, 1000, dec.DeintBufReq)
+
+	for i := 0; i < 2; i++ {
+		pkts, err2 := enc.Encode([][]byte{{0x01, 0x02, 0x03, byte(i)}})
+		require.NoError(t, err2)
+		require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+		pkts[0].Timestamp = uint32(i) * 3000
+
+		addAUs, err2 := dec.DecodeInterleaved(pkts[0])
+		if i == 0 {
+			require.Equal(t, rtph264.ErrMorePacketsNeeded, err2)
+			continue
+		}
+
+		require.NoError(t, err2)
+		aus = append(aus, addAUs...)
+	}
+
+	require.Equal(t, []*rtph264.AccessUnit{{
+		Timestamp: 0,
+		NALUs:     [][]byte{{0x01, 0x02, 0x03, 0x00}},
+	}}, aus)
+}
+
 func FuzzH264PTSEqualsDTS(f *testing.F) {
 	f.Fuzz(func(_ *testing.T, b []byte) {
 		(&H264{}).PTSEqualsDTS(&rtp.Packet{Payload: b})
@@ -22,6 +22,10 @@ type H265 struct {
 	PPS        []byte
 	MaxDONDiff int
 
+	// maximum number of NALUs that precede any NALU in the de-packetization buffer
+	// in reception order and follow it in decoding order.
+	DepackBufNALUs int
+
 	mutex sync.RWMutex
 }
 
@@ -78,6 +82,13 @@ func (f *H265) unmarshal(ctx *unmarshalContext) error {
 				return fmt.Errorf("invalid sprop-max-don-diff (%v)", ctx.fmtp)
 			}
 			f.MaxDONDiff = int(tmp)
+
+		case "sprop-depack-buf-nalus":
+			tmp, err := strconv.ParseUint(val, 10, 15)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-depack-buf-nalus (%v)", ctx.fmtp)
+			}
+			f.DepackBufNALUs = int(tmp)
 		}
 	}
 
@@ -122,6 +133,9 @@ func (f *H265) FMTP() map[string]string {
 	if f.MaxDONDiff != 0 {
 		fmtp["sprop-max-don-diff"] = strconv.FormatInt(int64(f.MaxDONDiff), 10)
 	}
+	if f.DepackBufNALUs != 0 {
+		fmtp["sprop-depack-buf-nalus"] = strconv.FormatInt(int64(f.DepackBufNALUs), 10)
+	}
 
 	return fmtp
 }
@@ -134,19 +148,53 @@ func (f *H265) PTSEqualsDTS(pkt *rtp.Packet) bool {
 
 	typ := h265.NALUType((pkt.Payload[0] >> 1) & 0b111111)
 
+	// payload without the payload header
+	var payload []byte
+	if len(pkt.Payload) >= 2 {
+		payload = pkt.Payload[2:]
+	}
+
+	// use the type of the packet contained into PACI packets
+	if typ == h265.NALUType_PACI {
+		if len(payload) < 2 {
+			return false
+		}
+
+		phsSize := int(payload[0]&0x01)<<4 | int(payload[1]>>4)
+		if len(payload) < (2 + phsSize) {
+			return false
+		}
+
+		typ = h265.NALUType((payload[0] >> 1) & 0b111111)
+		payload = payload[2+phsSize:]
+	}
+
 	switch typ {
 	case h265.NALUType_IDR_W_RADL, h265.NALUType_IDR_N_LP, h265.NALUType_CRA_NUT,
 		h265.NALUType_VPS_NUT, h265.NALUType_SPS_NUT, h265.NALUType_PPS_NUT:
 		return true
 
 	case h265.NALUType_AggregationUnit:
-		if len(pkt.Payload) < 4 {
-			return false
-		}
-
-		payload := pkt.Payload[2:]
+		first := true
 
 		for {
+			// skip DONL or DOND
+			if f.MaxDONDiff != 0 {
+				n := 1
+				if first {
+					n = 2
+				}
+				if len(payload) < n {
+					return false
+				}
+				payload = payload[n:]
+				first = false
+			}
+
+			if len(payload) < 2 {
+				return false
+			}
+
 			size := uint16(payload[0])<<8 | uint16(payload[1])
 			payload = payload[2:]
 
@@ -167,23 +215,19 @@ func (f *H265) PTSEqualsDTS(pkt *rtp.Packet) bool {
 			if len(payload) == 0 {
 				break
 			}
-
-			if len(payload) < 2 {
-				return false
-			}
 		}
 
 	case h265.NALUType_FragmentationUnit:
-		if len(pkt.Payload) < 3 {
+		if len(payload) < 1 {
 			return false
 		}
 
-		start := pkt.Payload[2] >> 7
+		start := payload[0] >> 7
 		if start != 1 {
 			return false
 		}
 
-		typ := h265.NALUType(pkt.Payload[2] & 0b111111)
+		typ := h265.NALUType(payload[0] & 0b111111)
 		switch typ {
 		case h265.NALUType_IDR_W_RADL, h265.NALUType_IDR_N_LP, h265.NALUType_CRA_NUT,
 			h265.NALUType_VPS_NUT, h265.NALUType_SPS_NUT, h265.NALUType_PPS_NUT:
@@ -197,7 +241,8 @@ func (f *H265) PTSEqualsDTS(pkt *rtp.Packet) bool {
 // CreateDecoder creates a decoder able to decode the content of the format.
 func (f *H265) CreateDecoder() (*rtph265.Decoder, error) {
 	d := &rtph265.Decoder{
-		MaxDONDiff: f.MaxDONDiff,
+		MaxDONDiff:     f.MaxDONDiff,
+		DepackBufNALUs: f.DepackBufNALUs,
 	}
 
 	err := d.Init()
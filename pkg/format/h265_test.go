@@ -51,6 +51,30 @@ func TestH265PTSEqualsDTS(t *testing.T) {
 	require.Equal(t, false, format.PTSEqualsDTS(&rtp.Packet{
 		Payload: []byte{byte(h265.NALUType_TRAIL_N) << 1},
 	}))
+
+	// CRA_NUT inside PACI
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x64, 0x01, 0x2a, 0x38, 0x05, 0x06, 0x80, 0x03},
+	}))
+
+	// CRA_NUT inside FragmentationUnit inside PACI
+	pkt := &rtp.Packet{
+		Payload: []byte{0x64, 0x01, 0x62, 0x00, 0x95, 0xaf},
+	}
+	require.Equal(t, true, format.PTSEqualsDTS(pkt))
+	require.Zero(t, testing.AllocsPerRun(10, func() {
+		format.PTSEqualsDTS(pkt)
+	}))
+
+	format.MaxDONDiff = 2
+
+	// VPS_NUT inside AggregationUnit with DONL
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{
+			0x60, 0x00, 0x00, 0x00, 0x00, 0x03, 0x02, 0x01,
+			0x07, 0x00, 0x00, 0x03, 0x40, 0x01, 0x08,
+		},
+	}))
 }
 
 func TestH265DecEncoder(t *testing.T) {
@@ -71,6 +95,26 @@ func TestH265DecEncoder(t *testing.T) {
 	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
 }
 
+func TestH265DecEncoderDON(t *testing.T) {
+	format := &H265{
+		MaxDONDiff: 2,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
+}
+
 func FuzzH265PTSEqualsDTS(f *testing.F) {
 	f.Fuzz(func(_ *testing.T, b []byte) {
 		(&H265{}).PTSEqualsDTS(&rtp.Packet{Payload: b})
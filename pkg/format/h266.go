@@ -0,0 +1,239 @@
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtph266"
+)
+
+// H266 NALU types that are relevant to PTSEqualsDTS.
+const (
+	h266NALUTypeIDRWRADL          = 7
+	h266NALUTypeIDRNLP            = 8
+	h266NALUTypeCRA               = 9
+	h266NALUTypeVPS               = 14
+	h266NALUTypeSPS               = 15
+	h266NALUTypePPS               = 16
+	h266NALUTypeAggregationPacket = 28
+	h266NALUTypeFragmentationUnit = 29
+)
+
+func h266IsRandomAccess(typ uint8) bool {
+	switch typ {
+	case h266NALUTypeIDRWRADL, h266NALUTypeIDRNLP, h266NALUTypeCRA,
+		h266NALUTypeVPS, h266NALUTypeSPS, h266NALUTypePPS:
+		return true
+	}
+	return false
+}
+
+// H266 is the RTP format for the H266 codec.
+// Specification: https://datatracker.ietf.org/doc/html/rfc9328
+type H266 struct {
+	PayloadTyp uint8
+	VPS        []byte
+	SPS        []byte
+	PPS        []byte
+	MaxDONDiff int
+
+	mutex sync.RWMutex
+}
+
+func (f *H266) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	for key, val := range ctx.fmtp {
+		switch key {
+		case "sprop-vps":
+			var err error
+			f.VPS, err = base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-vps (%v)", ctx.fmtp)
+			}
+
+			// some cameras ship parameters with Annex-B prefix
+			f.VPS = bytes.TrimPrefix(f.VPS, []byte{0, 0, 0, 1})
+
+		case "sprop-sps":
+			var err error
+			f.SPS, err = base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-sps (%v)", ctx.fmtp)
+			}
+
+			// some cameras ship parameters with Annex-B prefix
+			f.SPS = bytes.TrimPrefix(f.SPS, []byte{0, 0, 0, 1})
+
+		case "sprop-pps":
+			var err error
+			f.PPS, err = base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-pps (%v)", ctx.fmtp)
+			}
+
+			// some cameras ship parameters with Annex-B prefix
+			f.PPS = bytes.TrimPrefix(f.PPS, []byte{0, 0, 0, 1})
+
+		case "sprop-max-don-diff":
+			tmp, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid sprop-max-don-diff (%v)", ctx.fmtp)
+			}
+			f.MaxDONDiff = int(tmp)
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *H266) Codec() string {
+	return "H266"
+}
+
+// ClockRate implements Format.
+func (f *H266) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (f *H266) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *H266) RTPMap() string {
+	return "H266/90000"
+}
+
+// FMTP implements Format.
+func (f *H266) FMTP() map[string]string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	fmtp := make(map[string]string)
+	if f.VPS != nil {
+		fmtp["sprop-vps"] = base64.StdEncoding.EncodeToString(f.VPS)
+	}
+	if f.SPS != nil {
+		fmtp["sprop-sps"] = base64.StdEncoding.EncodeToString(f.SPS)
+	}
+	if f.PPS != nil {
+		fmtp["sprop-pps"] = base64.StdEncoding.EncodeToString(f.PPS)
+	}
+	if f.MaxDONDiff != 0 {
+		fmtp["sprop-max-don-diff"] = strconv.FormatInt(int64(f.MaxDONDiff), 10)
+	}
+
+	return fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (f *H266) PTSEqualsDTS(pkt *rtp.Packet) bool {
+	if len(pkt.Payload) < 2 {
+		return false
+	}
+
+	typ := pkt.Payload[1] >> 3
+
+	switch typ {
+	case h266NALUTypeAggregationPacket:
+		if len(pkt.Payload) < 4 {
+			return false
+		}
+
+		payload := pkt.Payload[2:]
+
+		for {
+			size := uint16(payload[0])<<8 | uint16(payload[1])
+			payload = payload[2:]
+
+			if size < 2 || int(size) > len(payload) {
+				return false
+			}
+
+			var nalu []byte
+			nalu, payload = payload[:size], payload[size:]
+
+			if h266IsRandomAccess(nalu[1] >> 3) {
+				return true
+			}
+
+			if len(payload) == 0 {
+				break
+			}
+
+			if len(payload) < 2 {
+				return false
+			}
+		}
+
+	case h266NALUTypeFragmentationUnit:
+		if len(pkt.Payload) < 3 {
+			return false
+		}
+
+		start := pkt.Payload[2] >> 7
+		if start != 1 {
+			return false
+		}
+
+		return h266IsRandomAccess(pkt.Payload[2] & 0b11111)
+
+	default:
+		return h266IsRandomAccess(typ)
+	}
+
+	return false
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *H266) CreateDecoder() (*rtph266.Decoder, error) {
+	d := &rtph266.Decoder{
+		MaxDONDiff: f.MaxDONDiff,
+	}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *H266) CreateEncoder() (*rtph266.Encoder, error) {
+	e := &rtph266.Encoder{
+		PayloadType: f.PayloadTyp,
+		MaxDONDiff:  f.MaxDONDiff,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// SafeSetParams sets the codec parameters.
+func (f *H266) SafeSetParams(vps []byte, sps []byte, pps []byte) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.VPS = vps
+	f.SPS = sps
+	f.PPS = pps
+}
+
+// SafeParams returns the codec parameters.
+func (f *H266) SafeParams() ([]byte, []byte, []byte) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.VPS, f.SPS, f.PPS
+}
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestILBCAttributes(t *testing.T) {
+	format := &ILBC{
+		PayloadTyp: 97,
+		Mode:       30,
+	}
+	require.Equal(t, "iLBC", format.Codec())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestILBCDecEncoder(t *testing.T) {
+	format := &ILBC{
+		PayloadTyp: 97,
+		Mode:       20,
+	}
+
+	frame := bytes.Repeat([]byte{0x01}, 38)
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkt, err := enc.Encode([][]byte{frame})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{frame}, byts)
+}
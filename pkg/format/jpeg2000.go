@@ -0,0 +1,131 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpjpeg2000"
+)
+
+// JPEG2000 is the RTP format for the JPEG 2000 codec.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5371
+type JPEG2000 struct {
+	PayloadTyp uint8
+	Sampling   string
+	Width      int
+	Height     int
+	Interlaced bool
+}
+
+func (f *JPEG2000) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	for key, val := range ctx.fmtp {
+		switch key {
+		case "sampling":
+			f.Sampling = val
+
+		case "width":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid width: %v", val)
+			}
+
+			f.Width = int(n)
+
+		case "height":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid height: %v", val)
+			}
+
+			f.Height = int(n)
+
+		case "interlace":
+			f.Interlaced = true
+		}
+	}
+
+	// interlace is usually a parameter without value
+	for _, param := range decodeFMTPValuelessParams(ctx.fmtpRaw) {
+		if strings.ToLower(param) == "interlace" {
+			f.Interlaced = true
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *JPEG2000) Codec() string {
+	return "JPEG 2000"
+}
+
+// ClockRate implements Format.
+func (f *JPEG2000) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (f *JPEG2000) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *JPEG2000) RTPMap() string {
+	return "jpeg2000/90000"
+}
+
+// FMTP implements Format.
+func (f *JPEG2000) FMTP() map[string]string {
+	fmtp := make(map[string]string)
+
+	if f.Sampling != "" {
+		fmtp["sampling"] = f.Sampling
+	}
+	if f.Width != 0 {
+		fmtp["width"] = strconv.FormatInt(int64(f.Width), 10)
+	}
+	if f.Height != 0 {
+		fmtp["height"] = strconv.FormatInt(int64(f.Height), 10)
+	}
+	if f.Interlaced {
+		fmtp["interlace"] = FMTPValueless
+	}
+
+	return fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (f *JPEG2000) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *JPEG2000) CreateDecoder() (*rtpjpeg2000.Decoder, error) {
+	d := &rtpjpeg2000.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *JPEG2000) CreateEncoder() (*rtpjpeg2000.Encoder, error) {
+	e := &rtpjpeg2000.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,52 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJPEG2000Attributes(t *testing.T) {
+	format := &JPEG2000{
+		PayloadTyp: 96,
+		Sampling:   "YCbCr-4:2:0",
+	}
+	require.Equal(t, "JPEG 2000", format.Codec())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestJPEG2000DecEncoder(t *testing.T) {
+	format := &JPEG2000{
+		PayloadTyp: 96,
+	}
+
+	codestream := []byte{
+		0xff, 0x4f,
+		0xff, 0x51, 0x00, 0x04, 0x01, 0x02,
+		0xff, 0x90, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0xff, 0x93,
+		0x03, 0x04,
+		0xff, 0xd9,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode(codestream)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	var byts []byte
+
+	for _, pkt := range pkts {
+		byts, err = dec.Decode(pkt)
+	}
+
+	require.NoError(t, err)
+	require.Equal(t, codestream, byts)
+}
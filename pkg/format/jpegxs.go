@@ -0,0 +1,176 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpjpegxs"
+)
+
+// JPEGXS is the RTP format for the JPEG XS codec.
+// Specification: https://datatracker.ietf.org/doc/html/rfc9134
+type JPEGXS struct {
+	PayloadTyp        uint8
+	PacketizationMode int
+	Profile           string
+	Level             string
+	Sublevel          string
+	Sampling          string
+	Depth             int
+	Width             int
+	Height            int
+	Interlaced        bool
+}
+
+func (f *JPEGXS) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	for key, val := range ctx.fmtp {
+		switch key {
+		case "packetmode":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil || n > 1 {
+				return fmt.Errorf("invalid packetmode: %v", val)
+			}
+
+			f.PacketizationMode = int(n)
+
+		case "profile":
+			f.Profile = val
+
+		case "level":
+			f.Level = val
+
+		case "sublevel":
+			f.Sublevel = val
+
+		case "sampling":
+			f.Sampling = val
+
+		case "depth":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid depth: %v", val)
+			}
+
+			f.Depth = int(n)
+
+		case "width":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid width: %v", val)
+			}
+
+			f.Width = int(n)
+
+		case "height":
+			n, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid height: %v", val)
+			}
+
+			f.Height = int(n)
+
+		case "interlace":
+			f.Interlaced = true
+		}
+	}
+
+	// interlace is usually a parameter without value
+	for _, param := range decodeFMTPValuelessParams(ctx.fmtpRaw) {
+		if strings.ToLower(param) == "interlace" {
+			f.Interlaced = true
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *JPEGXS) Codec() string {
+	return "JPEG XS"
+}
+
+// ClockRate implements Format.
+func (f *JPEGXS) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (f *JPEGXS) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *JPEGXS) RTPMap() string {
+	return "jxsv/90000"
+}
+
+// FMTP implements Format.
+func (f *JPEGXS) FMTP() map[string]string {
+	fmtp := map[string]string{
+		"packetmode": strconv.FormatInt(int64(f.PacketizationMode), 10),
+	}
+
+	if f.Profile != "" {
+		fmtp["profile"] = f.Profile
+	}
+	if f.Level != "" {
+		fmtp["level"] = f.Level
+	}
+	if f.Sublevel != "" {
+		fmtp["sublevel"] = f.Sublevel
+	}
+	if f.Sampling != "" {
+		fmtp["sampling"] = f.Sampling
+	}
+	if f.Depth != 0 {
+		fmtp["depth"] = strconv.FormatInt(int64(f.Depth), 10)
+	}
+	if f.Width != 0 {
+		fmtp["width"] = strconv.FormatInt(int64(f.Width), 10)
+	}
+	if f.Height != 0 {
+		fmtp["height"] = strconv.FormatInt(int64(f.Height), 10)
+	}
+	if f.Interlaced {
+		fmtp["interlace"] = FMTPValueless
+	}
+
+	return fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (f *JPEGXS) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *JPEGXS) CreateDecoder() (*rtpjpegxs.Decoder, error) {
+	d := &rtpjpegxs.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *JPEGXS) CreateEncoder() (*rtpjpegxs.Encoder, error) {
+	e := &rtpjpegxs.Encoder{
+		PayloadType:       f.PayloadTyp,
+		PacketizationMode: rtpjpegxs.PacketizationMode(f.PacketizationMode),
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,85 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpklv"
+)
+
+// KLV is the RTP format for SMPTE 336M Key-Length-Value metadata.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6597
+type KLV struct {
+	PayloadTyp uint8
+	ClockRat   int
+}
+
+func (f *KLV) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	clockRate, err := strconv.ParseUint(ctx.clock, 10, 31)
+	if err != nil || clockRate == 0 {
+		return fmt.Errorf("invalid clock rate: '%s'", ctx.clock)
+	}
+	f.ClockRat = int(clockRate)
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *KLV) Codec() string {
+	return "KLV"
+}
+
+// ClockRate implements Format.
+func (f *KLV) ClockRate() int {
+	return f.ClockRat
+}
+
+// PayloadType implements Format.
+func (f *KLV) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *KLV) RTPMap() string {
+	return "SMPTE336M/" + strconv.FormatInt(int64(f.ClockRat), 10)
+}
+
+// FMTP implements Format.
+func (f *KLV) FMTP() map[string]string {
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *KLV) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *KLV) CreateDecoder() (*rtpklv.Decoder, error) {
+	d := &rtpklv.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *KLV) CreateEncoder() (*rtpklv.Encoder, error) {
+	e := &rtpklv.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
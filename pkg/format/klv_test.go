@@ -0,0 +1,45 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKLVAttributes(t *testing.T) {
+	format := &KLV{
+		PayloadTyp: 96,
+		ClockRat:   90000,
+	}
+	require.Equal(t, "KLV", format.Codec())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestKLVDecEncoder(t *testing.T) {
+	format := &KLV{
+		PayloadTyp: 96,
+		ClockRat:   90000,
+	}
+
+	set := []byte{
+		0x06, 0x0e, 0x2b, 0x34, 0x02, 0x0b, 0x01, 0x01,
+		0x0e, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00,
+		0x02, 0x01, 0x02,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{set})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	sets, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{set}, sets)
+}
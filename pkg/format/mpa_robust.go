@@ -0,0 +1,75 @@
+package format
+
+import (
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmparobust"
+)
+
+// MPARobust is the RTP format for the MPEG-1/2 Audio codec,
+// transmitted in loss-tolerant form (Application Data Units).
+// Specification: https://datatracker.ietf.org/doc/html/rfc5219
+type MPARobust struct {
+	PayloadTyp uint8
+}
+
+func (f *MPARobust) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+	return nil
+}
+
+// Codec implements Format.
+func (f *MPARobust) Codec() string {
+	return "MPEG-1/2 Audio (ADU)"
+}
+
+// ClockRate implements Format.
+func (f *MPARobust) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (f *MPARobust) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *MPARobust) RTPMap() string {
+	return "mpa-robust/90000"
+}
+
+// FMTP implements Format.
+func (f *MPARobust) FMTP() map[string]string {
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *MPARobust) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *MPARobust) CreateDecoder() (*rtpmparobust.Decoder, error) {
+	d := &rtpmparobust.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *MPARobust) CreateEncoder() (*rtpmparobust.Encoder, error) {
+	e := &rtpmparobust.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
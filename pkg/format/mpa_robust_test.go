@@ -0,0 +1,40 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPARobustAttributes(t *testing.T) {
+	format := &MPARobust{
+		PayloadTyp: 96,
+	}
+	require.Equal(t, "MPEG-1/2 Audio (ADU)", format.Codec())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestMPARobustDecEncoder(t *testing.T) {
+	format := &MPARobust{
+		PayloadTyp: 96,
+	}
+
+	adu := append([]byte{0xff, 0xfb, 0x94, 0x64}, bytes.Repeat([]byte{0x01}, 100)...)
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{adu})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{adu}, byts)
+}
@@ -0,0 +1,94 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtponvifmetadata"
+)
+
+// ONVIFMetadata is the RTP format for ONVIF metadata streams.
+// Specification: ONVIF Streaming Specification, section 5.1.2.1.1
+type ONVIFMetadata struct {
+	PayloadTyp uint8
+
+	// encoding of documents.
+	// It can be empty (plain XML), "gzip", "exi.onvif" or "exi.ext".
+	Encoding string
+}
+
+func (f *ONVIFMetadata) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	if ctx.clock != "90000" {
+		return fmt.Errorf("invalid clock rate: '%s'", ctx.clock)
+	}
+
+	f.Encoding = strings.TrimPrefix(strings.TrimPrefix(ctx.codec, "vnd.onvif.metadata"), ".")
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *ONVIFMetadata) Codec() string {
+	return "ONVIF Metadata"
+}
+
+// ClockRate implements Format.
+func (f *ONVIFMetadata) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (f *ONVIFMetadata) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *ONVIFMetadata) RTPMap() string {
+	codec := "vnd.onvif.metadata"
+
+	if f.Encoding != "" {
+		codec += "." + f.Encoding
+	}
+
+	return codec + "/90000"
+}
+
+// FMTP implements Format.
+func (f *ONVIFMetadata) FMTP() map[string]string {
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *ONVIFMetadata) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *ONVIFMetadata) CreateDecoder() (*rtponvifmetadata.Decoder, error) {
+	d := &rtponvifmetadata.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *ONVIFMetadata) CreateEncoder() (*rtponvifmetadata.Encoder, error) {
+	e := &rtponvifmetadata.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
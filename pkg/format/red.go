@@ -0,0 +1,86 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+)
+
+// RED is the RTP payload format for redundant data.
+// Packets of this format carry a primary block and redundant copies of
+// previous blocks of another format of the same media.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2198
+type RED struct {
+	PayloadTyp uint8
+	ClockRat   int
+
+	// payload types of the primary block and of redundant blocks (optional).
+	PayloadTypes []uint8
+}
+
+func (f *RED) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	clockRate, err := strconv.ParseUint(ctx.clock, 10, 31)
+	if err != nil || clockRate == 0 {
+		return fmt.Errorf("invalid clock rate: '%s'", ctx.clock)
+	}
+	f.ClockRat = int(clockRate)
+
+	// the payload type list is a parameter without value
+	for _, param := range decodeFMTPValuelessParams(ctx.fmtpRaw) {
+		for _, part := range strings.Split(param, "/") {
+			tmp, err := strconv.ParseUint(part, 10, 7)
+			if err != nil {
+				return fmt.Errorf("invalid payload types: %v", param)
+			}
+
+			f.PayloadTypes = append(f.PayloadTypes, uint8(tmp))
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *RED) Codec() string {
+	return "RED"
+}
+
+// ClockRate implements Format.
+func (f *RED) ClockRate() int {
+	return f.ClockRat
+}
+
+// PayloadType implements Format.
+func (f *RED) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *RED) RTPMap() string {
+	return "red/" + strconv.FormatInt(int64(f.ClockRat), 10)
+}
+
+// FMTP implements Format.
+func (f *RED) FMTP() map[string]string {
+	if len(f.PayloadTypes) == 0 {
+		return nil
+	}
+
+	tmp := make([]string, len(f.PayloadTypes))
+	for i, pt := range f.PayloadTypes {
+		tmp[i] = strconv.FormatUint(uint64(pt), 10)
+	}
+
+	return map[string]string{
+		strings.Join(tmp, "/"): FMTPValueless,
+	}
+}
+
+// PTSEqualsDTS implements Format.
+func (f *RED) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
@@ -0,0 +1,19 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestREDAttributes(t *testing.T) {
+	format := &RED{
+		PayloadTyp:   100,
+		ClockRat:     1000,
+		PayloadTypes: []uint8{98, 98, 98},
+	}
+	require.Equal(t, "RED", format.Codec())
+	require.Equal(t, 1000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
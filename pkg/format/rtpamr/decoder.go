@@ -0,0 +1,119 @@
+package rtpamr
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/AMR decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4867
+type Decoder struct {
+	// whether the stream is AMR-WB.
+	Wideband bool
+
+	// whether the octet-aligned mode is in use.
+	OctetAlign bool
+
+	// whether frames are protected by CRCs.
+	// CRCs are skipped without being verified.
+	CRC bool
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	if d.CRC && !d.OctetAlign {
+		return fmt.Errorf("CRC requires the octet-aligned mode")
+	}
+
+	return nil
+}
+
+// Decode decodes frames from a RTP packet.
+// Each frame is returned in the AMR storage format, that is a header byte
+// containing frame type and quality indicator, followed by speech data.
+// Each frame lasts 20ms. In case of multiple channels, frames of the same
+// frame-block are consecutive.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	buf := pkt.Payload
+	pos := 0
+
+	// skip codec mode request
+	var err error
+	if d.OctetAlign {
+		_, err = bits.ReadBits(buf, &pos, 8)
+	} else {
+		_, err = bits.ReadBits(buf, &pos, 4)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var frameTypes []uint8
+	var qualities []uint8
+
+	// table of contents
+	for {
+		var entry uint64
+		if d.OctetAlign {
+			entry, err = bits.ReadBits(buf, &pos, 8)
+			entry >>= 2
+		} else {
+			entry, err = bits.ReadBits(buf, &pos, 6)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		frameTypes = append(frameTypes, uint8((entry>>1)&0x0F))
+		qualities = append(qualities, uint8(entry&0x01))
+
+		if (entry >> 5) == 0 {
+			break
+		}
+	}
+
+	sizes := make([]int, len(frameTypes))
+
+	for i, frameType := range frameTypes {
+		sizes[i], err = frameBits(d.Wideband, frameType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d.CRC {
+		for _, size := range sizes {
+			if size != 0 {
+				_, err = bits.ReadBits(buf, &pos, 8)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	frames := make([][]byte, len(frameTypes))
+
+	for i, size := range sizes {
+		err = bits.HasSpace(buf, pos, size)
+		if err != nil {
+			return nil, err
+		}
+
+		frame := make([]byte, 1+(size+7)/8)
+		frame[0] = frameTypes[i]<<3 | qualities[i]<<2
+
+		framePos := 8
+		copyBits(frame, &framePos, buf, &pos, size)
+
+		if d.OctetAlign {
+			pos = (pos + 7) &^ 0x07
+		}
+
+		frames[i] = frame
+	}
+
+	return frames, nil
+}
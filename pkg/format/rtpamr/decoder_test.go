@@ -0,0 +1,116 @@
+package rtpamr
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				Wideband:   ca.wideband,
+				OctetAlign: ca.octetAlign,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var frames [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addFrames, err := d.Decode(pkt)
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				frames = append(frames, addFrames...)
+			}
+
+			require.Equal(t, ca.frames, frames)
+		})
+	}
+}
+
+func TestDecodeCRC(t *testing.T) {
+	d := &Decoder{
+		OctetAlign: true,
+		CRC:        true,
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	// a SID frame with CRC, followed by a NO_DATA frame without CRC.
+	frames, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         false,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0xf0, 0xc4, 0x7c, 0xaa, 0x01, 0x02, 0x03, 0x04, 0x0e},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{
+		{0x44, 0x01, 0x02, 0x03, 0x04, 0x0e},
+		{0x7c},
+	}, frames)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		payload []byte
+		err     string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bits",
+		},
+		{
+			"missing toc",
+			[]byte{0xf8},
+			"not enough bits",
+		},
+		{
+			"invalid frame type",
+			[]byte{0xf4, 0xc0},
+			"invalid frame type (9)",
+		},
+		{
+			"missing data",
+			[]byte{0xf3, 0xc0, 0x00},
+			"not enough bits",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			_, err = d.Decode(&rtp.Packet{Payload: ca.payload})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte, wideband bool, octetAlign bool) {
+		d := &Decoder{
+			Wideband:   wideband,
+			OctetAlign: octetAlign,
+		}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,217 @@
+package rtpamr
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+
+	// codec mode request that means that no specific mode is requested.
+	cmrNoRequest = 15
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/AMR encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4867
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// whether the stream is AMR-WB.
+	Wideband bool
+
+	// whether to use the octet-aligned mode.
+	OctetAlign bool
+
+	// channel count (optional).
+	// It defaults to 1.
+	ChannelCount int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.ChannelCount == 0 {
+		e.ChannelCount = 1
+	}
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+func (e *Encoder) lenFrame(size int) int {
+	// table of contents entry and speech data
+	if e.OctetAlign {
+		return 8 + ((size+7)/8)*8
+	}
+	return 6 + size
+}
+
+func (e *Encoder) lenHeader() int {
+	if e.OctetAlign {
+		return 8
+	}
+	return 4
+}
+
+// Encode encodes frames into RTP packets.
+// Each frame must be in the AMR storage format, that is a header byte
+// containing frame type and quality indicator, followed by speech data.
+// In case of multiple channels, frames of the same frame-block must be consecutive.
+// Frames are grouped together as long as they fit into a packet.
+// Timestamps of packets are relative to the first frame.
+func (e *Encoder) Encode(frames [][]byte) ([]*rtp.Packet, error) {
+	if (len(frames) % e.ChannelCount) != 0 {
+		return nil, fmt.Errorf("invalid frame count")
+	}
+
+	sizes := make([]int, len(frames))
+
+	for i, frame := range frames {
+		if len(frame) < 1 {
+			return nil, fmt.Errorf("invalid frame")
+		}
+
+		size, err := frameBits(e.Wideband, (frame[0]>>3)&0x0F)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(frame) != (1 + (size+7)/8) {
+			return nil, fmt.Errorf("invalid frame size")
+		}
+
+		sizes[i] = size
+	}
+
+	var rets []*rtp.Packet
+	timestamp := uint32(0)
+	start := 0
+	packetBits := e.lenHeader()
+
+	for i := 0; i < len(frames); i += e.ChannelCount {
+		blockBits := 0
+		for j := i; j < (i + e.ChannelCount); j++ {
+			blockBits += e.lenFrame(sizes[j])
+		}
+
+		if ((packetBits + blockBits + 7) / 8) > e.PayloadMaxSize {
+			if i == start {
+				return nil, fmt.Errorf("frame is too big")
+			}
+
+			rets = append(rets, e.writePacket(frames[start:i], sizes[start:i], packetBits, timestamp))
+			timestamp += uint32((i-start)/e.ChannelCount) * samplesPerFrame(e.Wideband)
+			start = i
+			packetBits = e.lenHeader()
+
+			if ((packetBits + blockBits + 7) / 8) > e.PayloadMaxSize {
+				return nil, fmt.Errorf("frame is too big")
+			}
+		}
+
+		packetBits += blockBits
+	}
+
+	if len(frames) != 0 {
+		rets = append(rets, e.writePacket(frames[start:], sizes[start:], packetBits, timestamp))
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writePacket(frames [][]byte, sizes []int, packetBits int, timestamp uint32) *rtp.Packet {
+	payload := make([]byte, (packetBits+7)/8)
+	pos := 0
+
+	if e.OctetAlign {
+		bits.WriteBitsUnsafe(payload, &pos, cmrNoRequest<<4, 8)
+	} else {
+		bits.WriteBitsUnsafe(payload, &pos, cmrNoRequest, 4)
+	}
+
+	for i, frame := range frames {
+		follows := uint64(0)
+		if i != (len(frames) - 1) {
+			follows = 1
+		}
+
+		entry := follows<<5 | uint64((frame[0]>>2)&0x1F)
+
+		if e.OctetAlign {
+			bits.WriteBitsUnsafe(payload, &pos, entry<<2, 8)
+		} else {
+			bits.WriteBitsUnsafe(payload, &pos, entry, 6)
+		}
+	}
+
+	for i, frame := range frames {
+		framePos := 8
+		copyBits(payload, &pos, frame, &framePos, sizes[i])
+
+		if e.OctetAlign {
+			pos = (pos + 7) &^ 0x07
+		}
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      timestamp,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
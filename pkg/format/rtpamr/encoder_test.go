@@ -0,0 +1,216 @@
+package rtpamr
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var testFrameNB1 = []byte{
+	0x3c, 0x01, 0x08, 0x0f, 0x16, 0x1d, 0x24, 0x2b,
+	0x32, 0x39, 0x40, 0x47, 0x4e, 0x55, 0x5c, 0x63,
+	0x6a, 0x71, 0x78, 0x7f, 0x86, 0x8d, 0x94, 0x9b,
+	0xa2, 0xa9, 0xb0, 0xb7, 0xbe, 0xc5, 0xcc, 0xd0,
+}
+
+var testFrameNB2 = []byte{
+	0x14, 0x40, 0x47, 0x4e, 0x55, 0x5c, 0x63, 0x6a,
+	0x71, 0x78, 0x7f, 0x86, 0x8d, 0x94, 0x9b, 0xa0,
+}
+
+var testFrameWB = []byte{
+	0x44, 0x03, 0x0a, 0x11, 0x18, 0x1f, 0x26, 0x2d,
+	0x34, 0x3b, 0x42, 0x49, 0x50, 0x57, 0x5e, 0x65,
+	0x6c, 0x73, 0x7a, 0x81, 0x88, 0x8f, 0x96, 0x9d,
+	0xa4, 0xab, 0xb2, 0xb9, 0xc0, 0xc7, 0xce, 0xd5,
+	0xdc, 0xe3, 0xea, 0xf1, 0xf8, 0xff, 0x06, 0x0d,
+	0x14, 0x1b, 0x22, 0x29, 0x30, 0x37, 0x3e, 0x45,
+	0x4c, 0x53, 0x5a, 0x61, 0x68, 0x6f, 0x76, 0x7d,
+	0x84, 0x8b, 0x92, 0x99, 0xa0,
+}
+
+var cases = []struct {
+	name       string
+	wideband   bool
+	octetAlign bool
+	frames     [][]byte
+	pkts       []*rtp.Packet
+}{
+	{
+		"bandwidth efficient",
+		false,
+		false,
+		[][]byte{testFrameNB1, testFrameNB2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0xfb, 0xc5, 0x01, 0x08, 0x0f, 0x16, 0x1d, 0x24,
+					0x2b, 0x32, 0x39, 0x40, 0x47, 0x4e, 0x55, 0x5c,
+					0x63, 0x6a, 0x71, 0x78, 0x7f, 0x86, 0x8d, 0x94,
+					0x9b, 0xa2, 0xa9, 0xb0, 0xb7, 0xbe, 0xc5, 0xcc,
+					0xd4, 0x04, 0x74, 0xe5, 0x55, 0xc6, 0x36, 0xa7,
+					0x17, 0x87, 0xf8, 0x68, 0xd9, 0x49, 0xba, 0x00,
+				},
+			},
+		},
+	},
+	{
+		"octet aligned",
+		false,
+		true,
+		[][]byte{testFrameNB1, testFrameNB2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0xf0, 0xbc, 0x14, 0x01, 0x08, 0x0f, 0x16, 0x1d,
+					0x24, 0x2b, 0x32, 0x39, 0x40, 0x47, 0x4e, 0x55,
+					0x5c, 0x63, 0x6a, 0x71, 0x78, 0x7f, 0x86, 0x8d,
+					0x94, 0x9b, 0xa2, 0xa9, 0xb0, 0xb7, 0xbe, 0xc5,
+					0xcc, 0xd0, 0x40, 0x47, 0x4e, 0x55, 0x5c, 0x63,
+					0x6a, 0x71, 0x78, 0x7f, 0x86, 0x8d, 0x94, 0x9b,
+					0xa0,
+				},
+			},
+		},
+	},
+	{
+		"wideband",
+		true,
+		false,
+		[][]byte{testFrameWB},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0xf4, 0x40, 0xc2, 0x84, 0x46, 0x07, 0xc9, 0x8b,
+					0x4d, 0x0e, 0xd0, 0x92, 0x54, 0x15, 0xd7, 0x99,
+					0x5b, 0x1c, 0xde, 0xa0, 0x62, 0x23, 0xe5, 0xa7,
+					0x69, 0x2a, 0xec, 0xae, 0x70, 0x31, 0xf3, 0xb5,
+					0x77, 0x38, 0xfa, 0xbc, 0x7e, 0x3f, 0xc1, 0x83,
+					0x45, 0x06, 0xc8, 0x8a, 0x4c, 0x0d, 0xcf, 0x91,
+					0x53, 0x14, 0xd6, 0x98, 0x5a, 0x1b, 0xdd, 0x9f,
+					0x61, 0x22, 0xe4, 0xa6, 0x68,
+				},
+			},
+		},
+	},
+	{
+		"no data",
+		false,
+		false,
+		[][]byte{{0x7c}, {0x7c}},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0xff, 0xdf},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				Wideband:              ca.wideband,
+				OctetAlign:            ca.octetAlign,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeSplit(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           96,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	frames := make([][]byte, 60)
+	for i := range frames {
+		frames[i] = testFrameNB1
+	}
+
+	pkts, err := e.Encode(frames)
+	require.NoError(t, err)
+	require.Len(t, pkts, 2)
+	require.Len(t, pkts[0].Payload, 1438)
+	require.Equal(t, uint32(0), pkts[0].Timestamp)
+	require.Len(t, pkts[1].Payload, 438)
+	require.Equal(t, uint32(7360), pkts[1].Timestamp)
+	require.Equal(t, uint16(17646), pkts[1].SequenceNumber)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType:  96,
+		ChannelCount: 2,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{testFrameNB1})
+	require.EqualError(t, err, "invalid frame count")
+
+	_, err = e.Encode([][]byte{testFrameNB1, testFrameNB1[:10]})
+	require.EqualError(t, err, "invalid frame size")
+
+	_, err = e.Encode([][]byte{testFrameNB1, {0x4c}})
+	require.EqualError(t, err, "invalid frame type (9)")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
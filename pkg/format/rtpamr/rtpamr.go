@@ -0,0 +1,49 @@
+// Package rtpamr contains a RTP/AMR decoder and encoder.
+package rtpamr
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// size in bits of the speech data of AMR frames, indexed by frame type.
+// -1 means that the frame type is reserved.
+var narrowbandFrameBits = [16]int{95, 103, 118, 134, 148, 159, 204, 244, 39, -1, -1, -1, -1, -1, -1, 0}
+
+// size in bits of the speech data of AMR-WB frames, indexed by frame type.
+// -1 means that the frame type is reserved.
+var widebandFrameBits = [16]int{132, 177, 253, 285, 317, 365, 397, 461, 477, 40, -1, -1, -1, -1, 0, 0}
+
+func frameBits(wideband bool, frameType uint8) (int, error) {
+	var n int
+	if wideband {
+		n = widebandFrameBits[frameType]
+	} else {
+		n = narrowbandFrameBits[frameType]
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("invalid frame type (%d)", frameType)
+	}
+
+	return n, nil
+}
+
+func samplesPerFrame(wideband bool) uint32 {
+	// each frame lasts 20ms
+	if wideband {
+		return 320
+	}
+	return 160
+}
+
+// copyBits copies n bits from src, starting at position srcPos, into dst,
+// starting at position dstPos.
+func copyBits(dst []byte, dstPos *int, src []byte, srcPos *int, n int) {
+	for n > 0 {
+		le := min(n, 8)
+		bits.WriteBitsUnsafe(dst, dstPos, bits.ReadBitsUnsafe(src, srcPos, le), le)
+		n -= le
+	}
+}
@@ -0,0 +1,205 @@
+package rtpeac3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+// testSyncFrame returns a 48khz, 6-block syncframe.
+func testSyncFrame(size int, dependent bool, fill byte) []byte {
+	frmsiz := size/2 - 1
+
+	buf := bytes.Repeat([]byte{fill}, size)
+	buf[0] = 0x0b
+	buf[1] = 0x77
+	buf[2] = byte(frmsiz >> 8)
+	if dependent {
+		buf[2] |= 1 << 6
+	}
+	buf[3] = byte(frmsiz)
+	buf[4] = 0x34
+	buf[5] = 0x80
+
+	return buf
+}
+
+var (
+	testIndependent1 = testSyncFrame(64, false, 0x01)
+	testDependent    = testSyncFrame(32, true, 0x02)
+	testIndependent2 = testSyncFrame(64, false, 0x03)
+	testBig1         = testSyncFrame(1000, false, 0x04)
+	testBig2         = testSyncFrame(1000, false, 0x05)
+	testHuge         = testSyncFrame(3000, false, 0x06)
+)
+
+var cases = []struct {
+	name   string
+	frames [][]byte
+	pkts   []*rtp.Packet
+}{
+	{
+		"aggregated",
+		[][]byte{testIndependent1, testDependent, testIndependent2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x03},
+					testIndependent1,
+					testDependent,
+					testIndependent2,
+				),
+			},
+		},
+	},
+	{
+		"multiple packets",
+		[][]byte{testBig1, testBig2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x01},
+					testBig1,
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      1536,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x01},
+					testBig2,
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{testHuge},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x02, 0x03},
+					testHuge[:1456],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x03, 0x03},
+					testHuge[1456:2912],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x03, 0x03},
+					testHuge[2912:],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeInvalidSyncFrame(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{testIndependent1[:60]})
+	require.EqualError(t, err, "invalid syncframe size: 60, expected 64")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
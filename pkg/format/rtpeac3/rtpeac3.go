@@ -0,0 +1,62 @@
+// Package rtpeac3 contains a RTP/E-AC-3 decoder and encoder.
+package rtpeac3
+
+import (
+	"fmt"
+)
+
+// samples contained in an audio block.
+const samplesPerBlock = 256
+
+// syncFrame contains informations about an E-AC-3 syncframe.
+// Specification: ETSI TS 102 366, Annex E.
+type syncFrame struct {
+	// size of the syncframe, in bytes.
+	size int
+
+	// whether the syncframe belongs to a dependent substream.
+	dependent bool
+
+	// samples contained in the syncframe.
+	sampleCount int
+}
+
+func (s *syncFrame) unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if buf[0] != 0x0B || buf[1] != 0x77 {
+		return fmt.Errorf("invalid sync word")
+	}
+
+	bsid := buf[5] >> 3
+	if bsid <= 10 || bsid > 16 {
+		return fmt.Errorf("invalid bsid: %d", bsid)
+	}
+
+	strmtyp := buf[2] >> 6
+	switch strmtyp {
+	case 0, 2:
+		s.dependent = false
+
+	case 1:
+		s.dependent = true
+
+	default:
+		return fmt.Errorf("invalid strmtyp: %d", strmtyp)
+	}
+
+	frmsiz := int(buf[2]&0x07)<<8 | int(buf[3])
+	s.size = (frmsiz + 1) * 2
+
+	fscod := buf[4] >> 6
+	if fscod == 3 {
+		s.sampleCount = 6 * samplesPerBlock
+	} else {
+		numblkscod := (buf[4] >> 4) & 0x03
+		s.sampleCount = [...]int{1, 2, 3, 6}[numblkscod] * samplesPerBlock
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package rtpg726
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/G726 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Decoder struct {
+	// bit rate, in kbit/s (16, 24, 32 or 40).
+	BitRate int
+
+	// whether codewords are packed in big-endian order (AAL2).
+	BigEndian bool
+
+	bits int
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	var err error
+	d.bits, err = bitsPerCodeword(d.BitRate)
+	return err
+}
+
+// Decode decodes codewords from a RTP packet.
+// Each codeword is returned in a dedicated byte,
+// therefore the timestamp of the N-th codeword is the packet timestamp plus N.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	if ((len(pkt.Payload) * 8) % d.bits) != 0 {
+		return nil, fmt.Errorf("received payload of wrong size")
+	}
+
+	return unpack(pkt.Payload, d.bits, d.BigEndian), nil
+}
@@ -0,0 +1,139 @@
+package rtpg726
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/G726 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// bit rate, in kbit/s (16, 24, 32 or 40).
+	BitRate int
+
+	// whether codewords are packed in big-endian order (AAL2).
+	BigEndian bool
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	bits           int
+	maxCodewords   int
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	bits, err := bitsPerCodeword(e.BitRate)
+	if err != nil {
+		return err
+	}
+
+	if e.SSRC == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	e.bits = bits
+
+	// groups of 8 codewords always fill an integer number of octets.
+	e.maxCodewords = (e.PayloadMaxSize / e.bits) * 8
+
+	return nil
+}
+
+func (e *Encoder) packetCount(clen int) int {
+	n := (clen / e.maxCodewords)
+	if (clen % e.maxCodewords) != 0 {
+		n++
+	}
+	return n
+}
+
+// Encode encodes codewords into RTP packets.
+// Each codeword must be stored in a dedicated byte.
+func (e *Encoder) Encode(codewords []byte) ([]*rtp.Packet, error) {
+	clen := len(codewords)
+	if ((clen * e.bits) % 8) != 0 {
+		return nil, fmt.Errorf("invalid codeword count")
+	}
+
+	for _, cw := range codewords {
+		if (cw >> e.bits) != 0 {
+			return nil, fmt.Errorf("invalid codeword")
+		}
+	}
+
+	packetCount := e.packetCount(clen)
+	ret := make([]*rtp.Packet, packetCount)
+	pos := 0
+	count := e.maxCodewords
+	timestamp := uint32(0)
+
+	for i := range ret {
+		if count > len(codewords[pos:]) {
+			count = len(codewords[pos:])
+		}
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      timestamp,
+				SSRC:           *e.SSRC,
+				Marker:         false,
+			},
+			Payload: pack(codewords[pos:pos+count], e.bits, e.BigEndian),
+		}
+
+		e.sequenceNumber++
+		pos += count
+		timestamp += uint32(count)
+	}
+
+	return ret, nil
+}
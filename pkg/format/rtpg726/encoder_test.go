@@ -0,0 +1,180 @@
+package rtpg726
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var cases = []struct {
+	name      string
+	bitRate   int
+	bigEndian bool
+	codewords []byte
+	pkts      []*rtp.Packet
+}{
+	{
+		"32 little endian",
+		32,
+		false,
+		[]byte{0x01, 0x02, 0x03, 0x04},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x21, 0x43},
+			},
+		},
+	},
+	{
+		"32 big endian",
+		32,
+		true,
+		[]byte{0x01, 0x02, 0x03, 0x04},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x12, 0x34},
+			},
+		},
+	},
+	{
+		"24 little endian",
+		24,
+		false,
+		[]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x00},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0xd1, 0x58, 0x1f},
+			},
+		},
+	},
+	{
+		"24 big endian",
+		24,
+		true,
+		[]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x00},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x29, 0xcb, 0xb8},
+			},
+		},
+	},
+	{
+		"splitted",
+		32,
+		false,
+		bytes.Repeat([]byte{0x05}, 3000),
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: bytes.Repeat([]byte{0x55}, 1460),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2920,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: bytes.Repeat([]byte{0x55}, 40),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				BitRate:               ca.bitRate,
+				BigEndian:             ca.bigEndian,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.codewords)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		BitRate:     24,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([]byte{0x01, 0x02})
+	require.EqualError(t, err, "invalid codeword count")
+
+	_, err = e.Encode([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08})
+	require.EqualError(t, err, "invalid codeword")
+
+	e = &Encoder{
+		PayloadType: 96,
+		BitRate:     48,
+	}
+	err = e.Init()
+	require.EqualError(t, err, "unsupported bit rate: 48")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		BitRate:     32,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
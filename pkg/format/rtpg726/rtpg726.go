@@ -0,0 +1,85 @@
+// Package rtpg726 contains a RTP/G726 decoder and encoder.
+package rtpg726
+
+import (
+	"fmt"
+)
+
+func bitsPerCodeword(bitRate int) (int, error) {
+	switch bitRate {
+	case 16, 24, 32, 40:
+		return bitRate / 8, nil
+
+	default:
+		return 0, fmt.Errorf("unsupported bit rate: %d", bitRate)
+	}
+}
+
+// pack packs codewords into a byte slice.
+// In little-endian packing (RFC3551), the first codeword is placed
+// into the least significant bits of the first octet.
+// In big-endian packing (ITU-T I.366.2, AAL2), the first codeword is placed
+// into the most significant bits of the first octet.
+func pack(codewords []byte, bits int, bigEndian bool) []byte {
+	buf := make([]byte, len(codewords)*bits/8)
+	acc := uint32(0)
+	accBits := 0
+	pos := 0
+
+	for _, cw := range codewords {
+		if bigEndian {
+			acc = acc<<bits | uint32(cw)
+			accBits += bits
+
+			for accBits >= 8 {
+				accBits -= 8
+				buf[pos] = byte(acc >> accBits)
+				pos++
+			}
+		} else {
+			acc |= uint32(cw) << accBits
+			accBits += bits
+
+			for accBits >= 8 {
+				buf[pos] = byte(acc)
+				pos++
+				acc >>= 8
+				accBits -= 8
+			}
+		}
+	}
+
+	return buf
+}
+
+// unpack unpacks codewords from a byte slice.
+func unpack(buf []byte, bits int, bigEndian bool) []byte {
+	codewords := make([]byte, len(buf)*8/bits)
+	mask := uint32(1)<<bits - 1
+	acc := uint32(0)
+	accBits := 0
+	pos := 0
+
+	for i := range codewords {
+		for accBits < bits {
+			if bigEndian {
+				acc = acc<<8 | uint32(buf[pos])
+			} else {
+				acc |= uint32(buf[pos]) << accBits
+			}
+			pos++
+			accBits += 8
+		}
+
+		if bigEndian {
+			accBits -= bits
+			codewords[i] = byte((acc >> accBits) & mask)
+		} else {
+			codewords[i] = byte(acc & mask)
+			acc >>= bits
+			accBits -= bits
+		}
+	}
+
+	return codewords
+}
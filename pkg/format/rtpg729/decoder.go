@@ -0,0 +1,46 @@
+package rtpg729
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/G729 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551#section-4.5.6
+type Decoder struct{}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes frames from a RTP packet.
+// Speech frames are 10 bytes long. A packet can end with a 2-byte long
+// Annex B silence insertion descriptor (SID) frame, that is returned as the last frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	payload := pkt.Payload
+
+	n := len(payload) / frameSize
+	rem := len(payload) % frameSize
+
+	if rem != 0 && rem != sidFrameSize {
+		return nil, fmt.Errorf("invalid payload size: %d", len(payload))
+	}
+
+	if rem == 0 && n == 0 {
+		return nil, fmt.Errorf("payload is empty")
+	}
+
+	frames := make([][]byte, 0, n+1)
+
+	for i := range n {
+		frames = append(frames, payload[i*frameSize:(i+1)*frameSize])
+	}
+
+	if rem == sidFrameSize {
+		frames = append(frames, payload[n*frameSize:])
+	}
+
+	return frames, nil
+}
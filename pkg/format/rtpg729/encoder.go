@@ -0,0 +1,119 @@
+package rtpg729
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/G729 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551#section-4.5.6
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes frames into a RTP packet.
+// A silence insertion descriptor (SID) frame can only be the last frame.
+func (e *Encoder) Encode(frames [][]byte) (*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	size := 0
+
+	for i, frame := range frames {
+		switch {
+		case len(frame) == frameSize:
+
+		case len(frame) == sidFrameSize:
+			if i != (len(frames) - 1) {
+				return nil, fmt.Errorf("SID frame must be the last frame")
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid frame size: %d", len(frame))
+		}
+
+		size += len(frame)
+	}
+
+	if size > e.PayloadMaxSize {
+		return nil, fmt.Errorf("frames are too big")
+	}
+
+	payload := make([]byte, size)
+	n := 0
+	for _, frame := range frames {
+		n += copy(payload[n:], frame)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt, nil
+}
@@ -0,0 +1,10 @@
+// Package rtpg729 contains a RTP/G729 decoder and encoder.
+package rtpg729
+
+const (
+	// size of a 10ms speech frame.
+	frameSize = 10
+
+	// size of a Annex B silence insertion descriptor (SID) frame.
+	sidFrameSize = 2
+)
@@ -0,0 +1,39 @@
+package rtpgsm
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/GSM decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551#section-4.5.8
+type Decoder struct{}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes frames from a RTP packet.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	payload := pkt.Payload
+
+	if len(payload) == 0 || (len(payload)%frameSize) != 0 {
+		return nil, fmt.Errorf("invalid payload size: %d", len(payload))
+	}
+
+	n := len(payload) / frameSize
+	frames := make([][]byte, n)
+
+	for i := range n {
+		frames[i] = payload[i*frameSize : (i+1)*frameSize]
+
+		err := checkFrame(frames[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return frames, nil
+}
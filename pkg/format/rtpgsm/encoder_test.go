@@ -0,0 +1,103 @@
+package rtpgsm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func testFrame(b byte) []byte {
+	frame := bytes.Repeat([]byte{b}, frameSize)
+	frame[0] = 0xd0 | (b & 0x0f)
+	return frame
+}
+
+var cases = []struct {
+	name   string
+	frames [][]byte
+	pkt    *rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{testFrame(0x01)},
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    3,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: testFrame(0x01),
+		},
+	},
+	{
+		"multiple",
+		[][]byte{testFrame(0x01), testFrame(0x02)},
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    3,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: append(testFrame(0x01), testFrame(0x02)...),
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           3,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkt, err := e.Encode(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkt, pkt)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 3,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{{0xd0, 0x01}})
+	require.EqualError(t, err, "invalid frame size: 2")
+
+	frame := testFrame(0x01)
+	frame[0] = 0x01
+
+	_, err = e.Encode([][]byte{frame})
+	require.EqualError(t, err, "invalid frame signature: 0")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 3,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,26 @@
+// Package rtpgsm contains a RTP/GSM decoder and encoder.
+package rtpgsm
+
+import (
+	"fmt"
+)
+
+const (
+	// size of a 20ms frame.
+	frameSize = 33
+
+	// every frame starts with this 4-bit signature.
+	frameSignature = 0x0D
+)
+
+func checkFrame(frame []byte) error {
+	if len(frame) != frameSize {
+		return fmt.Errorf("invalid frame size: %d", len(frame))
+	}
+
+	if (frame[0] >> 4) != frameSignature {
+		return fmt.Errorf("invalid frame signature: %d", frame[0]>>4)
+	}
+
+	return nil
+}
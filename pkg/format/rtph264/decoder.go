@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/pion/rtp"
 
@@ -46,12 +47,45 @@ func auSize(au [][]byte) int {
 	return s
 }
 
+func isVCL(nalu []byte) bool {
+	typ := h264.NALUType(nalu[0] & 0x1F)
+	return typ >= h264.NALUTypeNonIDR && typ <= h264.NALUTypeIDR
+}
+
+// AccessUnit is an access unit decoded in interleaved mode.
+type AccessUnit struct {
+	// RTP timestamp of the access unit.
+	Timestamp uint32
+
+	// NALUs of the access unit, in decoding order.
+	NALUs [][]byte
+}
+
+// NALU received in interleaved mode, with its decoding order number.
+type interleavedNALU struct {
+	don  int64
+	ts   uint32
+	nalu []byte
+}
+
 // Decoder is a RTP/H264 decoder.
 // Specification: https://datatracker.ietf.org/doc/html/rfc6184
 type Decoder struct {
 	// indicates the packetization mode.
 	PacketizationMode int
 
+	// maximum number of VCL NALUs that precede any VCL NALU
+	// in transmission order and follow it in decoding order
+	// (sprop-interleaving-depth).
+	// It is used in interleaved mode (PacketizationMode = 2) only.
+	InterleavingDepth int
+
+	// maximum size, in bytes, of the de-interleaving buffer
+	// (sprop-deint-buf-req).
+	// It is used in interleaved mode (PacketizationMode = 2) only.
+	// When zero, the buffer is limited by the NALU count only.
+	DeintBufReq int
+
 	firstPacketReceived bool
 	fragments           [][]byte
 	fragmentsSize       int
@@ -63,12 +97,32 @@ type Decoder struct {
 	frameBufferLen       int
 	frameBufferSize      int
 	frameBufferTimestamp uint32
+
+	// for DecodeInterleaved()
+	fragmentsDON       uint16
+	fragmentsTimestamp uint32
+	donReceived        bool
+	lastDON            uint16
+	lastExtDON         int64
+	donOutput          bool
+	lastOutputDON      int64
+	deintBuffer        []interleavedNALU
+	deintBufferVCL     int
+	deintBufferSize    int
+	outputAU           *AccessUnit
+	outputAUSize       int
 }
 
 // Init initializes the decoder.
 func (d *Decoder) Init() error {
-	if d.PacketizationMode >= 2 {
-		return fmt.Errorf("PacketizationMode >= 2 is not supported")
+	if d.PacketizationMode > 2 {
+		return fmt.Errorf("PacketizationMode > 2 is not supported")
+	}
+	if d.InterleavingDepth < 0 {
+		return fmt.Errorf("invalid InterleavingDepth")
+	}
+	if d.DeintBufReq < 0 {
+		return fmt.Errorf("invalid DeintBufReq")
 	}
 	return nil
 }
@@ -216,7 +270,12 @@ func (d *Decoder) decodeNALUs(pkt *rtp.Packet) ([][]byte, error) {
 }
 
 // Decode decodes an access unit from a RTP packet.
+// In interleaved mode (PacketizationMode = 2), DecodeInterleaved must be used instead.
 func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	if d.PacketizationMode == 2 {
+		return nil, fmt.Errorf("interleaved mode requires DecodeInterleaved()")
+	}
+
 	nalus, err := d.decodeNALUs(pkt)
 	if err != nil {
 		return nil, err
@@ -304,3 +363,311 @@ func (d *Decoder) removeAnnexB(nalus [][]byte) ([][]byte, error) {
 
 	return nalus, nil
 }
+
+func (d *Decoder) decodeInterleavedNALUs(pkt *rtp.Packet) ([]interleavedNALU, error) {
+	if len(pkt.Payload) < 1 {
+		d.resetFragments()
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	typ := h264.NALUType(pkt.Payload[0] & 0x1F)
+	var nalus []interleavedNALU
+
+	switch typ {
+	case h264.NALUTypeFUB:
+		if len(pkt.Payload) < 4 {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid FU-B packet (invalid size)")
+		}
+
+		start := pkt.Payload[1] >> 7
+		end := (pkt.Payload[1] >> 6) & 0x01
+
+		// FU-B is used for the first fragment only,
+		// following fragments are sent with FU-A.
+		if start != 1 || end != 0 {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid FU-B packet (must be a starting, non-ending fragment)")
+		}
+
+		d.resetFragments()
+
+		nri := (pkt.Payload[0] >> 5) & 0x03
+		typ := pkt.Payload[1] & 0x1F
+		d.fragmentsSize = 1 + len(pkt.Payload[4:])
+		d.fragments = append(d.fragments, []byte{(nri << 5) | typ}, pkt.Payload[4:])
+		d.fragmentsDON = uint16(pkt.Payload[2])<<8 | uint16(pkt.Payload[3])
+		d.fragmentsTimestamp = pkt.Timestamp
+		d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+		d.firstPacketReceived = true
+
+		return nil, ErrMorePacketsNeeded
+
+	case h264.NALUTypeFUA:
+		if len(pkt.Payload) < 2 {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid FU-A packet (invalid size)")
+		}
+
+		start := pkt.Payload[1] >> 7
+		end := (pkt.Payload[1] >> 6) & 0x01
+
+		if start == 1 {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid FU-A packet (starting fragments must be sent with FU-B in interleaved mode)")
+		}
+
+		if d.fragmentsSize == 0 {
+			if !d.firstPacketReceived {
+				return nil, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, fmt.Errorf("invalid FU-A packet (non-starting)")
+		}
+
+		if pkt.SequenceNumber != d.fragmentNextSeqNum {
+			d.resetFragments()
+			return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+		}
+
+		d.fragmentsSize += len(pkt.Payload[2:])
+
+		if d.fragmentsSize > h264.MaxAccessUnitSize {
+			errSize := d.fragmentsSize
+			d.resetFragments()
+			return nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d",
+				errSize, h264.MaxAccessUnitSize)
+		}
+
+		d.fragments = append(d.fragments, pkt.Payload[2:])
+		d.fragmentNextSeqNum++
+
+		if end != 1 {
+			return nil, ErrMorePacketsNeeded
+		}
+
+		nalus = []interleavedNALU{{
+			don:  int64(d.fragmentsDON),
+			ts:   d.fragmentsTimestamp,
+			nalu: joinFragments(d.fragments, d.fragmentsSize),
+		}}
+		d.resetFragments()
+
+	case h264.NALUTypeSTAPB:
+		d.resetFragments()
+
+		if len(pkt.Payload) < 3 {
+			return nil, fmt.Errorf("invalid STAP-B packet (invalid size)")
+		}
+
+		don := uint16(pkt.Payload[1])<<8 | uint16(pkt.Payload[2])
+		payload := pkt.Payload[3:]
+
+		for {
+			if len(payload) < 2 {
+				return nil, fmt.Errorf("invalid STAP-B packet (invalid size)")
+			}
+
+			size := uint16(payload[0])<<8 | uint16(payload[1])
+			payload = payload[2:]
+
+			if size == 0 || int(size) > len(payload) {
+				return nil, fmt.Errorf("invalid STAP-B packet (invalid size)")
+			}
+
+			// NALUs of a STAP-B have consecutive DONs
+			nalus = append(nalus, interleavedNALU{
+				don:  int64(don),
+				ts:   pkt.Timestamp,
+				nalu: payload[:size],
+			})
+			payload = payload[size:]
+			don++
+
+			if len(payload) == 0 {
+				break
+			}
+		}
+
+		d.firstPacketReceived = true
+
+	case h264.NALUTypeMTAP16, h264.NALUTypeMTAP24:
+		d.resetFragments()
+
+		if len(pkt.Payload) < 3 {
+			return nil, fmt.Errorf("invalid MTAP packet (invalid size)")
+		}
+
+		tsOffsetLen := 2
+		if typ == h264.NALUTypeMTAP24 {
+			tsOffsetLen = 3
+		}
+
+		donb := uint16(pkt.Payload[1])<<8 | uint16(pkt.Payload[2])
+		payload := pkt.Payload[3:]
+
+		for {
+			if len(payload) < (3 + tsOffsetLen) {
+				return nil, fmt.Errorf("invalid MTAP packet (invalid size)")
+			}
+
+			size := uint16(payload[0])<<8 | uint16(payload[1])
+			dond := payload[2]
+
+			var tsOffset uint32
+			for i := 0; i < tsOffsetLen; i++ {
+				tsOffset = tsOffset<<8 | uint32(payload[3+i])
+			}
+
+			payload = payload[3+tsOffsetLen:]
+
+			if size == 0 || int(size) > len(payload) {
+				return nil, fmt.Errorf("invalid MTAP packet (invalid size)")
+			}
+
+			nalus = append(nalus, interleavedNALU{
+				don:  int64(donb + uint16(dond)),
+				ts:   pkt.Timestamp + tsOffset,
+				nalu: payload[:size],
+			})
+			payload = payload[size:]
+
+			if len(payload) == 0 {
+				break
+			}
+		}
+
+		d.firstPacketReceived = true
+
+	default:
+		d.resetFragments()
+		d.firstPacketReceived = true
+		return nil, fmt.Errorf("packet type not supported in interleaved mode (%v)", typ)
+	}
+
+	return nalus, nil
+}
+
+// extendDON converts a 16-bit DON into a DON that doesn't wrap around.
+func (d *Decoder) extendDON(don uint16) int64 {
+	if !d.donReceived {
+		d.donReceived = true
+		d.lastExtDON = int64(don)
+	} else {
+		d.lastExtDON += int64(int16(don - d.lastDON))
+	}
+
+	d.lastDON = don
+	return d.lastExtDON
+}
+
+// DecodeInterleaved decodes access units from a RTP packet in interleaved mode
+// (PacketizationMode = 2).
+// NALUs are reordered by their decoding order number (DON) and grouped into
+// access units by their timestamp. Since NALUs of different access units are
+// interleaved, a packet can complete zero or more access units.
+func (d *Decoder) DecodeInterleaved(pkt *rtp.Packet) ([]*AccessUnit, error) {
+	if d.PacketizationMode != 2 {
+		return nil, fmt.Errorf("DecodeInterleaved() can be used in interleaved mode only")
+	}
+
+	nalus, err := d.decodeInterleavedNALUs(pkt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nalu := range nalus {
+		nalu.don = d.extendDON(uint16(nalu.don))
+
+		// discard NALUs that should have been decoded before the ones already outputted
+		if d.donOutput && nalu.don <= d.lastOutputDON {
+			continue
+		}
+
+		if len(d.deintBuffer) >= (d.InterleavingDepth+1)*h264.MaxNALUsPerAccessUnit {
+			errCount := len(d.deintBuffer) + 1
+			d.resetDeintBuffer()
+			return nil, fmt.Errorf("de-interleaving buffer NALU count (%d) exceeds maximum allowed (%d)",
+				errCount, (d.InterleavingDepth+1)*h264.MaxNALUsPerAccessUnit)
+		}
+
+		if d.DeintBufReq != 0 && (d.deintBufferSize+len(nalu.nalu)) > d.DeintBufReq {
+			errSize := d.deintBufferSize + len(nalu.nalu)
+			d.resetDeintBuffer()
+			return nil, fmt.Errorf("de-interleaving buffer size (%d) exceeds maximum allowed (%d)",
+				errSize, d.DeintBufReq)
+		}
+
+		i := sort.Search(len(d.deintBuffer), func(i int) bool {
+			return d.deintBuffer[i].don > nalu.don
+		})
+		d.deintBuffer = append(d.deintBuffer, interleavedNALU{})
+		copy(d.deintBuffer[i+1:], d.deintBuffer[i:])
+		d.deintBuffer[i] = nalu
+
+		d.deintBufferSize += len(nalu.nalu)
+
+		if isVCL(nalu.nalu) {
+			d.deintBufferVCL++
+		}
+	}
+
+	var ret []*AccessUnit
+
+	// a NALU can be outputted when the number of VCL NALUs
+	// that may precede it in decoding order is greater than the interleaving depth.
+	for d.deintBufferVCL > d.InterleavingDepth {
+		nalu := d.deintBuffer[0]
+		d.deintBuffer = d.deintBuffer[1:]
+		d.deintBufferSize -= len(nalu.nalu)
+
+		if isVCL(nalu.nalu) {
+			d.deintBufferVCL--
+		}
+
+		d.donOutput = true
+		d.lastOutputDON = nalu.don
+
+		// an access unit is complete when a NALU with a different timestamp is found
+		if d.outputAU != nil && nalu.ts != d.outputAU.Timestamp {
+			ret = append(ret, d.outputAU)
+			d.outputAU = nil
+			d.outputAUSize = 0
+		}
+
+		if d.outputAU == nil {
+			d.outputAU = &AccessUnit{Timestamp: nalu.ts}
+		}
+
+		if (len(d.outputAU.NALUs) + 1) > h264.MaxNALUsPerAccessUnit {
+			errCount := len(d.outputAU.NALUs) + 1
+			d.outputAU = nil
+			d.outputAUSize = 0
+			return nil, fmt.Errorf("NALU count (%d) exceeds maximum allowed (%d)",
+				errCount, h264.MaxNALUsPerAccessUnit)
+		}
+
+		if (d.outputAUSize + len(nalu.nalu)) > h264.MaxAccessUnitSize {
+			errSize := d.outputAUSize + len(nalu.nalu)
+			d.outputAU = nil
+			d.outputAUSize = 0
+			return nil, fmt.Errorf("access unit size (%d) is too big, maximum is %d",
+				errSize, h264.MaxAccessUnitSize)
+		}
+
+		d.outputAU.NALUs = append(d.outputAU.NALUs, nalu.nalu)
+		d.outputAUSize += len(nalu.nalu)
+	}
+
+	if ret == nil {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	return ret, nil
+}
+
+func (d *Decoder) resetDeintBuffer() {
+	d.deintBuffer = nil
+	d.deintBufferVCL = 0
+	d.deintBufferSize = 0
+}
@@ -359,6 +359,169 @@ func TestDecodeErrorMissingPacket(t *testing.T) {
 	require.EqualError(t, err, "discarding frame since a RTP packet is missing")
 }
 
+func TestDecodeInterleaved(t *testing.T) {
+	for _, ca := range casesInterleaved {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				PacketizationMode: 2,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			for _, pkt := range ca.pkts {
+				pkt = pkt.Clone()
+				pkt.Timestamp = 2289527317
+
+				_, err = d.DecodeInterleaved(pkt)
+				require.Equal(t, ErrMorePacketsNeeded, err)
+			}
+
+			// access units are complete when the next one starts
+			aus, err := d.DecodeInterleaved(&rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17700,
+					Timestamp:      2289530317,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x19, 0x00, 0x10, 0x00, 0x02, 0x41, 0x00},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []*AccessUnit{{
+				Timestamp: 2289527317,
+				NALUs:     ca.nalus,
+			}}, aus)
+		})
+	}
+}
+
+func TestDecodeInterleavedReorder(t *testing.T) {
+	d := &Decoder{
+		PacketizationMode: 2,
+		InterleavingDepth: 1,
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	// STAP-B with the second access unit
+	_, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			Timestamp:      4000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x19, 0x00, 0x01, 0x00, 0x02, 0x41, 0x02},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	// MTAP16 with the first and third access units
+	aus, err := d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17646,
+			Timestamp:      1000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{
+			0x1a, 0x00, 0x00,
+			0x00, 0x02, 0x00, 0x00, 0x00, 0x65, 0x01,
+			0x00, 0x02, 0x02, 0x17, 0x70, 0x41, 0x03,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*AccessUnit{{
+		Timestamp: 1000,
+		NALUs:     [][]byte{{0x65, 0x01}},
+	}}, aus)
+
+	// STAP-B with the fourth access unit
+	aus, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17647,
+			Timestamp:      10000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x19, 0x00, 0x03, 0x00, 0x02, 0x41, 0x04},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*AccessUnit{{
+		Timestamp: 4000,
+		NALUs:     [][]byte{{0x41, 0x02}},
+	}}, aus)
+}
+
+func TestDecodeInterleavedErrorDeintBufReq(t *testing.T) {
+	d := &Decoder{
+		PacketizationMode: 2,
+		InterleavingDepth: 1,
+		DeintBufReq:       3,
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			Timestamp:      4000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x19, 0x00, 0x01, 0x00, 0x02, 0x41, 0x02},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17646,
+			Timestamp:      1000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x19, 0x00, 0x00, 0x00, 0x02, 0x65, 0x01},
+	})
+	require.EqualError(t, err, "de-interleaving buffer size (4) exceeds maximum allowed (3)")
+}
+
+func TestDecodeInterleavedErrorFUAStart(t *testing.T) {
+	d := &Decoder{
+		PacketizationMode: 2,
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x7c, 0x85, 0x01, 0x02},
+	})
+	require.EqualError(t, err, "invalid FU-A packet "+
+		"(starting fragments must be sent with FU-B in interleaved mode)")
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 17646,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x19, 0x00, 0x00, 0x00, 0x02, 0x41, 0x00},
+	})
+	require.EqualError(t, err, "interleaved mode requires DecodeInterleaved()")
+}
+
 func FuzzDecoder(f *testing.F) {
 	f.Fuzz(func(t *testing.T, a []byte, am bool, b []byte, bm bool) {
 		d := &Decoder{}
@@ -396,3 +559,42 @@ func FuzzDecoder(f *testing.F) {
 		}
 	})
 }
+
+func FuzzDecoderInterleaved(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte, c []byte) {
+		d := &Decoder{
+			PacketizationMode: 2,
+			InterleavingDepth: 1,
+		}
+		err := d.Init()
+		require.NoError(t, err)
+
+		for i, payload := range [][]byte{a, b, c} {
+			aus, err := d.DecodeInterleaved(&rtp.Packet{
+				Header: rtp.Header{
+					SequenceNumber: 17645 + uint16(i),
+					Timestamp:      uint32(i) * 3000,
+				},
+				Payload: payload,
+			})
+
+			if err == nil {
+				if len(aus) == 0 {
+					t.Errorf("should not happen")
+				}
+
+				for _, au := range aus {
+					if len(au.NALUs) == 0 {
+						t.Errorf("should not happen")
+					}
+
+					for _, nalu := range au.NALUs {
+						if len(nalu) == 0 {
+							t.Errorf("should not happen")
+						}
+					}
+				}
+			}
+		}
+	})
+}
@@ -23,9 +23,13 @@ func randUint32() (uint32, error) {
 	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
 }
 
-func lenAggregated(nalus [][]byte, addNALU []byte) int {
+func lenAggregated(nalus [][]byte, addNALU []byte, interleaved bool) int {
 	n := 1 // header
 
+	if interleaved {
+		n += 2 // DON
+	}
+
 	for _, nalu := range nalus {
 		n += 2         // size
 		n += len(nalu) // nalu
@@ -65,15 +69,21 @@ type Encoder struct {
 	// It defaults to 1460.
 	PayloadMaxSize int
 
+	// packetization mode.
+	// When it is 2 (interleaved mode), NALUs are sent inside STAP-B and FU-B packets
+	// with consecutive decoding order numbers. NALUs are not reordered,
+	// therefore the resulting stream has an interleaving depth of zero
+	// and is compatible with any sprop-interleaving-depth.
 	PacketizationMode int
 
 	sequenceNumber uint16
+	don            uint16
 }
 
 // Init initializes the encoder.
 func (e *Encoder) Init() error {
-	if e.PacketizationMode >= 2 {
-		return fmt.Errorf("PacketizationMode >= 2 is not supported")
+	if e.PacketizationMode > 2 {
+		return fmt.Errorf("PacketizationMode > 2 is not supported")
 	}
 
 	if e.SSRC == nil {
@@ -106,7 +116,7 @@ func (e *Encoder) Encode(au [][]byte) ([]*rtp.Packet, error) {
 
 	// split NALUs into batches
 	for _, nalu := range au {
-		if lenAggregated(batch, nalu) <= e.PayloadMaxSize {
+		if lenAggregated(batch, nalu, e.PacketizationMode == 2) <= e.PayloadMaxSize {
 			// add to existing batch
 			batch = append(batch, nalu)
 		} else {
@@ -136,6 +146,15 @@ func (e *Encoder) Encode(au [][]byte) ([]*rtp.Packet, error) {
 }
 
 func (e *Encoder) writeBatch(nalus [][]byte, marker bool) ([]*rtp.Packet, error) {
+	if e.PacketizationMode == 2 {
+		// single NALUs are not allowed in interleaved mode, use STAP-B instead
+		if lenAggregated(nalus, nil, true) <= e.PayloadMaxSize {
+			return e.writeAggregated(nalus, marker)
+		}
+
+		return e.writeFragmentedInterleaved(nalus[0], marker)
+	}
+
 	if len(nalus) == 1 {
 		// the NALU fits into a single RTP packet
 		if len(nalus[0]) < e.PayloadMaxSize {
@@ -167,8 +186,7 @@ func (e *Encoder) writeSingle(nalu []byte, marker bool) ([]*rtp.Packet, error) {
 }
 
 func (e *Encoder) writeFragmented(nalu []byte, marker bool) ([]*rtp.Packet, error) {
-	// use only FU-A, not FU-B, since we always use non-interleaved mode
-	// (packetization-mode=1)
+	// use only FU-A, since FU-B is reserved to interleaved mode
 	avail := e.PayloadMaxSize - 2
 	le := len(nalu) - 1
 	packetCount := packetCount(avail, le)
@@ -212,12 +230,99 @@ func (e *Encoder) writeFragmented(nalu []byte, marker bool) ([]*rtp.Packet, erro
 	return ret, nil
 }
 
+// writeFragmentedInterleaved writes a FU-B packet followed by FU-A packets.
+func (e *Encoder) writeFragmentedInterleaved(nalu []byte, marker bool) ([]*rtp.Packet, error) {
+	// the FU-B packet and the FU-A packets must contain at least a byte each
+	if len(nalu) < 3 {
+		return nil, fmt.Errorf("NALU is too small to be fragmented")
+	}
+	if e.PayloadMaxSize < 5 {
+		return nil, fmt.Errorf("PayloadMaxSize is too small to fragment NALUs")
+	}
+
+	nri := (nalu[0] >> 5) & 0x03
+	typ := nalu[0] & 0x1F
+	nalu = nalu[1:] // remove header
+
+	// the FU-B packet can't contain the entire NALU
+	le := e.PayloadMaxSize - 4
+	if le >= len(nalu) {
+		le = len(nalu) - 1
+	}
+
+	data := make([]byte, 4+le)
+	data[0] = (nri << 5) | uint8(h264.NALUTypeFUB)
+	data[1] = (1 << 7) | typ
+	data[2] = uint8(e.don >> 8)
+	data[3] = uint8(e.don)
+	copy(data[4:], nalu)
+	nalu = nalu[le:]
+
+	e.don++
+
+	ret := []*rtp.Packet{{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: data,
+	}}
+
+	e.sequenceNumber++
+
+	avail := e.PayloadMaxSize - 2
+	packetCount := packetCount(avail, len(nalu))
+	le = avail
+	end := uint8(0)
+
+	for i := 0; i < packetCount; i++ {
+		if i == (packetCount - 1) {
+			end = 1
+			le = len(nalu)
+		}
+
+		data := make([]byte, 2+le)
+		data[0] = (nri << 5) | uint8(h264.NALUTypeFUA)
+		data[1] = (end << 6) | typ
+		copy(data[2:], nalu)
+		nalu = nalu[le:]
+
+		ret = append(ret, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				SSRC:           *e.SSRC,
+				Marker:         (i == (packetCount-1) && marker),
+			},
+			Payload: data,
+		})
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
+
 func (e *Encoder) writeAggregated(nalus [][]byte, marker bool) ([]*rtp.Packet, error) {
-	payload := make([]byte, lenAggregated(nalus, nil))
+	interleaved := (e.PacketizationMode == 2)
+	payload := make([]byte, lenAggregated(nalus, nil, interleaved))
 
 	// header
-	payload[0] = uint8(h264.NALUTypeSTAPA)
-	pos := 1
+	var pos int
+	if interleaved {
+		payload[0] = uint8(h264.NALUTypeSTAPB)
+		payload[1] = uint8(e.don >> 8)
+		payload[2] = uint8(e.don)
+		e.don += uint16(len(nalus))
+		pos = 3
+	} else {
+		payload[0] = uint8(h264.NALUTypeSTAPA)
+		pos = 1
+	}
 
 	for _, nalu := range nalus {
 		// size
@@ -300,6 +300,91 @@ var cases = []struct {
 	},
 }
 
+var casesInterleaved = []struct {
+	name  string
+	nalus [][]byte
+	pkts  []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{{0x41, 0x01, 0x02}},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x19, 0x00, 0x00, 0x00, 0x03, 0x41, 0x01, 0x02,
+				},
+			},
+		},
+	},
+	{
+		"aggregated",
+		[][]byte{
+			{0x67, 0x01},
+			{0x68, 0x02},
+			{0x65, 0x03},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x19, 0x00, 0x00, 0x00, 0x02, 0x67, 0x01, 0x00,
+					0x02, 0x68, 0x02, 0x00, 0x02, 0x65, 0x03,
+				},
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{
+			mergeBytes(
+				[]byte{0x65},
+				bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 512),
+			),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x7d, 0x85, 0x00, 0x00},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 364),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x7c, 0x45},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 148),
+				),
+			},
+		},
+	},
+}
+
 func TestEncode(t *testing.T) {
 	for _, ca := range cases {
 		t.Run(ca.name, func(t *testing.T) {
@@ -318,6 +403,76 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncodeInterleaved(t *testing.T) {
+	for _, ca := range casesInterleaved {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				PacketizationMode:     2,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.nalus)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeInterleavedSmallPayloadMaxSize(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           96,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+		PayloadMaxSize:        5,
+		PacketizationMode:     2,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{{0x05}})
+	require.EqualError(t, err, "NALU is too small to be fragmented")
+
+	d := &Decoder{
+		PacketizationMode: 2,
+	}
+	err = d.Init()
+	require.NoError(t, err)
+
+	var aus []*AccessUnit
+
+	for i, au := range [][][]byte{
+		{{0x05, 0x01, 0x02}},
+		{{0x01, 0x03, 0x04}},
+	} {
+		var pkts []*rtp.Packet
+		pkts, err = e.Encode(au)
+		require.NoError(t, err)
+		require.Len(t, pkts, 2)
+
+		for _, pkt := range pkts {
+			pkt.Timestamp = uint32(i) * 3000
+
+			var addAUs []*AccessUnit
+			addAUs, err = d.DecodeInterleaved(pkt)
+			if err == nil {
+				aus = append(aus, addAUs...)
+			} else {
+				require.Equal(t, ErrMorePacketsNeeded, err)
+			}
+		}
+	}
+
+	// an access unit is outputted when the next one is received
+	require.Equal(t, []*AccessUnit{{
+		Timestamp: 0,
+		NALUs:     [][]byte{{0x05, 0x01, 0x02}},
+	}}, aus)
+}
+
 func TestEncodeRandomInitialState(t *testing.T) {
 	e := &Encoder{
 		PayloadType: 96,
@@ -3,6 +3,7 @@ package rtph265
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/pion/rtp"
 
@@ -36,27 +37,67 @@ func auSize(au [][]byte) int {
 	return s
 }
 
+// AccessUnit is an access unit decoded by DecodeReordered.
+type AccessUnit struct {
+	// RTP timestamp of the access unit.
+	Timestamp uint32
+
+	// NALUs of the access unit, in decoding order.
+	NALUs [][]byte
+}
+
+// NALU waiting in the de-packetization buffer.
+type bufferedNALU struct {
+	don  int64
+	ts   uint32
+	nalu []byte
+}
+
 // Decoder is a RTP/H265 decoder.
 // Specification: https://datatracker.ietf.org/doc/html/rfc7798
 type Decoder struct {
-	// indicates that NALUs have an additional field that specifies the decoding order.
+	// indicates that NALUs have an additional field that specifies the decoding order
+	// (sprop-max-don-diff).
 	MaxDONDiff int
 
+	// maximum number of NALUs that precede any NALU in the de-packetization buffer
+	// in reception order and follow it in decoding order (sprop-depack-buf-nalus).
+	// When it is not zero, DecodeReordered() must be used.
+	DepackBufNALUs int
+
+	// called when a PACI packet with temporal scalability control information
+	// is received (optional).
+	OnTSCI func(*TSCI)
+
 	firstPacketReceived bool
 	fragments           [][]byte
 	fragmentsSize       int
 	fragmentNextSeqNum  uint16
+	fragmentsDON        uint16
 
 	// for Decode()
 	frameBuffer     [][]byte
 	frameBufferLen  int
 	frameBufferSize int
+
+	// for DecodeReordered()
+	donReceived   bool
+	lastDON       uint16
+	lastExtDON    int64
+	donOutput     bool
+	lastOutputDON int64
+	depackBuffer  []bufferedNALU
+	outputAU      *AccessUnit
+	outputAUSize  int
 }
 
 // Init initializes the decoder.
 func (d *Decoder) Init() error {
-	if d.MaxDONDiff != 0 {
-		return fmt.Errorf("MaxDONDiff != 0 is not supported (yet)")
+	if d.MaxDONDiff < 0 || d.DepackBufNALUs < 0 {
+		return fmt.Errorf("invalid decoding order parameters")
+	}
+	if d.DepackBufNALUs != 0 && d.MaxDONDiff == 0 {
+		return fmt.Errorf("DepackBufNALUs requires MaxDONDiff")
 	}
 	return nil
 }
@@ -66,34 +107,59 @@ func (d *Decoder) resetFragments() {
 	d.fragmentsSize = 0
 }
 
-func (d *Decoder) decodeNALUs(pkt *rtp.Packet) ([][]byte, error) {
-	if len(pkt.Payload) < 2 {
+// decodeNALUs decodes NALUs from a RTP packet.
+// When MaxDONDiff is not zero, the decoding order number of each NALU is returned too.
+func (d *Decoder) decodeNALUs(pkt *rtp.Packet, payload []byte) ([][]byte, []uint16, error) {
+	if len(payload) < 2 {
 		d.resetFragments()
-		return nil, fmt.Errorf("payload is too short")
+		return nil, nil, fmt.Errorf("payload is too short")
 	}
 
-	typ := h265.NALUType((pkt.Payload[0] >> 1) & 0b111111)
+	typ := h265.NALUType((payload[0] >> 1) & 0b111111)
+	hasDON := (d.MaxDONDiff != 0)
 	var nalus [][]byte
+	var dons []uint16
 
 	switch typ {
 	case h265.NALUType_AggregationUnit:
 		d.resetFragments()
 
-		payload := pkt.Payload[2:]
+		payload := payload[2:]
+		var don uint16
 
 		for {
+			if hasDON {
+				// first aggregation unit has a DONL, the following ones have a DOND
+				if nalus == nil {
+					if len(payload) < 2 {
+						return nil, nil, fmt.Errorf("invalid aggregation unit (invalid size)")
+					}
+					don = uint16(payload[0])<<8 | uint16(payload[1])
+					payload = payload[2:]
+				} else {
+					if len(payload) < 1 {
+						return nil, nil, fmt.Errorf("invalid aggregation unit (invalid size)")
+					}
+					don += uint16(payload[0]) + 1
+					payload = payload[1:]
+				}
+			}
+
 			if len(payload) < 2 {
-				return nil, fmt.Errorf("invalid aggregation unit (invalid size)")
+				return nil, nil, fmt.Errorf("invalid aggregation unit (invalid size)")
 			}
 
 			size := uint16(payload[0])<<8 | uint16(payload[1])
 			payload = payload[2:]
 
 			if size == 0 || int(size) > len(payload) {
-				return nil, fmt.Errorf("invalid aggregation unit (invalid size)")
+				return nil, nil, fmt.Errorf("invalid aggregation unit (invalid size)")
 			}
 
 			nalus = append(nalus, payload[:size])
+			if hasDON {
+				dons = append(dons, don)
+			}
 			payload = payload[size:]
 
 			if len(payload) == 0 {
@@ -104,78 +170,152 @@ func (d *Decoder) decodeNALUs(pkt *rtp.Packet) ([][]byte, error) {
 		d.firstPacketReceived = true
 
 	case h265.NALUType_FragmentationUnit:
-		if len(pkt.Payload) < 3 {
+		if len(payload) < 3 {
 			d.resetFragments()
-			return nil, fmt.Errorf("payload is too short")
+			return nil, nil, fmt.Errorf("payload is too short")
 		}
 
-		start := pkt.Payload[2] >> 7
-		end := (pkt.Payload[2] >> 6) & 0x01
+		start := payload[2] >> 7
+		end := (payload[2] >> 6) & 0x01
 
 		if start == 1 {
 			d.resetFragments()
 
 			if end != 0 {
-				return nil, fmt.Errorf("invalid fragmentation unit (can't contain both a start and end bit)")
+				return nil, nil, fmt.Errorf("invalid fragmentation unit (can't contain both a start and end bit)")
+			}
+
+			data := payload[3:]
+
+			// DONL is present in the first fragment only
+			if hasDON {
+				if len(data) < 2 {
+					return nil, nil, fmt.Errorf("payload is too short")
+				}
+				d.fragmentsDON = uint16(data[0])<<8 | uint16(data[1])
+				data = data[2:]
 			}
 
-			typ := pkt.Payload[2] & 0b111111
-			head := uint16(pkt.Payload[0]&0b10000001)<<8 | uint16(typ)<<9 | uint16(pkt.Payload[1])
-			d.fragmentsSize = len(pkt.Payload[1:])
-			d.fragments = append(d.fragments, []byte{byte(head >> 8), byte(head)}, pkt.Payload[3:])
+			typ := payload[2] & 0b111111
+			head := uint16(payload[0]&0b10000001)<<8 | uint16(typ)<<9 | uint16(payload[1])
+			d.fragmentsSize = 2 + len(data)
+			d.fragments = append(d.fragments, []byte{byte(head >> 8), byte(head)}, data)
 			d.fragmentNextSeqNum = pkt.SequenceNumber + 1
 			d.firstPacketReceived = true
 
-			return nil, ErrMorePacketsNeeded
+			return nil, nil, ErrMorePacketsNeeded
 		}
 
 		if d.fragmentsSize == 0 {
 			if !d.firstPacketReceived {
-				return nil, ErrNonStartingPacketAndNoPrevious
+				return nil, nil, ErrNonStartingPacketAndNoPrevious
 			}
 
-			return nil, fmt.Errorf("invalid fragmentation unit (non-starting)")
+			return nil, nil, fmt.Errorf("invalid fragmentation unit (non-starting)")
 		}
 
 		if pkt.SequenceNumber != d.fragmentNextSeqNum {
 			d.resetFragments()
-			return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+			return nil, nil, fmt.Errorf("discarding frame since a RTP packet is missing")
 		}
 
-		d.fragmentsSize += len(pkt.Payload[3:])
+		d.fragmentsSize += len(payload[3:])
 
 		if d.fragmentsSize > h265.MaxAccessUnitSize {
 			errSize := d.fragmentsSize
 			d.resetFragments()
-			return nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d",
+			return nil, nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d",
 				errSize, h265.MaxAccessUnitSize)
 		}
 
-		d.fragments = append(d.fragments, pkt.Payload[3:])
+		d.fragments = append(d.fragments, payload[3:])
 		d.fragmentNextSeqNum++
 
 		if end != 1 {
-			return nil, ErrMorePacketsNeeded
+			return nil, nil, ErrMorePacketsNeeded
 		}
 
 		nalus = [][]byte{joinFragments(d.fragments, d.fragmentsSize)}
+		if hasDON {
+			dons = []uint16{d.fragmentsDON}
+		}
 		d.resetFragments()
 
 	case h265.NALUType_PACI:
-		d.resetFragments()
-		return nil, fmt.Errorf("PACI packets are not supported (yet)")
+		if len(payload) < 4 {
+			d.resetFragments()
+			return nil, nil, fmt.Errorf("invalid PACI packet (invalid size)")
+		}
+
+		a := payload[2] >> 7
+		ctype := (payload[2] >> 1) & 0b111111
+		phsSize := int(payload[2]&0x01)<<4 | int(payload[3]>>4)
+		f0 := (payload[3] >> 3) & 0x01
+
+		if h265.NALUType(ctype) == h265.NALUType_PACI {
+			d.resetFragments()
+			return nil, nil, fmt.Errorf("invalid PACI packet (nested PACI)")
+		}
+
+		if len(payload) < (4 + phsSize) {
+			d.resetFragments()
+			return nil, nil, fmt.Errorf("invalid PACI packet (invalid size)")
+		}
+
+		phes := payload[4 : 4+phsSize]
+
+		if f0 == 1 {
+			var tsci TSCI
+			err := tsci.unmarshal(phes)
+			if err != nil {
+				d.resetFragments()
+				return nil, nil, err
+			}
+
+			if d.OnTSCI != nil {
+				d.OnTSCI(&tsci)
+			}
+		}
+
+		// rebuild the payload header of the contained packet
+		inner := make([]byte, 2+len(payload[4+phsSize:]))
+		inner[0] = a<<7 | ctype<<1 | (payload[0] & 0x01)
+		inner[1] = payload[1]
+		copy(inner[2:], payload[4+phsSize:])
+
+		return d.decodeNALUs(pkt, inner)
 
 	default:
 		d.resetFragments()
-		nalus = [][]byte{pkt.Payload}
+
+		if hasDON {
+			if len(payload) < 4 {
+				return nil, nil, fmt.Errorf("payload is too short")
+			}
+
+			// remove DONL
+			nalu := make([]byte, len(payload)-2)
+			copy(nalu, payload[:2])
+			copy(nalu[2:], payload[4:])
+
+			nalus = [][]byte{nalu}
+			dons = []uint16{uint16(payload[2])<<8 | uint16(payload[3])}
+		} else {
+			nalus = [][]byte{payload}
+		}
 	}
 
-	return nalus, nil
+	return nalus, dons, nil
 }
 
 // Decode decodes an access unit from a RTP packet.
+// When DepackBufNALUs is not zero, DecodeReordered must be used instead.
 func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
-	nalus, err := d.decodeNALUs(pkt)
+	if d.DepackBufNALUs != 0 {
+		return nil, fmt.Errorf("DepackBufNALUs != 0 requires DecodeReordered()")
+	}
+
+	nalus, _, err := d.decodeNALUs(pkt, pkt.Payload)
 	if err != nil {
 		return nil, err
 	}
@@ -218,3 +358,106 @@ func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
 
 	return ret, nil
 }
+
+// extendDON converts a 16-bit DON into a DON that doesn't wrap around.
+func (d *Decoder) extendDON(don uint16) int64 {
+	if !d.donReceived {
+		d.donReceived = true
+		d.lastExtDON = int64(don)
+	} else {
+		d.lastExtDON += int64(int16(don - d.lastDON))
+	}
+
+	d.lastDON = don
+	return d.lastExtDON
+}
+
+// DecodeReordered decodes access units from a RTP packet,
+// reordering NALUs by their decoding order number (DON).
+// It requires MaxDONDiff to be not zero.
+// NALUs are grouped into access units by their timestamp, therefore
+// an access unit is returned when the first NALU of the next one is decoded.
+// A packet can complete zero or more access units.
+func (d *Decoder) DecodeReordered(pkt *rtp.Packet) ([]*AccessUnit, error) {
+	if d.MaxDONDiff == 0 {
+		return nil, fmt.Errorf("DecodeReordered() requires MaxDONDiff")
+	}
+
+	nalus, dons, err := d.decodeNALUs(pkt, pkt.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, nalu := range nalus {
+		don := d.extendDON(dons[i])
+
+		// discard NALUs that should have been decoded before the ones already outputted
+		if d.donOutput && don <= d.lastOutputDON {
+			continue
+		}
+
+		if len(d.depackBuffer) >= (d.DepackBufNALUs + h265.MaxNALUsPerAccessUnit) {
+			errCount := len(d.depackBuffer) + 1
+			d.depackBuffer = nil
+			return nil, fmt.Errorf("de-packetization buffer NALU count (%d) exceeds maximum allowed (%d)",
+				errCount, d.DepackBufNALUs+h265.MaxNALUsPerAccessUnit)
+		}
+
+		j := sort.Search(len(d.depackBuffer), func(j int) bool {
+			return d.depackBuffer[j].don > don
+		})
+		d.depackBuffer = append(d.depackBuffer, bufferedNALU{})
+		copy(d.depackBuffer[j+1:], d.depackBuffer[j:])
+		d.depackBuffer[j] = bufferedNALU{
+			don:  don,
+			ts:   pkt.Timestamp,
+			nalu: nalu,
+		}
+	}
+
+	var ret []*AccessUnit
+
+	for len(d.depackBuffer) > d.DepackBufNALUs {
+		nalu := d.depackBuffer[0]
+		d.depackBuffer = d.depackBuffer[1:]
+
+		d.donOutput = true
+		d.lastOutputDON = nalu.don
+
+		// an access unit is complete when a NALU with a different timestamp is found
+		if d.outputAU != nil && nalu.ts != d.outputAU.Timestamp {
+			ret = append(ret, d.outputAU)
+			d.outputAU = nil
+			d.outputAUSize = 0
+		}
+
+		if d.outputAU == nil {
+			d.outputAU = &AccessUnit{Timestamp: nalu.ts}
+		}
+
+		if (len(d.outputAU.NALUs) + 1) > h265.MaxNALUsPerAccessUnit {
+			errCount := len(d.outputAU.NALUs) + 1
+			d.outputAU = nil
+			d.outputAUSize = 0
+			return nil, fmt.Errorf("NALU count (%d) exceeds maximum allowed (%d)",
+				errCount, h265.MaxNALUsPerAccessUnit)
+		}
+
+		if (d.outputAUSize + len(nalu.nalu)) > h265.MaxAccessUnitSize {
+			errSize := d.outputAUSize + len(nalu.nalu)
+			d.outputAU = nil
+			d.outputAUSize = 0
+			return nil, fmt.Errorf("access unit size (%d) is too big, maximum is %d",
+				errSize, h265.MaxAccessUnitSize)
+		}
+
+		d.outputAU.NALUs = append(d.outputAU.NALUs, nalu.nalu)
+		d.outputAUSize += len(nalu.nalu)
+	}
+
+	if ret == nil {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	return ret, nil
+}
@@ -128,6 +128,136 @@ func TestDecodeErrorMissingPacket(t *testing.T) {
 	require.EqualError(t, err, "discarding frame since a RTP packet is missing")
 }
 
+func TestDecodeDON(t *testing.T) {
+	for _, ca := range casesDON {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				MaxDONDiff: 2,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var nalus [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addNALUs, err := d.Decode(pkt)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+
+				require.NoError(t, err)
+				nalus = append(nalus, addNALUs...)
+			}
+
+			require.Equal(t, ca.nalus, nalus)
+		})
+	}
+}
+
+func TestDecodeReordered(t *testing.T) {
+	d := &Decoder{
+		MaxDONDiff:     2,
+		DepackBufNALUs: 1,
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.DecodeReordered(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			Timestamp:      4000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x02, 0x01, 0x00, 0x01, 0xb2},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.DecodeReordered(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17646,
+			Timestamp:      1000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x26, 0x01, 0x00, 0x00, 0xa1},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	aus, err := d.DecodeReordered(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17647,
+			Timestamp:      7000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x02, 0x01, 0x00, 0x02, 0xc3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*AccessUnit{{
+		Timestamp: 1000,
+		NALUs:     [][]byte{{0x26, 0x01, 0xa1}},
+	}}, aus)
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17648,
+			Timestamp:      10000,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x02, 0x01, 0x00, 0x03, 0xd4},
+	})
+	require.EqualError(t, err, "DepackBufNALUs != 0 requires DecodeReordered()")
+}
+
+func TestDecodePACI(t *testing.T) {
+	var tsci *TSCI
+
+	d := &Decoder{
+		OnTSCI: func(t *TSCI) {
+			tsci = t
+		},
+	}
+	err := d.Init()
+	require.NoError(t, err)
+
+	nalus, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{
+			0x64, 0x01, 0x02, 0x38, 0x05, 0x06, 0x80, 0x03,
+			0x04,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x02, 0x01, 0x03, 0x04}}, nalus)
+	require.Equal(t, &TSCI{
+		TL0PicIdx: 5,
+		IrapPicID: 6,
+		S:         true,
+	}, tsci)
+}
+
 func FuzzDecoder(f *testing.F) {
 	f.Fuzz(func(t *testing.T, a []byte, am bool, b []byte, bm bool) {
 		d := &Decoder{}
@@ -165,3 +295,42 @@ func FuzzDecoder(f *testing.F) {
 		}
 	})
 }
+
+func FuzzDecoderReordered(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte, c []byte) {
+		d := &Decoder{
+			MaxDONDiff:     2,
+			DepackBufNALUs: 1,
+		}
+		err := d.Init()
+		require.NoError(t, err)
+
+		for i, payload := range [][]byte{a, b, c} {
+			aus, err := d.DecodeReordered(&rtp.Packet{
+				Header: rtp.Header{
+					SequenceNumber: 17645 + uint16(i),
+					Timestamp:      uint32(i) * 3000,
+				},
+				Payload: payload,
+			})
+
+			if err == nil {
+				if len(aus) == 0 {
+					t.Errorf("should not happen")
+				}
+
+				for _, au := range aus {
+					if len(au.NALUs) == 0 {
+						t.Errorf("should not happen")
+					}
+
+					for _, nalu := range au.NALUs {
+						if len(nalu) == 0 {
+							t.Errorf("should not happen")
+						}
+					}
+				}
+			}
+		}
+	})
+}
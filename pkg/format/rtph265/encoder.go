@@ -48,15 +48,22 @@ type Encoder struct {
 	PayloadMaxSize int
 
 	// indicates that NALUs have an additional field that specifies the decoding order.
+	// NALUs are sent in decoding order, with consecutive decoding order numbers.
 	MaxDONDiff int
 
+	// temporal scalability control information (optional).
+	// When set, packets are wrapped into PACI packets that carry it.
+	// It can be changed between calls to Encode().
+	TSCI *TSCI
+
 	sequenceNumber uint16
+	don            uint16
 }
 
 // Init initializes the encoder.
 func (e *Encoder) Init() error {
-	if e.MaxDONDiff != 0 {
-		return fmt.Errorf("MaxDONDiff != 0 is not supported (yet)")
+	if e.MaxDONDiff < 0 {
+		return fmt.Errorf("invalid MaxDONDiff")
 	}
 
 	if e.SSRC == nil {
@@ -82,6 +89,14 @@ func (e *Encoder) Init() error {
 	return nil
 }
 
+// maximum size of payloads of packets before they are wrapped into PACI packets.
+func (e *Encoder) innerPayloadMaxSize() int {
+	if e.TSCI != nil {
+		return e.PayloadMaxSize - 2 - tsciSize
+	}
+	return e.PayloadMaxSize
+}
+
 // Encode encodes an access unit into RTP/H265 packets.
 func (e *Encoder) Encode(au [][]byte) ([]*rtp.Packet, error) {
 	var rets []*rtp.Packet
@@ -89,7 +104,7 @@ func (e *Encoder) Encode(au [][]byte) ([]*rtp.Packet, error) {
 
 	// split NALUs into batches
 	for _, nalu := range au {
-		if e.lenAggregationUnit(batch, nalu) <= e.PayloadMaxSize {
+		if e.lenAggregationUnit(batch, nalu) <= e.innerPayloadMaxSize() {
 			// add to existing batch
 			batch = append(batch, nalu)
 		} else {
@@ -115,13 +130,37 @@ func (e *Encoder) Encode(au [][]byte) ([]*rtp.Packet, error) {
 	}
 	rets = append(rets, pkts...)
 
+	if e.TSCI != nil {
+		for _, pkt := range rets {
+			pkt.Payload = e.wrapPACI(pkt.Payload)
+		}
+	}
+
 	return rets, nil
 }
 
+// wrapPACI wraps a payload into a PACI packet that carries the TSCI.
+func (e *Encoder) wrapPACI(payload []byte) []byte {
+	ret := make([]byte, 4+tsciSize+len(payload)-2)
+
+	// payload header
+	ret[0] = 50<<1 | (payload[0] & 0x01)
+	ret[1] = payload[1]
+
+	// A, cType, PHSsize, F0 (TSCI is present), F1, F2, Y
+	ret[2] = (payload[0] & 0b11111110) | (tsciSize >> 4)
+	ret[3] = (tsciSize&0x0F)<<4 | 1<<3
+
+	e.TSCI.marshalTo(ret[4:])
+	copy(ret[4+tsciSize:], payload[2:])
+
+	return ret
+}
+
 func (e *Encoder) writeBatch(nalus [][]byte, marker bool) ([]*rtp.Packet, error) {
 	if len(nalus) == 1 {
 		// the NALU fits into a single RTP packet
-		if len(nalus[0]) < e.PayloadMaxSize {
+		if e.lenSingle(nalus[0]) < e.innerPayloadMaxSize() {
 			return e.writeSingle(nalus[0], marker)
 		}
 
@@ -132,7 +171,25 @@ func (e *Encoder) writeBatch(nalus [][]byte, marker bool) ([]*rtp.Packet, error)
 	return e.writeAggregationUnit(nalus, marker)
 }
 
+func (e *Encoder) lenSingle(nalu []byte) int {
+	if e.MaxDONDiff != 0 {
+		return len(nalu) + 2 // DONL
+	}
+	return len(nalu)
+}
+
 func (e *Encoder) writeSingle(nalu []byte, marker bool) ([]*rtp.Packet, error) {
+	if e.MaxDONDiff != 0 {
+		payload := make([]byte, len(nalu)+2)
+		copy(payload, nalu[:2])
+		payload[2] = uint8(e.don >> 8)
+		payload[3] = uint8(e.don)
+		copy(payload[4:], nalu[2:])
+		nalu = payload
+
+		e.don++
+	}
+
 	pkt := &rtp.Packet{
 		Header: rtp.Header{
 			Version:        rtpVersion,
@@ -150,8 +207,16 @@ func (e *Encoder) writeSingle(nalu []byte, marker bool) ([]*rtp.Packet, error) {
 }
 
 func (e *Encoder) writeFragmentationUnits(nalu []byte, marker bool) ([]*rtp.Packet, error) {
-	avail := e.PayloadMaxSize - 3
+	avail := e.innerPayloadMaxSize() - 3
 	le := len(nalu) - 2
+
+	// DONL is present in the first fragment only
+	donlLen := 0
+	if e.MaxDONDiff != 0 {
+		donlLen = 2
+		le += donlLen
+	}
+
 	packetCount := packetCount(avail, le)
 
 	ret := make([]*rtp.Packet, packetCount)
@@ -164,7 +229,7 @@ func (e *Encoder) writeFragmentationUnits(nalu []byte, marker bool) ([]*rtp.Pack
 
 	for i := range ret {
 		if i == (packetCount - 1) {
-			le = len(nalu)
+			le = len(nalu) + donlLen
 			end = 1
 		}
 
@@ -172,8 +237,15 @@ func (e *Encoder) writeFragmentationUnits(nalu []byte, marker bool) ([]*rtp.Pack
 		data[0] = head[0]&0b10000001 | 49<<1
 		data[1] = head[1]
 		data[2] = (start << 7) | (end << 6) | (head[0]>>1)&0b111111
-		copy(data[3:], nalu)
-		nalu = nalu[le:]
+
+		if donlLen != 0 {
+			data[3] = uint8(e.don >> 8)
+			data[4] = uint8(e.don)
+		}
+
+		copy(data[3+donlLen:], nalu)
+		nalu = nalu[le-donlLen:]
+		donlLen = 0
 
 		ret[i] = &rtp.Packet{
 			Header: rtp.Header{
@@ -190,18 +262,32 @@ func (e *Encoder) writeFragmentationUnits(nalu []byte, marker bool) ([]*rtp.Pack
 		start = 0
 	}
 
+	if e.MaxDONDiff != 0 {
+		e.don++
+	}
+
 	return ret, nil
 }
 
 func (e *Encoder) lenAggregationUnit(nalus [][]byte, addNALU []byte) int {
 	ret := 2 // header
 
+	if e.MaxDONDiff != 0 {
+		ret++ // DONL of the first unit is one byte longer than the DONDs
+	}
+
 	for _, nalu := range nalus {
+		if e.MaxDONDiff != 0 {
+			ret++ // DOND
+		}
 		ret += 2         // size
 		ret += len(nalu) // nalu
 	}
 
 	if addNALU != nil {
+		if e.MaxDONDiff != 0 {
+			ret++ // DOND
+		}
 		ret += 2            // size
 		ret += len(addNALU) // nalu
 	}
@@ -218,7 +304,22 @@ func (e *Encoder) writeAggregationUnit(nalus [][]byte, marker bool) ([]*rtp.Pack
 	payload[1] = byte(h)
 	pos := 2
 
-	for _, nalu := range nalus {
+	for i, nalu := range nalus {
+		if e.MaxDONDiff != 0 {
+			if i == 0 {
+				// DONL
+				payload[pos] = uint8(e.don >> 8)
+				payload[pos+1] = uint8(e.don)
+				pos += 2
+			} else {
+				// DOND, NALUs are in decoding order
+				payload[pos] = 0
+				pos++
+			}
+
+			e.don++
+		}
+
 		// size
 		naluLen := len(nalu)
 		payload[pos] = uint8(naluLen >> 8)
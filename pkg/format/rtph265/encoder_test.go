@@ -158,6 +158,104 @@ var cases = []struct {
 	},
 }
 
+var casesDON = []struct {
+	name  string
+	nalus [][]byte
+	pkts  []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{{0x02, 0x01, 0x03, 0x04}},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x02, 0x01, 0x00, 0x00, 0x03, 0x04},
+			},
+		},
+	},
+	{
+		"aggregated",
+		[][]byte{
+			{0x40, 0x01, 0x07},
+			{0x42, 0x01, 0x08},
+			{0x44, 0x01, 0x09},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x60, 0x00, 0x00, 0x00, 0x00, 0x03, 0x40, 0x01,
+					0x07, 0x00, 0x00, 0x03, 0x42, 0x01, 0x08, 0x00,
+					0x00, 0x03, 0x44, 0x01, 0x09,
+				},
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{
+			mergeBytes(
+				[]byte{0x26, 0x01},
+				bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 1024),
+			),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x62, 0x01, 0x93, 0x00, 0x00},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 363),
+					[]byte{0x01, 0x02, 0x03},
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x62, 0x01, 0x13, 0x04},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 364),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x62, 0x01, 0x53},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 296),
+				),
+			},
+		},
+	},
+}
+
 func TestEncode(t *testing.T) {
 	for _, ca := range cases {
 		t.Run(ca.name, func(t *testing.T) {
@@ -176,6 +274,56 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncodeDON(t *testing.T) {
+	for _, ca := range casesDON {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				MaxDONDiff:            2,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.nalus)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodePACI(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           96,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+		TSCI: &TSCI{
+			TL0PicIdx: 5,
+			IrapPicID: 6,
+			S:         true,
+		},
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode([][]byte{{0x02, 0x01, 0x03, 0x04}})
+	require.NoError(t, err)
+	require.Equal(t, []*rtp.Packet{{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{
+			0x64, 0x01, 0x02, 0x38, 0x05, 0x06, 0x80, 0x03,
+			0x04,
+		},
+	}}, pkts)
+}
+
 func TestEncodeRandomInitialState(t *testing.T) {
 	e := &Encoder{
 		PayloadType: 96,
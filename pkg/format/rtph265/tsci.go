@@ -0,0 +1,43 @@
+package rtph265
+
+import (
+	"fmt"
+)
+
+const tsciSize = 3
+
+// TSCI is the temporal scalability control information,
+// that can be carried by the header extension of PACI packets.
+// Specification: https://datatracker.ietf.org/doc/html/rfc7798#section-4.5
+type TSCI struct {
+	TL0PicIdx uint8
+	IrapPicID uint8
+	S         bool
+	E         bool
+}
+
+func (t *TSCI) unmarshal(buf []byte) error {
+	if len(buf) < tsciSize {
+		return fmt.Errorf("invalid TSCI (invalid size)")
+	}
+
+	t.TL0PicIdx = buf[0]
+	t.IrapPicID = buf[1]
+	t.S = (buf[2] >> 7) == 1
+	t.E = ((buf[2] >> 6) & 0x01) == 1
+
+	return nil
+}
+
+func (t TSCI) marshalTo(buf []byte) {
+	buf[0] = t.TL0PicIdx
+	buf[1] = t.IrapPicID
+	buf[2] = 0
+
+	if t.S {
+		buf[2] |= 1 << 7
+	}
+	if t.E {
+		buf[2] |= 1 << 6
+	}
+}
@@ -0,0 +1,21 @@
+// Package rtph266 contains a RTP/H266 decoder and encoder.
+package rtph266
+
+const (
+	// maximum size of an access unit.
+	maxAccessUnitSize = 8 * 1024 * 1024
+
+	// maximum number of NALUs inside an access unit.
+	maxNALUsPerAccessUnit = 21
+)
+
+// NALU types that are specific to the RTP payload format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc9328#section-4.3
+const (
+	naluTypeAggregationPacket = 28
+	naluTypeFragmentationUnit = 29
+)
+
+func naluType(nalu []byte) uint8 {
+	return nalu[1] >> 3
+}
@@ -0,0 +1,71 @@
+package rtpilbc
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/iLBC decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3952
+type Decoder struct {
+	// frame duration in milliseconds, 20 or 30 (optional).
+	// It defaults to 30.
+	Mode int
+
+	frameSize      int
+	otherFrameSize int
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	if d.Mode == 0 {
+		d.Mode = defaultMode
+	}
+
+	var err error
+	d.frameSize, err = frameSize(d.Mode)
+	if err != nil {
+		return err
+	}
+
+	if d.Mode == 20 {
+		d.otherFrameSize, _ = frameSize(30)
+	} else {
+		d.otherFrameSize, _ = frameSize(20)
+	}
+
+	return nil
+}
+
+// Decode decodes frames from a RTP packet.
+// When the payload size is not compatible with the configured mode,
+// frames are split according to the other mode, as suggested by the specification.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	payload := pkt.Payload
+
+	var fs int
+
+	switch {
+	case len(payload) == 0:
+		return nil, fmt.Errorf("payload is empty")
+
+	case (len(payload) % d.frameSize) == 0:
+		fs = d.frameSize
+
+	case (len(payload) % d.otherFrameSize) == 0:
+		fs = d.otherFrameSize
+
+	default:
+		return nil, fmt.Errorf("invalid payload size: %d", len(payload))
+	}
+
+	n := len(payload) / fs
+	frames := make([][]byte, n)
+
+	for i := range n {
+		frames[i] = payload[i*fs : (i+1)*fs]
+	}
+
+	return frames, nil
+}
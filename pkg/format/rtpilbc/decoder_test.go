@@ -0,0 +1,54 @@
+package rtpilbc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				Mode: ca.mode,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			frames, err := d.Decode(ca.pkt)
+			require.NoError(t, err)
+			require.Equal(t, ca.frames, frames)
+		})
+	}
+}
+
+func TestDecodeOtherMode(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	frames, err := d.Decode(&rtp.Packet{
+		Payload: bytes.Repeat([]byte{0x01}, 38),
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{bytes.Repeat([]byte{0x01}, 38)}, frames)
+
+	_, err = d.Decode(&rtp.Packet{
+		Payload: bytes.Repeat([]byte{0x01}, 40),
+	})
+	require.EqualError(t, err, "invalid payload size: 40")
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Payload: a,
+		})
+	})
+}
@@ -0,0 +1,124 @@
+package rtpilbc
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/iLBC encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3952
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// frame duration in milliseconds, 20 or 30 (optional).
+	// It defaults to 30.
+	Mode int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	frameSize      int
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.Mode == 0 {
+		e.Mode = defaultMode
+	}
+
+	fs, err := frameSize(e.Mode)
+	if err != nil {
+		return err
+	}
+
+	if e.SSRC == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	e.frameSize = fs
+
+	return nil
+}
+
+// Encode encodes frames into a RTP packet.
+func (e *Encoder) Encode(frames [][]byte) (*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	for _, frame := range frames {
+		if len(frame) != e.frameSize {
+			return nil, fmt.Errorf("invalid frame size: %d", len(frame))
+		}
+	}
+
+	size := len(frames) * e.frameSize
+
+	if size > e.PayloadMaxSize {
+		return nil, fmt.Errorf("frames are too big")
+	}
+
+	payload := make([]byte, size)
+	n := 0
+	for _, frame := range frames {
+		n += copy(payload[n:], frame)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt, nil
+}
@@ -0,0 +1,105 @@
+package rtpilbc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var cases = []struct {
+	name   string
+	mode   int
+	frames [][]byte
+	pkt    *rtp.Packet
+}{
+	{
+		"mode 30",
+		30,
+		[][]byte{bytes.Repeat([]byte{0x01}, 50)},
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    97,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: bytes.Repeat([]byte{0x01}, 50),
+		},
+	},
+	{
+		"mode 20 multiple",
+		20,
+		[][]byte{
+			bytes.Repeat([]byte{0x01}, 38),
+			bytes.Repeat([]byte{0x02}, 38),
+		},
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    97,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: append(bytes.Repeat([]byte{0x01}, 38), bytes.Repeat([]byte{0x02}, 38)...),
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           97,
+				Mode:                  ca.mode,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkt, err := e.Encode(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkt, pkt)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 97,
+		Mode:        25,
+	}
+	err := e.Init()
+	require.EqualError(t, err, "unsupported mode: 25")
+
+	e = &Encoder{
+		PayloadType: 97,
+	}
+	err = e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{bytes.Repeat([]byte{0x01}, 38)})
+	require.EqualError(t, err, "invalid frame size: 38")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 97,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,24 @@
+// Package rtpilbc contains a RTP/iLBC decoder and encoder.
+package rtpilbc
+
+import (
+	"fmt"
+)
+
+const (
+	defaultMode = 30
+)
+
+// frameSize returns the size of frames of the given mode.
+func frameSize(mode int) (int, error) {
+	switch mode {
+	case 20:
+		return 38, nil
+
+	case 30:
+		return 50, nil
+
+	default:
+		return 0, fmt.Errorf("unsupported mode: %d", mode)
+	}
+}
@@ -0,0 +1,122 @@
+package rtpjpeg2000
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented codestream and we didn't receive anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+func joinFragments(fragments [][]byte, size int) []byte {
+	ret := make([]byte, size)
+	n := 0
+	for _, p := range fragments {
+		n += copy(ret[n:], p)
+	}
+	return ret
+}
+
+// Decoder is a RTP/JPEG 2000 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5371
+type Decoder struct {
+	fragments          [][]byte
+	fragmentsSize      int
+	fragmentNextSeqNum uint16
+	fragmentsTimestamp uint32
+
+	// last received main header, used to rebuild codestreams
+	// whose main header has been omitted by the sender.
+	mainHeader   []byte
+	mainHeaderID uint8
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes a codestream from a RTP packet.
+// In case of interlaced video, each field is returned as a separate codestream.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	var h header
+	err := h.unmarshal(pkt.Payload)
+	if err != nil {
+		d.resetFragments()
+		return nil, err
+	}
+
+	payload := pkt.Payload[headerSize:]
+
+	if d.fragmentsSize == 0 {
+		switch {
+		case h.FragmentOffset == 0:
+			if h.MainHeaderFlag != mainHeaderFragment && h.MainHeaderFlag != mainHeaderWhole {
+				return nil, fmt.Errorf("main header is missing")
+			}
+
+		// main header has been omitted since it is equal to the previous one
+		case h.MainHeaderFlag == mainHeaderNone && d.mainHeader != nil &&
+			h.MainHeaderID == d.mainHeaderID && int(h.FragmentOffset) == len(d.mainHeader):
+			d.fragments = append(d.fragments, d.mainHeader)
+			d.fragmentsSize = len(d.mainHeader)
+
+		default:
+			return nil, ErrNonStartingPacketAndNoPrevious
+		}
+	} else {
+		if pkt.SequenceNumber != d.fragmentNextSeqNum || pkt.Timestamp != d.fragmentsTimestamp {
+			d.resetFragments()
+			return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+		}
+
+		if int(h.FragmentOffset) != d.fragmentsSize {
+			errOffset := d.fragmentsSize
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid fragment offset: %d, expected %d",
+				h.FragmentOffset, errOffset)
+		}
+	}
+
+	d.fragmentsSize += len(payload)
+
+	if d.fragmentsSize > maxCodestreamSize {
+		errSize := d.fragmentsSize
+		d.resetFragments()
+		return nil, fmt.Errorf("codestream size (%d) is too big, maximum is %d",
+			errSize, maxCodestreamSize)
+	}
+
+	d.fragments = append(d.fragments, payload)
+	d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+	d.fragmentsTimestamp = pkt.Timestamp
+
+	// main header is transmitted in dedicated packets, store it
+	if h.MainHeaderFlag == mainHeaderLastFragment || h.MainHeaderFlag == mainHeaderWhole {
+		d.mainHeader = joinFragments(d.fragments, d.fragmentsSize)
+		d.mainHeaderID = h.MainHeaderID
+	}
+
+	if !pkt.Marker {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	codestream := joinFragments(d.fragments, d.fragmentsSize)
+	d.resetFragments()
+
+	return codestream, nil
+}
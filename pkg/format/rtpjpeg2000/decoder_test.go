@@ -0,0 +1,95 @@
+package rtpjpeg2000
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var codestream []byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				codestream, err = d.Decode(pkt)
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+			}
+
+			require.Equal(t, ca.codestream, codestream)
+		})
+	}
+}
+
+func TestDecodeOmittedMainHeader(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[0].pkts[1])
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+
+	_, err = d.Decode(cases[0].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	codestream, err := d.Decode(cases[0].pkts[1])
+	require.NoError(t, err)
+	require.Equal(t, testCodestream, codestream)
+
+	pkt := cases[0].pkts[1].Clone()
+	pkt.SequenceNumber = 17647
+
+	codestream, err = d.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, testCodestream, codestream)
+}
+
+func TestDecodeMissingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[1].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.Decode(cases[1].pkts[2])
+	require.EqualError(t, err, "discarding frame since a RTP packet is missing")
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, am bool, b []byte, bm bool) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				Marker:         am,
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				Marker:         bm,
+				SequenceNumber: 17646,
+			},
+			Payload: b,
+		})
+	})
+}
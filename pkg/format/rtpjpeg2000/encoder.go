@@ -0,0 +1,173 @@
+package rtpjpeg2000
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/JPEG 2000 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5371
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	mainHeader     []byte
+	mainHeaderID   uint8
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	if e.PayloadMaxSize <= headerSize {
+		return fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+func (e *Encoder) writePacket(h header, payload []byte) *rtp.Packet {
+	buf := make([]byte, headerSize+len(payload))
+	h.marshalTo(buf)
+	copy(buf[headerSize:], payload)
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			SSRC:           *e.SSRC,
+		},
+		Payload: buf,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
+
+// Encode encodes a codestream into RTP packets.
+// The main header is sent in dedicated packets, while tile-parts are split
+// into packets that carry the tile number.
+func (e *Encoder) Encode(codestream []byte) ([]*rtp.Packet, error) {
+	if len(codestream) > maxCodestreamSize {
+		return nil, fmt.Errorf("codestream size (%d) is too big, maximum is %d",
+			len(codestream), maxCodestreamSize)
+	}
+
+	mhSize, err := mainHeaderSize(codestream)
+	if err != nil {
+		return nil, err
+	}
+
+	tileParts, err := splitTileParts(codestream, mhSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// the main header ID must change every time the main header changes
+	if e.mainHeader != nil && !bytes.Equal(e.mainHeader, codestream[:mhSize]) {
+		e.mainHeaderID = (e.mainHeaderID + 1) & 0x07
+	}
+	e.mainHeader = append(e.mainHeader[:0], codestream[:mhSize]...)
+
+	avail := e.PayloadMaxSize - headerSize
+	var ret []*rtp.Packet
+
+	for pos := 0; pos < mhSize; {
+		le := min(avail, mhSize-pos)
+
+		var flag uint8
+		switch {
+		case pos == 0 && le == mhSize:
+			flag = mainHeaderWhole
+		case (pos + le) == mhSize:
+			flag = mainHeaderLastFragment
+		default:
+			flag = mainHeaderFragment
+		}
+
+		ret = append(ret, e.writePacket(header{
+			MainHeaderFlag:    flag,
+			MainHeaderID:      e.mainHeaderID,
+			TileNumberInvalid: true,
+			FragmentOffset:    uint32(pos),
+		}, codestream[pos:pos+le]))
+
+		pos += le
+	}
+
+	for _, tp := range tileParts {
+		for pos := tp.start; pos < tp.end; {
+			le := min(avail, tp.end-pos)
+
+			// headers have the highest priority, while
+			// the priority of other packets is not specified.
+			priority := uint8(255)
+			if pos == tp.start {
+				priority = 0
+			}
+
+			ret = append(ret, e.writePacket(header{
+				MainHeaderID:   e.mainHeaderID,
+				Priority:       priority,
+				TileNumber:     tp.tileNumber,
+				FragmentOffset: uint32(pos),
+			}, codestream[pos:pos+le]))
+
+			pos += le
+		}
+	}
+
+	ret[len(ret)-1].Marker = true
+
+	return ret, nil
+}
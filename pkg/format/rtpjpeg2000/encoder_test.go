@@ -0,0 +1,264 @@
+package rtpjpeg2000
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var testCodestream = []byte{
+	0xff, 0x4f, // SOC
+	0xff, 0x51, 0x00, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, // SIZ
+	0xff, 0x90, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x00, 0x01, // SOT
+	0xff, 0x93, // SOD
+	0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	0xff, 0xd9, // EOC
+}
+
+var testCodestreamMultipleTiles = []byte{
+	0xff, 0x4f, // SOC
+	0xff, 0x51, 0x00, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, // SIZ
+	0xff, 0x90, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x01, // SOT
+	0xff, 0x93, // SOD
+	0x0a, 0x0b,
+	0xff, 0x90, 0x00, 0x0a, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, // SOT
+	0xff, 0x93, // SOD
+	0x0c, 0x0d,
+	0xff, 0xd9, // EOC
+}
+
+var cases = []struct {
+	name           string
+	payloadMaxSize int
+	codestream     []byte
+	pkts           []*rtp.Packet
+}{
+	{
+		"single tile",
+		0,
+		testCodestream,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x31, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+					testCodestream[0:12],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0c},
+					testCodestream[12:34],
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		18,
+		testCodestream,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+					testCodestream[0:10],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a},
+					testCodestream[10:12],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0c},
+					testCodestream[12:22],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17648,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x16},
+					testCodestream[22:32],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17649,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20},
+					testCodestream[32:34],
+				),
+			},
+		},
+	},
+	{
+		"multiple tiles",
+		0,
+		testCodestreamMultipleTiles,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x31, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+					testCodestreamMultipleTiles[0:12],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0c},
+					testCodestreamMultipleTiles[12:28],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x1c},
+					testCodestreamMultipleTiles[28:46],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				PayloadMaxSize:        ca.payloadMaxSize,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.codestream)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeMainHeaderID(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(testCodestream)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x31), pkts[0].Payload[0])
+
+	pkts, err = e.Encode(testCodestream)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x31), pkts[0].Payload[0])
+
+	codestream := append([]byte(nil), testCodestream...)
+	codestream[6] = 0x07
+
+	pkts, err = e.Encode(codestream)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x33), pkts[0].Payload[0])
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,160 @@
+// Package rtpjpeg2000 contains a RTP/JPEG 2000 decoder and encoder.
+package rtpjpeg2000
+
+import (
+	"fmt"
+)
+
+const (
+	// maximum size of a codestream, limited by the 24-bit fragment offset.
+	maxCodestreamSize = 0xFFFFFF
+
+	headerSize = 8
+)
+
+const (
+	markerSOC = 0xFF4F
+	markerSOT = 0xFF90
+	markerEOC = 0xFFD9
+)
+
+// values of the main header flag.
+const (
+	mainHeaderNone = iota
+	mainHeaderFragment
+	mainHeaderLastFragment
+	mainHeaderWhole
+)
+
+type header struct {
+	Type              uint8
+	MainHeaderFlag    uint8
+	MainHeaderID      uint8
+	TileNumberInvalid bool
+	Priority          uint8
+	TileNumber        uint16
+	FragmentOffset    uint32
+}
+
+func (h *header) unmarshal(buf []byte) error {
+	if len(buf) < headerSize {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	h.Type = buf[0] >> 6
+	h.MainHeaderFlag = (buf[0] >> 4) & 0x03
+	h.MainHeaderID = (buf[0] >> 1) & 0x07
+	h.TileNumberInvalid = (buf[0] & 0x01) != 0
+	h.Priority = buf[1]
+	h.TileNumber = uint16(buf[2])<<8 | uint16(buf[3])
+	h.FragmentOffset = uint32(buf[5])<<16 | uint32(buf[6])<<8 | uint32(buf[7])
+
+	if h.Type == 3 {
+		return fmt.Errorf("invalid type: %d", h.Type)
+	}
+
+	return nil
+}
+
+func (h header) marshalTo(buf []byte) {
+	buf[0] = h.Type<<6 | h.MainHeaderFlag<<4 | h.MainHeaderID<<1
+	if h.TileNumberInvalid {
+		buf[0] |= 0x01
+	}
+	buf[1] = h.Priority
+	buf[2] = byte(h.TileNumber >> 8)
+	buf[3] = byte(h.TileNumber)
+	buf[4] = 0
+	buf[5] = byte(h.FragmentOffset >> 16)
+	buf[6] = byte(h.FragmentOffset >> 8)
+	buf[7] = byte(h.FragmentOffset)
+}
+
+type tilePart struct {
+	start      int
+	end        int
+	tileNumber uint16
+}
+
+// mainHeaderSize returns the size of the main header of a codestream,
+// that starts with the SOC marker and ends before the first SOT marker.
+func mainHeaderSize(codestream []byte) (int, error) {
+	if len(codestream) < 2 || (uint16(codestream[0])<<8|uint16(codestream[1])) != markerSOC {
+		return 0, fmt.Errorf("SOC marker not found")
+	}
+
+	pos := 2
+
+	for {
+		if (len(codestream) - pos) < 4 {
+			return 0, fmt.Errorf("SOT marker not found")
+		}
+
+		marker := uint16(codestream[pos])<<8 | uint16(codestream[pos+1])
+		if marker == markerSOT {
+			return pos, nil
+		}
+
+		if (marker & 0xFF00) != 0xFF00 {
+			return 0, fmt.Errorf("invalid marker: %x", marker)
+		}
+
+		le := int(uint16(codestream[pos+2])<<8 | uint16(codestream[pos+3]))
+		if le < 2 {
+			return 0, fmt.Errorf("invalid marker segment length: %d", le)
+		}
+
+		pos += 2 + le
+	}
+}
+
+// splitTileParts returns the tile-parts of a codestream.
+// The EOC marker is included in the last tile-part.
+func splitTileParts(codestream []byte, pos int) ([]tilePart, error) {
+	var ret []tilePart
+
+	for {
+		if len(codestream) == pos {
+			return nil, fmt.Errorf("EOC marker not found")
+		}
+
+		if (len(codestream)-pos) == 2 &&
+			(uint16(codestream[pos])<<8|uint16(codestream[pos+1])) == markerEOC {
+			ret[len(ret)-1].end = len(codestream)
+			return ret, nil
+		}
+
+		if (len(codestream) - pos) < 12 {
+			return nil, fmt.Errorf("SOT marker not found")
+		}
+
+		if (uint16(codestream[pos])<<8 | uint16(codestream[pos+1])) != markerSOT {
+			return nil, fmt.Errorf("SOT marker not found")
+		}
+
+		tileNumber := uint16(codestream[pos+4])<<8 | uint16(codestream[pos+5])
+		le := int(uint32(codestream[pos+6])<<24 | uint32(codestream[pos+7])<<16 |
+			uint32(codestream[pos+8])<<8 | uint32(codestream[pos+9]))
+
+		// a length of zero means that the tile-part lasts until the EOC marker
+		if le == 0 {
+			ret = append(ret, tilePart{
+				start:      pos,
+				end:        len(codestream),
+				tileNumber: tileNumber,
+			})
+			return ret, nil
+		}
+
+		if le < 12 || le > (len(codestream)-pos) {
+			return nil, fmt.Errorf("invalid tile-part length: %d", le)
+		}
+
+		ret = append(ret, tilePart{
+			start:      pos,
+			end:        pos + le,
+			tileNumber: tileNumber,
+		})
+		pos += le
+	}
+}
@@ -0,0 +1,121 @@
+package rtpjpegxs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented codestream and we didn't receive anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+func joinFragments(fragments [][]byte, size int) []byte {
+	ret := make([]byte, size)
+	n := 0
+	for _, p := range fragments {
+		n += copy(ret[n:], p)
+	}
+	return ret
+}
+
+// Decoder is a RTP/JPEG XS decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc9134
+type Decoder struct {
+	fragments          [][]byte
+	fragmentsSize      int
+	fragmentNextSeqNum uint16
+	fragmentsTimestamp uint32
+	fragmentsHeader    header
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// nextCounters returns the counters that the packet following h must have.
+func nextCounters(h header) (uint16, uint16) {
+	if h.PacketizationMode == PacketizationModeCodestream {
+		v := (uint32(h.SEPCounter)<<11 | uint32(h.PacketCounter)) + 1
+		return uint16((v >> 11) & maxCounter), uint16(v & maxCounter)
+	}
+
+	if h.Last {
+		return (h.SEPCounter + 1) & maxCounter, 0
+	}
+	return h.SEPCounter, h.PacketCounter + 1
+}
+
+// Decode decodes a codestream from a RTP packet.
+// Both the codestream and the slice packetization modes are supported.
+// In case of interlaced video, each field is returned as a separate codestream.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	var h header
+	err := h.unmarshal(pkt.Payload)
+	if err != nil {
+		d.resetFragments()
+		return nil, err
+	}
+
+	payload := pkt.Payload[headerSize:]
+
+	if d.fragmentsSize == 0 {
+		if h.SEPCounter != 0 || h.PacketCounter != 0 {
+			return nil, ErrNonStartingPacketAndNoPrevious
+		}
+	} else {
+		if pkt.SequenceNumber != d.fragmentNextSeqNum || pkt.Timestamp != d.fragmentsTimestamp {
+			d.resetFragments()
+			return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+		}
+
+		if h.PacketizationMode != d.fragmentsHeader.PacketizationMode ||
+			h.FrameCounter != d.fragmentsHeader.FrameCounter {
+			d.resetFragments()
+			return nil, fmt.Errorf("packet belongs to a different frame")
+		}
+
+		sep, p := nextCounters(d.fragmentsHeader)
+		if h.SEPCounter != sep || h.PacketCounter != p {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid packet counters: %d %d, expected %d %d",
+				h.SEPCounter, h.PacketCounter, sep, p)
+		}
+	}
+
+	d.fragmentsSize += len(payload)
+
+	if d.fragmentsSize > maxCodestreamSize {
+		errSize := d.fragmentsSize
+		d.resetFragments()
+		return nil, fmt.Errorf("codestream size (%d) is too big, maximum is %d",
+			errSize, maxCodestreamSize)
+	}
+
+	d.fragments = append(d.fragments, payload)
+	d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+	d.fragmentsTimestamp = pkt.Timestamp
+	d.fragmentsHeader = h
+
+	if !pkt.Marker {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	codestream := joinFragments(d.fragments, d.fragmentsSize)
+	d.resetFragments()
+
+	return codestream, nil
+}
@@ -0,0 +1,175 @@
+package rtpjpegxs
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+func packetCount(avail, le int) int {
+	n := le / avail
+	if (le % avail) != 0 {
+		n++
+	}
+	return n
+}
+
+// Encoder is a RTP/JPEG XS encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc9134
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// packetization mode (optional).
+	// It defaults to PacketizationModeCodestream.
+	PacketizationMode PacketizationMode
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	frameCounter   uint8
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.PacketizationMode > PacketizationModeSlice {
+		return fmt.Errorf("PacketizationMode > 1 is not supported")
+	}
+
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	if e.PayloadMaxSize <= headerSize {
+		return fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes a codestream into RTP packets.
+// In the codestream packetization mode, the whole codestream is a single
+// packetization unit; in the slice packetization mode, the header segment
+// and each slice are separate packetization units.
+func (e *Encoder) Encode(codestream []byte) ([]*rtp.Packet, error) {
+	if len(codestream) > maxCodestreamSize {
+		return nil, fmt.Errorf("codestream size (%d) is too big, maximum is %d",
+			len(codestream), maxCodestreamSize)
+	}
+
+	err := checkCodestream(codestream)
+	if err != nil {
+		return nil, err
+	}
+
+	var units [][]byte
+
+	if e.PacketizationMode == PacketizationModeSlice {
+		units, err = splitSlices(codestream)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(units) > (maxCounter + 1) {
+			return nil, fmt.Errorf("too many slices")
+		}
+	} else {
+		units = [][]byte{codestream}
+	}
+
+	avail := e.PayloadMaxSize - headerSize
+	var ret []*rtp.Packet
+
+	for i, unit := range units {
+		packetCount := packetCount(avail, len(unit))
+
+		if e.PacketizationMode == PacketizationModeSlice && packetCount > (maxCounter+1) {
+			return nil, fmt.Errorf("slice is too big")
+		}
+
+		if packetCount > (maxCounter+1)*(maxCounter+1) {
+			return nil, fmt.Errorf("codestream is too big")
+		}
+
+		for j := range packetCount {
+			le := min(avail, len(unit)-j*avail)
+
+			h := header{
+				Sequential:        true,
+				PacketizationMode: e.PacketizationMode,
+				Last:              (j == packetCount-1),
+				FrameCounter:      e.frameCounter,
+			}
+
+			if e.PacketizationMode == PacketizationModeSlice {
+				h.SEPCounter = uint16(i)
+				h.PacketCounter = uint16(j)
+			} else {
+				h.SEPCounter = uint16(j >> 11)
+				h.PacketCounter = uint16(j & maxCounter)
+			}
+
+			payload := make([]byte, headerSize+le)
+			h.marshalTo(payload)
+			copy(payload[headerSize:], unit[j*avail:])
+
+			ret = append(ret, &rtp.Packet{
+				Header: rtp.Header{
+					Version:        rtpVersion,
+					PayloadType:    e.PayloadType,
+					SequenceNumber: e.sequenceNumber,
+					SSRC:           *e.SSRC,
+				},
+				Payload: payload,
+			})
+
+			e.sequenceNumber++
+		}
+	}
+
+	ret[len(ret)-1].Marker = true
+	e.frameCounter = (e.frameCounter + 1) & 0x1F
+
+	return ret, nil
+}
@@ -0,0 +1,195 @@
+package rtpjpegxs
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var testCodestream = []byte{
+	0xff, 0x10, // SOC
+	0xff, 0x50, 0x00, 0x02, // CAP
+	0xff, 0x12, 0x00, 0x04, 0xaa, 0xbb, // PIH
+	0xff, 0x20, 0x00, 0x04, 0x00, 0x00, // SLH
+	0x01, 0x02, 0x03, 0x04,
+	0xff, 0x20, 0x00, 0x04, 0x00, 0x01, // SLH
+	0x05, 0x06,
+	0xff, 0x11, // EOC
+}
+
+func testPacket(seqNum uint16, marker bool, payload []byte) *rtp.Packet {
+	return &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         marker,
+			PayloadType:    96,
+			SequenceNumber: seqNum,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: payload,
+	}
+}
+
+var cases = []struct {
+	name              string
+	packetizationMode PacketizationMode
+	payloadMaxSize    int
+	codestream        []byte
+	pkts              []*rtp.Packet
+}{
+	{
+		"codestream",
+		PacketizationModeCodestream,
+		0,
+		testCodestream,
+		[]*rtp.Packet{
+			testPacket(17645, true, mergeBytes(
+				[]byte{0xa0, 0x00, 0x00, 0x00},
+				testCodestream,
+			)),
+		},
+	},
+	{
+		"codestream fragmented",
+		PacketizationModeCodestream,
+		16,
+		testCodestream,
+		[]*rtp.Packet{
+			testPacket(17645, false, mergeBytes(
+				[]byte{0x80, 0x00, 0x00, 0x00},
+				testCodestream[0:12],
+			)),
+			testPacket(17646, false, mergeBytes(
+				[]byte{0x80, 0x00, 0x00, 0x01},
+				testCodestream[12:24],
+			)),
+			testPacket(17647, true, mergeBytes(
+				[]byte{0xa0, 0x00, 0x00, 0x02},
+				testCodestream[24:32],
+			)),
+		},
+	},
+	{
+		"slice",
+		PacketizationModeSlice,
+		0,
+		testCodestream,
+		[]*rtp.Packet{
+			testPacket(17645, false, mergeBytes(
+				[]byte{0xe0, 0x00, 0x00, 0x00},
+				testCodestream[0:12],
+			)),
+			testPacket(17646, false, mergeBytes(
+				[]byte{0xe0, 0x00, 0x08, 0x00},
+				testCodestream[12:22],
+			)),
+			testPacket(17647, true, mergeBytes(
+				[]byte{0xe0, 0x00, 0x10, 0x00},
+				testCodestream[22:32],
+			)),
+		},
+	},
+	{
+		"slice fragmented",
+		PacketizationModeSlice,
+		12,
+		testCodestream,
+		[]*rtp.Packet{
+			testPacket(17645, false, mergeBytes(
+				[]byte{0xc0, 0x00, 0x00, 0x00},
+				testCodestream[0:8],
+			)),
+			testPacket(17646, false, mergeBytes(
+				[]byte{0xe0, 0x00, 0x00, 0x01},
+				testCodestream[8:12],
+			)),
+			testPacket(17647, false, mergeBytes(
+				[]byte{0xc0, 0x00, 0x08, 0x00},
+				testCodestream[12:20],
+			)),
+			testPacket(17648, false, mergeBytes(
+				[]byte{0xe0, 0x00, 0x08, 0x01},
+				testCodestream[20:22],
+			)),
+			testPacket(17649, false, mergeBytes(
+				[]byte{0xc0, 0x00, 0x10, 0x00},
+				testCodestream[22:30],
+			)),
+			testPacket(17650, true, mergeBytes(
+				[]byte{0xe0, 0x00, 0x10, 0x01},
+				testCodestream[30:32],
+			)),
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				PacketizationMode:     ca.packetizationMode,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				PayloadMaxSize:        ca.payloadMaxSize,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.codestream)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeFrameCounter(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(testCodestream)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xa0, 0x00, 0x00, 0x00}, pkts[0].Payload[:4])
+
+	pkts, err = e.Encode(testCodestream)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xa0, 0x40, 0x00, 0x00}, pkts[0].Payload[:4])
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
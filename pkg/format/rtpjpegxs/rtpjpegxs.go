@@ -0,0 +1,160 @@
+// Package rtpjpegxs contains a RTP/JPEG XS decoder and encoder.
+package rtpjpegxs
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	// maximum size of a codestream.
+	maxCodestreamSize = 16 * 1024 * 1024
+
+	headerSize = 4
+
+	maxCounter = 0x7FF
+)
+
+const (
+	markerSOC = 0xFF10
+	markerEOC = 0xFF11
+	markerSLH = 0xFF20
+)
+
+// PacketizationMode is a packetization mode.
+type PacketizationMode int
+
+// packetization modes.
+const (
+	PacketizationModeCodestream PacketizationMode = 0
+	PacketizationModeSlice      PacketizationMode = 1
+)
+
+type header struct {
+	Sequential        bool
+	PacketizationMode PacketizationMode
+	Last              bool
+	Interlaced        uint8
+	FrameCounter      uint8
+	SEPCounter        uint16
+	PacketCounter     uint16
+}
+
+func (h *header) unmarshal(buf []byte) error {
+	if len(buf) < headerSize {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	v := uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+
+	h.Sequential = (v >> 31) != 0
+	h.PacketizationMode = PacketizationMode((v >> 30) & 0x01)
+	h.Last = ((v >> 29) & 0x01) != 0
+	h.Interlaced = uint8((v >> 27) & 0x03)
+	h.FrameCounter = uint8((v >> 22) & 0x1F)
+	h.SEPCounter = uint16((v >> 11) & maxCounter)
+	h.PacketCounter = uint16(v & maxCounter)
+
+	if h.Interlaced == 1 {
+		return fmt.Errorf("invalid interlaced information: %d", h.Interlaced)
+	}
+
+	return nil
+}
+
+func (h header) marshalTo(buf []byte) {
+	v := uint32(h.PacketizationMode)<<30 |
+		uint32(h.Interlaced)<<27 |
+		uint32(h.FrameCounter&0x1F)<<22 |
+		uint32(h.SEPCounter&maxCounter)<<11 |
+		uint32(h.PacketCounter&maxCounter)
+
+	if h.Sequential {
+		v |= 1 << 31
+	}
+	if h.Last {
+		v |= 1 << 29
+	}
+
+	buf[0] = byte(v >> 24)
+	buf[1] = byte(v >> 16)
+	buf[2] = byte(v >> 8)
+	buf[3] = byte(v)
+}
+
+func readMarker(buf []byte, pos int) uint16 {
+	return uint16(buf[pos])<<8 | uint16(buf[pos+1])
+}
+
+// checkCodestream checks that a codestream starts with SOC and ends with EOC.
+func checkCodestream(codestream []byte) error {
+	if len(codestream) < 4 || readMarker(codestream, 0) != markerSOC {
+		return fmt.Errorf("SOC marker not found")
+	}
+
+	if readMarker(codestream, len(codestream)-2) != markerEOC {
+		return fmt.Errorf("EOC marker not found")
+	}
+
+	return nil
+}
+
+// splitSlices splits a codestream into the header segment and slices.
+// The EOC marker is included in the last slice.
+// Since precincts are not parsed, the end of a slice is found by
+// looking for the header of the following slice.
+func splitSlices(codestream []byte) ([][]byte, error) {
+	pos := 2
+
+	for {
+		if (len(codestream) - pos) < 4 {
+			return nil, fmt.Errorf("SLH marker not found")
+		}
+
+		marker := readMarker(codestream, pos)
+		if marker == markerSLH {
+			break
+		}
+
+		if (marker & 0xFF00) != 0xFF00 {
+			return nil, fmt.Errorf("invalid marker: %x", marker)
+		}
+
+		le := int(readMarker(codestream, pos+2))
+		if le < 2 {
+			return nil, fmt.Errorf("invalid marker segment length: %d", le)
+		}
+
+		pos += 2 + le
+	}
+
+	ret := [][]byte{codestream[:pos]}
+	sliceIndex := uint16(0)
+
+	for {
+		if (len(codestream)-pos) < 6 || readMarker(codestream, pos+2) != 4 {
+			return nil, fmt.Errorf("invalid slice header")
+		}
+
+		if readMarker(codestream, pos+4) != sliceIndex {
+			return nil, fmt.Errorf("invalid slice index: %d, expected %d",
+				readMarker(codestream, pos+4), sliceIndex)
+		}
+
+		sliceIndex++
+
+		next := []byte{
+			byte(markerSLH >> 8), byte(markerSLH & 0xFF), 0x00, 0x04,
+			byte(sliceIndex >> 8), byte(sliceIndex),
+		}
+
+		n := bytes.Index(codestream[pos+6:], next)
+		if n < 0 {
+			ret = append(ret, codestream[pos:])
+			return ret, nil
+		}
+
+		ret = append(ret, codestream[pos:pos+6+n])
+		pos += 6 + n
+	}
+}
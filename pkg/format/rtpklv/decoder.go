@@ -0,0 +1,96 @@
+package rtpklv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented KLV unit and we didn't receive anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+func joinFragments(fragments [][]byte, size int) []byte {
+	ret := make([]byte, size)
+	n := 0
+	for _, p := range fragments {
+		n += copy(ret[n:], p)
+	}
+	return ret
+}
+
+// Decoder is a RTP/KLV decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6597
+type Decoder struct {
+	fragments          [][]byte
+	fragmentsSize      int
+	fragmentNextSeqNum uint16
+	fragmentsTimestamp uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes KLV sets from a RTP packet.
+// A KLV unit can be split into multiple packets that share the same timestamp;
+// the last one has the marker bit set.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	if d.fragmentsSize != 0 {
+		if pkt.SequenceNumber == d.fragmentNextSeqNum && pkt.Timestamp == d.fragmentsTimestamp {
+			d.fragmentsSize += len(pkt.Payload)
+
+			if d.fragmentsSize > maxUnitSize {
+				errSize := d.fragmentsSize
+				d.resetFragments()
+				return nil, fmt.Errorf("KLV unit size (%d) is too big, maximum is %d",
+					errSize, maxUnitSize)
+			}
+
+			d.fragments = append(d.fragments, pkt.Payload)
+			d.fragmentNextSeqNum++
+
+			if !pkt.Marker {
+				return nil, ErrMorePacketsNeeded
+			}
+
+			unit := joinFragments(d.fragments, d.fragmentsSize)
+			d.resetFragments()
+			return splitUnit(unit)
+		}
+
+		d.resetFragments()
+
+		// the packet may be the start of the next unit
+		if !bytes.HasPrefix(pkt.Payload, universalLabelPrefix) {
+			return nil, fmt.Errorf("discarding KLV unit since a RTP packet is missing")
+		}
+	} else if !bytes.HasPrefix(pkt.Payload, universalLabelPrefix) {
+		// the start of a unit can't be signaled in any other way
+		return nil, ErrNonStartingPacketAndNoPrevious
+	}
+
+	if pkt.Marker {
+		return splitUnit(pkt.Payload)
+	}
+
+	d.fragmentsSize = len(pkt.Payload)
+	d.fragments = append(d.fragments, pkt.Payload)
+	d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+	d.fragmentsTimestamp = pkt.Timestamp
+	return nil, ErrMorePacketsNeeded
+}
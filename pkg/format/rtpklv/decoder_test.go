@@ -0,0 +1,126 @@
+package rtpklv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var sets [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				sets, err = d.Decode(pkt)
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+			}
+
+			require.Equal(t, ca.sets, sets)
+		})
+	}
+}
+
+func TestDecodeNonStartingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Marker: true,
+		},
+		Payload: []byte{0x01, 0x02, 0x03, 0x04},
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func TestDecodeMissingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[2].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	pkt := cases[2].pkts[1].Clone()
+	pkt.SequenceNumber++
+
+	_, err = d.Decode(pkt)
+	require.EqualError(t, err, "discarding KLV unit since a RTP packet is missing")
+}
+
+func TestDecodeMissingPacketAndNewUnit(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[2].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	pkt := cases[0].pkts[0].Clone()
+	pkt.SequenceNumber = cases[2].pkts[0].SequenceNumber + 2
+	pkt.Timestamp = cases[2].pkts[0].Timestamp + 3000
+
+	sets, err := d.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, cases[0].sets, sets)
+
+	// a fragmented unit is collected again
+	for i, pkt := range cases[2].pkts {
+		pkt = pkt.Clone()
+		pkt.SequenceNumber += 10
+
+		sets, err = d.Decode(pkt)
+		if i != len(cases[2].pkts)-1 {
+			require.Equal(t, ErrMorePacketsNeeded, err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, cases[2].sets, sets)
+		}
+	}
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, am bool, b []byte, bm bool) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		sets, err := d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Marker:         am,
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+		if err == nil && len(sets) == 0 {
+			t.Errorf("should not happen")
+		}
+
+		sets, err = d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Marker:         bm,
+				SequenceNumber: 17646,
+			},
+			Payload: b,
+		})
+		if err == nil && len(sets) == 0 {
+			t.Errorf("should not happen")
+		}
+	})
+}
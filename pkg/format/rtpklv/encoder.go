@@ -0,0 +1,136 @@
+package rtpklv
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+func packetCount(avail, le int) int {
+	n := le / avail
+	if (le % avail) != 0 {
+		n++
+	}
+	return n
+}
+
+// Encoder is a RTP/KLV encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6597
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes KLV sets into RTP packets.
+// Sets are grouped into a single KLV unit, that is split into packets if needed.
+func (e *Encoder) Encode(sets [][]byte) ([]*rtp.Packet, error) {
+	unitSize := 0
+
+	for _, set := range sets {
+		n, err := setSize(set)
+		if err != nil {
+			return nil, err
+		}
+
+		if n != len(set) {
+			return nil, fmt.Errorf("KLV set has trailing data")
+		}
+
+		unitSize += n
+	}
+
+	if unitSize == 0 {
+		return nil, fmt.Errorf("no KLV sets provided")
+	}
+
+	if unitSize > maxUnitSize {
+		return nil, fmt.Errorf("KLV unit size (%d) is too big, maximum is %d",
+			unitSize, maxUnitSize)
+	}
+
+	unit := joinFragments(sets, unitSize)
+
+	avail := e.PayloadMaxSize
+	le := len(unit)
+	packetCount := packetCount(avail, le)
+
+	ret := make([]*rtp.Packet, packetCount)
+	pos := 0
+	le = avail
+
+	for i := range ret {
+		if i == (packetCount - 1) {
+			le = len(unit[pos:])
+		}
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				SSRC:           *e.SSRC,
+				Marker:         (i == packetCount-1),
+			},
+			Payload: unit[pos : pos+le],
+		}
+
+		pos += le
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,165 @@
+package rtpklv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var testKey = []byte{
+	0x06, 0x0e, 0x2b, 0x34, 0x02, 0x0b, 0x01, 0x01,
+	0x0e, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00,
+}
+
+var cases = []struct {
+	name string
+	sets [][]byte
+	pkts []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{
+			mergeBytes(testKey, []byte{0x05, 0x01, 0x02, 0x03, 0x04, 0x05}),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(testKey, []byte{0x05, 0x01, 0x02, 0x03, 0x04, 0x05}),
+			},
+		},
+	},
+	{
+		"multiple sets",
+		[][]byte{
+			mergeBytes(testKey, []byte{0x02, 0x01, 0x02}),
+			mergeBytes(testKey, []byte{0x81, 0x90}, bytes.Repeat([]byte{0x01}, 144)),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					testKey, []byte{0x02, 0x01, 0x02},
+					testKey, []byte{0x81, 0x90}, bytes.Repeat([]byte{0x01}, 144),
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{
+			mergeBytes(testKey, []byte{0x82, 0x07, 0xd0}, bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 500)),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					testKey, []byte{0x82, 0x07, 0xd0},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 360),
+					[]byte{0x01},
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x02, 0x03, 0x04},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 139),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.sets)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode(nil)
+	require.EqualError(t, err, "no KLV sets provided")
+
+	_, err = e.Encode([][]byte{{0x01, 0x02}})
+	require.EqualError(t, err, "KLV set is too short")
+
+	_, err = e.Encode([][]byte{mergeBytes(testKey, []byte{0x01, 0x01, 0x02})})
+	require.EqualError(t, err, "KLV set has trailing data")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,73 @@
+// Package rtpklv contains a RTP/KLV decoder and encoder.
+package rtpklv
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	// maximum size of a KLV unit.
+	maxUnitSize = 1 * 1024 * 1024
+
+	keySize = 16
+)
+
+// every SMPTE Universal Label starts with this prefix.
+var universalLabelPrefix = []byte{0x06, 0x0E, 0x2B, 0x34}
+
+// setSize returns the size of the KLV set at the beginning of buf.
+func setSize(buf []byte) (int, error) {
+	if len(buf) < (keySize + 1) {
+		return 0, fmt.Errorf("KLV set is too short")
+	}
+
+	if !bytes.Equal(buf[:len(universalLabelPrefix)], universalLabelPrefix) {
+		return 0, fmt.Errorf("KLV set doesn't start with a Universal Label")
+	}
+
+	pos := keySize
+	length := int(buf[pos])
+	pos++
+
+	// BER long form
+	if (length & 0x80) != 0 {
+		n := length & 0x7F
+		if n == 0 || n > 4 {
+			return 0, fmt.Errorf("unsupported BER length size (%d)", n)
+		}
+
+		if len(buf[pos:]) < n {
+			return 0, fmt.Errorf("KLV set is too short")
+		}
+
+		length = 0
+		for i := 0; i < n; i++ {
+			length = length<<8 | int(buf[pos])
+			pos++
+		}
+	}
+
+	if length > (len(buf) - pos) {
+		return 0, fmt.Errorf("KLV set is too short")
+	}
+
+	return pos + length, nil
+}
+
+// splitUnit splits a KLV unit into KLV sets.
+func splitUnit(buf []byte) ([][]byte, error) {
+	var sets [][]byte
+
+	for len(buf) != 0 {
+		n, err := setSize(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, buf[:n])
+		buf = buf[n:]
+	}
+
+	return sets, nil
+}
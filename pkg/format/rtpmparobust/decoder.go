@@ -0,0 +1,262 @@
+package rtpmparobust
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented ADU and we didn't received anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// InterleavingCycle is an interleaving cycle decoded by DecodeInterleaved().
+type InterleavingCycle struct {
+	// RTP timestamp of the first ADU of the cycle.
+	Timestamp uint32
+
+	// ADUs of the cycle, ordered by interleaving index.
+	// Missing ADUs are nil.
+	ADUs [][]byte
+}
+
+// Decoder is a RTP/MPEG-1/2 Audio robust (ADU) decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5219
+type Decoder struct {
+	firstPacketReceived bool
+	fragment            []byte
+	fragmentExpected    int
+	fragmentNextSeqNum  uint16
+
+	cycleStarted      bool
+	cycleCount        uint8
+	cycleTimestamp    uint32
+	cycleTimestampSet bool
+	cycleADUs         [][]byte
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+func (d *Decoder) resetFragment() {
+	d.fragment = nil
+	d.fragmentExpected = 0
+}
+
+// decodeADUs returns the ADUs completed by a packet.
+// The first returned ADU always has the timestamp of the packet.
+func (d *Decoder) decodeADUs(pkt *rtp.Packet) ([][]byte, error) {
+	buf := pkt.Payload
+	var adus [][]byte
+
+	if len(buf) == 0 {
+		d.resetFragment()
+		return nil, fmt.Errorf("payload is empty")
+	}
+
+	for len(buf) != 0 {
+		var desc descriptor
+		n, err := desc.unmarshal(buf)
+		if err != nil {
+			d.resetFragment()
+			return nil, err
+		}
+		buf = buf[n:]
+
+		if desc.Size == 0 {
+			d.resetFragment()
+			return nil, fmt.Errorf("invalid ADU size: 0")
+		}
+
+		if desc.Continuation {
+			if d.fragment == nil || len(adus) != 0 {
+				if !d.firstPacketReceived {
+					return nil, ErrNonStartingPacketAndNoPrevious
+				}
+
+				d.resetFragment()
+				return nil, fmt.Errorf("received a subsequent fragment without previous fragments")
+			}
+
+			if pkt.SequenceNumber != d.fragmentNextSeqNum {
+				d.resetFragment()
+				return nil, fmt.Errorf("discarding ADU since a RTP packet is missing")
+			}
+
+			if desc.Size != (len(d.fragment) + d.fragmentExpected) {
+				d.resetFragment()
+				return nil, fmt.Errorf("invalid ADU size: %d", desc.Size)
+			}
+
+			le := min(len(buf), d.fragmentExpected)
+			d.fragment = append(d.fragment, buf[:le]...)
+			d.fragmentExpected -= le
+			d.fragmentNextSeqNum++
+			buf = buf[le:]
+
+			if d.fragmentExpected == 0 {
+				adus = append(adus, d.fragment)
+				d.resetFragment()
+			}
+
+			continue
+		}
+
+		d.resetFragment()
+		d.firstPacketReceived = true
+
+		// ADU is fragmented
+		if desc.Size > len(buf) {
+			d.fragment = append([]byte(nil), buf...)
+			d.fragmentExpected = desc.Size - len(buf)
+			d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+			break
+		}
+
+		adus = append(adus, buf[:desc.Size])
+		buf = buf[desc.Size:]
+	}
+
+	for _, adu := range adus {
+		if len(adu) < 4 {
+			return nil, fmt.Errorf("ADU is too short")
+		}
+	}
+
+	return adus, nil
+}
+
+// Decode decodes ADUs from a RTP packet.
+// It can be used with non-interleaved streams only.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	adus, err := d.decodeADUs(pkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(adus) == 0 {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	for _, adu := range adus {
+		if isInterleaved(adu) {
+			return nil, fmt.Errorf("ADUs are interleaved, use DecodeInterleaved()")
+		}
+	}
+
+	return adus, nil
+}
+
+func (d *Decoder) flushCycle() (*InterleavingCycle, error) {
+	c := &InterleavingCycle{
+		Timestamp: d.cycleTimestamp,
+		ADUs:      d.cycleADUs,
+	}
+	timestampSet := d.cycleTimestampSet
+
+	d.cycleStarted = false
+	d.cycleTimestampSet = false
+	d.cycleADUs = nil
+
+	if !timestampSet {
+		return nil, fmt.Errorf("unable to compute the timestamp of the interleaving cycle")
+	}
+
+	return c, nil
+}
+
+// DecodeInterleaved decodes interleaving cycles from a RTP packet.
+// ADUs are reordered by their interleaving index and returned when
+// an ADU of the following cycle is received.
+// Non-interleaved ADUs are returned immediately, in a cycle of their own.
+func (d *Decoder) DecodeInterleaved(pkt *rtp.Packet) ([]*InterleavingCycle, error) {
+	adus, err := d.decodeADUs(pkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(adus) == 0 {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	var ret []*InterleavingCycle
+
+	if !isInterleaved(adus[0]) {
+		for _, adu := range adus[1:] {
+			if isInterleaved(adu) {
+				return nil, fmt.Errorf("packet contains both interleaved and non-interleaved ADUs")
+			}
+		}
+
+		if d.cycleStarted {
+			c, err := d.flushCycle()
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, c)
+		}
+
+		ret = append(ret, &InterleavingCycle{
+			Timestamp: pkt.Timestamp,
+			ADUs:      adus,
+		})
+
+		return ret, nil
+	}
+
+	for i, adu := range adus {
+		if !isInterleaved(adu) {
+			return nil, fmt.Errorf("packet contains both interleaved and non-interleaved ADUs")
+		}
+
+		index := int(adu[0])
+		count := adu[1] >> 5
+
+		// restore the sync word
+		adu = append([]byte{0xFF, adu[1] | 0xE0}, adu[2:]...)
+
+		if d.cycleStarted && count != d.cycleCount {
+			c, err := d.flushCycle()
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, c)
+		}
+
+		if !d.cycleStarted {
+			d.cycleStarted = true
+			d.cycleCount = count
+		}
+
+		// the timestamp of the packet is the one of its first ADU
+		if i == 0 && !d.cycleTimestampSet {
+			samples, sampleRate, err := aduDuration(adu)
+			if err != nil {
+				return nil, err
+			}
+
+			d.cycleTimestamp = pkt.Timestamp - samplesToTimestamp(index*samples, sampleRate)
+			d.cycleTimestampSet = true
+		}
+
+		if index >= len(d.cycleADUs) {
+			d.cycleADUs = append(d.cycleADUs, make([][]byte, index+1-len(d.cycleADUs))...)
+		}
+		d.cycleADUs[index] = adu
+	}
+
+	if len(ret) == 0 {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,161 @@
+package rtpmparobust
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var adus [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addADUs, err := d.Decode(pkt)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+
+				require.NoError(t, err)
+				adus = append(adus, addADUs...)
+			}
+
+			require.Equal(t, ca.adus, adus)
+		})
+	}
+}
+
+func TestDecodeInterleaved(t *testing.T) {
+	adus := [][]byte{
+		testADU(40, 0x01),
+		testADU(40, 0x02),
+		testADU(40, 0x03),
+		testADU(40, 0x04),
+	}
+
+	e := &Encoder{
+		PayloadType:           96,
+		InterleavingCycleSize: 4,
+		PayloadMaxSize:        82,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts1, err := e.Encode(adus)
+	require.NoError(t, err)
+
+	pkts2, err := e.Encode(adus)
+	require.NoError(t, err)
+
+	for _, pkt := range pkts1 {
+		pkt.Timestamp += 1000
+	}
+
+	for _, pkt := range pkts2 {
+		pkt.Timestamp += 1000 + 4*2160
+	}
+
+	d := &Decoder{}
+	err = d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(pkts1[0])
+	require.EqualError(t, err, "ADUs are interleaved, use DecodeInterleaved()")
+
+	_, err = d.DecodeInterleaved(pkts1[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	// lose second packet of the first cycle
+
+	// second cycle starts with its second packet
+	cycles, err := d.DecodeInterleaved(pkts2[1])
+	require.NoError(t, err)
+	require.Equal(t, []*InterleavingCycle{{
+		Timestamp: 1000,
+		ADUs:      [][]byte{adus[0], nil, adus[2]},
+	}}, cycles)
+
+	_, err = d.DecodeInterleaved(pkts2[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	cycles, err = d.DecodeInterleaved(&rtp.Packet{
+		Header: rtp.Header{
+			SequenceNumber: 1000,
+		},
+		Payload: append([]byte{0x28}, adus[0]...),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*InterleavingCycle{
+		{
+			Timestamp: 1000 + 4*2160,
+			ADUs:      adus,
+		},
+		{
+			Timestamp: 0,
+			ADUs:      [][]byte{adus[0]},
+		},
+	}, cycles)
+}
+
+func TestDecodeErrorMissingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[3].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.Decode(cases[3].pkts[2])
+	require.EqualError(t, err, "discarding ADU since a RTP packet is missing")
+}
+
+func TestDecodeNonStartingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[3].pkts[1])
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte, c []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.DecodeInterleaved(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+
+		d.DecodeInterleaved(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				SequenceNumber: 17646,
+			},
+			Payload: b,
+		})
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				SequenceNumber: 17647,
+			},
+			Payload: c,
+		})
+	})
+}
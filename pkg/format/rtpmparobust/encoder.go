@@ -0,0 +1,234 @@
+package rtpmparobust
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+type encoderADU struct {
+	buf       []byte
+	timestamp uint32
+}
+
+func descriptorSize(adu []byte) int {
+	return descriptor{Size: len(adu)}.marshalSize()
+}
+
+// Encoder is a RTP/MPEG-1/2 Audio robust (ADU) encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5219
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// number of ADUs in each interleaving cycle (optional).
+	// When zero, ADUs are not interleaved.
+	InterleavingCycleSize int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	cycleCount     uint8
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.InterleavingCycleSize < 0 || e.InterleavingCycleSize > maxInterleavingCycleSize {
+		return fmt.Errorf("invalid InterleavingCycleSize: %d", e.InterleavingCycleSize)
+	}
+
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	if e.PayloadMaxSize <= 2 {
+		return fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// interleave reorders ADUs in order to spread adjacent ADUs over different packets,
+// and stores interleaving informations in place of their sync word.
+func (e *Encoder) interleave(adus []encoderADU) []encoderADU {
+	ret := make([]encoderADU, 0, len(adus))
+
+	for pos := 0; pos < len(adus); pos += e.InterleavingCycleSize {
+		cycle := adus[pos : pos+e.InterleavingCycleSize]
+
+		for start := range 2 {
+			for index := start; index < len(cycle); index += 2 {
+				buf := append([]byte(nil), cycle[index].buf...)
+				buf[0] = byte(index)
+				buf[1] = e.cycleCount<<5 | (buf[1] & 0x1F)
+
+				ret = append(ret, encoderADU{
+					buf:       buf,
+					timestamp: cycle[index].timestamp,
+				})
+			}
+		}
+
+		e.cycleCount = (e.cycleCount + 1) & 0x07
+	}
+
+	return ret
+}
+
+// Encode encodes ADUs into RTP packets.
+// When interleaving is enabled, the ADU count must be a multiple of InterleavingCycleSize.
+func (e *Encoder) Encode(adus [][]byte) ([]*rtp.Packet, error) {
+	if len(adus) == 0 {
+		return nil, fmt.Errorf("no ADUs provided")
+	}
+
+	if e.InterleavingCycleSize != 0 && (len(adus)%e.InterleavingCycleSize) != 0 {
+		return nil, fmt.Errorf("ADU count (%d) is not a multiple of InterleavingCycleSize (%d)",
+			len(adus), e.InterleavingCycleSize)
+	}
+
+	encADUs := make([]encoderADU, len(adus))
+	samples := 0
+
+	for i, adu := range adus {
+		if len(adu) > maxADUSize {
+			return nil, fmt.Errorf("ADU size (%d) is too big, maximum is %d", len(adu), maxADUSize)
+		}
+
+		n, sampleRate, err := aduDuration(adu)
+		if err != nil {
+			return nil, err
+		}
+
+		encADUs[i] = encoderADU{
+			buf:       adu,
+			timestamp: samplesToTimestamp(samples, sampleRate),
+		}
+		samples += n
+	}
+
+	if e.InterleavingCycleSize != 0 {
+		encADUs = e.interleave(encADUs)
+	}
+
+	var ret []*rtp.Packet
+	var batch []encoderADU
+	batchSize := 0
+
+	for _, adu := range encADUs {
+		le := descriptorSize(adu.buf) + len(adu.buf)
+
+		if batch != nil && (batchSize+le) > e.PayloadMaxSize {
+			ret = append(ret, e.writeAggregated(batch, batchSize))
+			batch = nil
+			batchSize = 0
+		}
+
+		if le > e.PayloadMaxSize {
+			ret = append(ret, e.writeFragmented(adu)...)
+			continue
+		}
+
+		batch = append(batch, adu)
+		batchSize += le
+	}
+
+	if batch != nil {
+		ret = append(ret, e.writeAggregated(batch, batchSize))
+	}
+
+	return ret, nil
+}
+
+func (e *Encoder) writePacket(timestamp uint32, payload []byte) *rtp.Packet {
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      timestamp,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
+
+func (e *Encoder) writeAggregated(adus []encoderADU, size int) *rtp.Packet {
+	payload := make([]byte, size)
+	n := 0
+
+	for _, adu := range adus {
+		n += descriptor{Size: len(adu.buf)}.marshalTo(payload[n:])
+		n += copy(payload[n:], adu.buf)
+	}
+
+	return e.writePacket(adus[0].timestamp, payload)
+}
+
+func (e *Encoder) writeFragmented(adu encoderADU) []*rtp.Packet {
+	var ret []*rtp.Packet
+	desc := descriptor{Size: len(adu.buf)}
+	avail := e.PayloadMaxSize - desc.marshalSize()
+	buf := adu.buf
+
+	for len(buf) != 0 {
+		le := min(avail, len(buf))
+
+		payload := make([]byte, desc.marshalSize()+le)
+		n := desc.marshalTo(payload)
+		copy(payload[n:], buf[:le])
+		buf = buf[le:]
+
+		ret = append(ret, e.writePacket(adu.timestamp, payload))
+
+		desc.Continuation = true
+	}
+
+	return ret
+}
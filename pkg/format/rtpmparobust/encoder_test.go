@@ -0,0 +1,285 @@
+package rtpmparobust
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+// testADU returns a MPEG-1 layer 3, 128kbit/s, 48khz ADU.
+func testADU(size int, fill byte) []byte {
+	buf := bytes.Repeat([]byte{fill}, size)
+	buf[0] = 0xff
+	buf[1] = 0xfb
+	buf[2] = 0x94
+	buf[3] = 0x64
+	return buf
+}
+
+var (
+	testSmall1 = testADU(40, 0x01)
+	testSmall2 = testADU(40, 0x02)
+	testMedium = testADU(100, 0x03)
+	testBig1   = testADU(1000, 0x04)
+	testBig2   = testADU(1000, 0x05)
+	testHuge   = testADU(3000, 0x06)
+)
+
+var cases = []struct {
+	name string
+	adus [][]byte
+	pkts []*rtp.Packet
+}{
+	{
+		"aggregated",
+		[][]byte{testSmall1, testSmall2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x28},
+					testSmall1,
+					[]byte{0x28},
+					testSmall2,
+				),
+			},
+		},
+	},
+	{
+		"two-byte descriptor",
+		[][]byte{testMedium},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x40, 0x64},
+					testMedium,
+				),
+			},
+		},
+	},
+	{
+		"multiple packets",
+		[][]byte{testBig1, testBig2},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x43, 0xe8},
+					testBig1,
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2160,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x43, 0xe8},
+					testBig2,
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{testHuge},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x4b, 0xb8},
+					testHuge[:1458],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xcb, 0xb8},
+					testHuge[1458:2916],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xcb, 0xb8},
+					testHuge[2916:],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.adus)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func interleavedADU(adu []byte, index byte, count byte) []byte {
+	buf := append([]byte(nil), adu...)
+	buf[0] = index
+	buf[1] = count<<5 | (buf[1] & 0x1f)
+	return buf
+}
+
+func TestEncodeInterleaved(t *testing.T) {
+	adus := [][]byte{
+		testADU(40, 0x01),
+		testADU(40, 0x02),
+		testADU(40, 0x03),
+		testADU(40, 0x04),
+	}
+
+	e := &Encoder{
+		PayloadType:           96,
+		InterleavingCycleSize: 4,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+		PayloadMaxSize:        82,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(adus)
+	require.NoError(t, err)
+	require.Equal(t, []*rtp.Packet{
+		{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: mergeBytes(
+				[]byte{0x28},
+				interleavedADU(adus[0], 0, 0),
+				[]byte{0x28},
+				interleavedADU(adus[2], 2, 0),
+			),
+		},
+		{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 17646,
+				Timestamp:      2160,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: mergeBytes(
+				[]byte{0x28},
+				interleavedADU(adus[1], 1, 0),
+				[]byte{0x28},
+				interleavedADU(adus[3], 3, 0),
+			),
+		},
+	}, pkts)
+
+	// input must not be modified
+	require.Equal(t, testADU(40, 0x01), adus[0])
+
+	pkts, err = e.Encode(adus)
+	require.NoError(t, err)
+	require.Equal(t, interleavedADU(adus[0], 0, 1), pkts[0].Payload[1:41])
+
+	_, err = e.Encode(adus[:3])
+	require.EqualError(t, err, "ADU count (3) is not a multiple of InterleavingCycleSize (4)")
+}
+
+func TestEncodeTimestamp44100(t *testing.T) {
+	adu := testADU(400, 0x01)
+	adu[2] = 0x90
+
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode([][]byte{adu, adu, adu, adu})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(pkts))
+	require.Equal(t, uint32(0), pkts[0].Timestamp)
+	require.Equal(t, uint32(7053), pkts[1].Timestamp)
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,98 @@
+// Package rtpmparobust contains a RTP/MPEG-1/2 Audio robust (ADU) decoder and encoder.
+package rtpmparobust
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1audio"
+)
+
+const (
+	clockRate = 90000
+
+	// maximum size of an ADU, limited by the size field of descriptors.
+	maxADUSize = 0x3FFF
+
+	// maximum number of ADUs in an interleaving cycle.
+	// An interleaving index of 255 combined with a cycle count of 7
+	// is indistinguishable from a sync word.
+	maxInterleavingCycleSize = 255
+)
+
+// descriptor is an ADU descriptor.
+type descriptor struct {
+	// whether the data following the descriptor is the continuation of a fragmented ADU.
+	Continuation bool
+
+	// size of the whole ADU.
+	Size int
+}
+
+func (d *descriptor) unmarshal(buf []byte) (int, error) {
+	if len(buf) < 1 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	d.Continuation = (buf[0] >> 7) != 0
+
+	if ((buf[0] >> 6) & 0x01) == 0 {
+		d.Size = int(buf[0] & 0x3F)
+		return 1, nil
+	}
+
+	if len(buf) < 2 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	d.Size = int(buf[0]&0x3F)<<8 | int(buf[1])
+	return 2, nil
+}
+
+func (d descriptor) marshalSize() int {
+	if d.Size > 0x3F {
+		return 2
+	}
+	return 1
+}
+
+func (d descriptor) marshalTo(buf []byte) int {
+	var b byte
+	if d.Continuation {
+		b = 1 << 7
+	}
+
+	if d.Size > 0x3F {
+		buf[0] = b | 1<<6 | byte(d.Size>>8)
+		buf[1] = byte(d.Size)
+		return 2
+	}
+
+	buf[0] = b | byte(d.Size)
+	return 1
+}
+
+// isInterleaved checks whether the sync word of an ADU
+// has been replaced with interleaving informations.
+func isInterleaved(adu []byte) bool {
+	return adu[0] != 0xFF || (adu[1]&0xE0) != 0xE0
+}
+
+// aduDuration returns the duration of an ADU, in samples, and its sample rate.
+func aduDuration(adu []byte) (int, int, error) {
+	var h mpeg1audio.FrameHeader
+	err := h.Unmarshal(adu)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if h.Layer != 3 {
+		return 0, 0, fmt.Errorf("ADUs can be used with MPEG layer 3 only")
+	}
+
+	return h.SampleCount(), h.SampleRate, nil
+}
+
+// samplesToTimestamp converts a sample count into a RTP timestamp.
+func samplesToTimestamp(samples int, sampleRate int) uint32 {
+	return uint32(int64(samples) * clockRate / int64(sampleRate))
+}
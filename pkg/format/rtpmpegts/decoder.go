@@ -0,0 +1,40 @@
+package rtpmpegts
+
+import (
+	"fmt"
+
+	"github.com/asticode/go-astits"
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/MPEG-TS decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Decoder struct{}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes TS packets from a RTP packet.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	le := len(pkt.Payload)
+	if le == 0 || (le%astits.MpegTsPacketSize) != 0 {
+		return nil, fmt.Errorf("payload size (%d) is not a multiple of %d", le, astits.MpegTsPacketSize)
+	}
+
+	n := le / astits.MpegTsPacketSize
+	tsPackets := make([][]byte, n)
+
+	for i := range tsPackets {
+		tsPacket := pkt.Payload[i*astits.MpegTsPacketSize : (i+1)*astits.MpegTsPacketSize]
+
+		if tsPacket[0] != syncByte {
+			return nil, fmt.Errorf("invalid sync byte")
+		}
+
+		tsPackets[i] = tsPacket
+	}
+
+	return tsPackets, nil
+}
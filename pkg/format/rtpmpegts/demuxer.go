@@ -0,0 +1,81 @@
+package rtpmpegts
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/asticode/go-astits"
+)
+
+// Demuxer feeds TS packets into an astits demuxer,
+// in order to extract tables and elementary streams.
+//
+// Since astits reads from a stream, the demuxer runs in a dedicated routine
+// and OnData is called from that routine.
+type Demuxer struct {
+	// called when the demuxer outputs data (PAT, PMT, PES packets).
+	OnData func(*astits.DemuxerData)
+
+	// called when the demuxer fails to decode data (optional).
+	// Invalid data is skipped and demuxing continues.
+	OnDecodeError func(error)
+
+	pr   *io.PipeReader
+	pw   *io.PipeWriter
+	done chan struct{}
+}
+
+// Init initializes the Demuxer.
+func (d *Demuxer) Init() error {
+	d.pr, d.pw = io.Pipe()
+	d.done = make(chan struct{})
+
+	go d.run()
+
+	return nil
+}
+
+// Close closes the Demuxer.
+// Data that is still buffered is flushed before returning.
+func (d *Demuxer) Close() {
+	d.pw.Close()
+	<-d.done
+}
+
+func (d *Demuxer) run() {
+	defer close(d.done)
+
+	dem := astits.NewDemuxer(context.Background(), d.pr,
+		astits.DemuxerOptPacketSize(astits.MpegTsPacketSize))
+
+	for {
+		data, err := dem.NextData()
+		if err != nil {
+			if errors.Is(err, astits.ErrNoMorePackets) || errors.Is(err, io.ErrClosedPipe) {
+				return
+			}
+
+			if d.OnDecodeError != nil {
+				d.OnDecodeError(err)
+			}
+			continue
+		}
+
+		if d.OnData != nil {
+			d.OnData(data)
+		}
+	}
+}
+
+// Write writes TS packets into the demuxer.
+func (d *Demuxer) Write(tsPackets [][]byte) error {
+	for _, tsPacket := range tsPackets {
+		_, err := d.pw.Write(tsPacket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package rtpmpegts
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/asticode/go-astits"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemuxer(t *testing.T) {
+	var buf bytes.Buffer
+
+	mux := astits.NewMuxer(context.Background(), &buf)
+
+	err := mux.AddElementaryStream(astits.PMTElementaryStream{
+		ElementaryPID: 256,
+		StreamType:    astits.StreamTypeH264Video,
+	})
+	require.NoError(t, err)
+
+	mux.SetPCRPID(256)
+
+	for i := 0; i < 2; i++ {
+		_, err = mux.WriteData(&astits.MuxerData{
+			PID: 256,
+			PES: &astits.PESData{
+				Header: &astits.PESHeader{
+					OptionalHeader: &astits.PESOptionalHeader{
+						MarkerBits:      2,
+						PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+						PTS:             &astits.ClockReference{Base: int64(i) * 3000},
+					},
+					StreamID: 224,
+				},
+				Data: bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x01, 0x09, 0xf0, byte(i)}, 100),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	var tsPackets [][]byte
+	for buf.Len() != 0 {
+		tsPackets = append(tsPackets, buf.Next(astits.MpegTsPacketSize))
+	}
+
+	e := &Encoder{}
+	err = e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(tsPackets)
+	require.NoError(t, err)
+
+	d := &Decoder{}
+	err = d.Init()
+	require.NoError(t, err)
+
+	var mutex sync.Mutex
+	var pesData [][]byte
+	var pmtReceived bool
+
+	dem := &Demuxer{
+		OnData: func(data *astits.DemuxerData) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if data.PMT != nil {
+				pmtReceived = true
+			}
+			if data.PES != nil {
+				pesData = append(pesData, data.PES.Data)
+			}
+		},
+	}
+	err = dem.Init()
+	require.NoError(t, err)
+
+	for _, pkt := range pkts {
+		var decoded [][]byte
+		decoded, err = d.Decode(pkt)
+		require.NoError(t, err)
+
+		err = dem.Write(decoded)
+		require.NoError(t, err)
+	}
+
+	dem.Close()
+
+	require.True(t, pmtReceived)
+	require.Equal(t, [][]byte{
+		bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x01, 0x09, 0xf0, 0}, 100),
+		bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x01, 0x09, 0xf0, 1}, 100),
+	}, pesData)
+}
+
+func TestDemuxerInvalidData(t *testing.T) {
+	var buf bytes.Buffer
+
+	mux := astits.NewMuxer(context.Background(), &buf)
+
+	err := mux.AddElementaryStream(astits.PMTElementaryStream{
+		ElementaryPID: 256,
+		StreamType:    astits.StreamTypeH264Video,
+	})
+	require.NoError(t, err)
+
+	mux.SetPCRPID(256)
+
+	_, err = mux.WriteData(&astits.MuxerData{
+		PID: 256,
+		PES: &astits.PESData{
+			Header: &astits.PESHeader{
+				OptionalHeader: &astits.PESOptionalHeader{
+					MarkerBits:      2,
+					PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+					PTS:             &astits.ClockReference{Base: 0},
+				},
+				StreamID: 224,
+			},
+			Data: []byte{0x00, 0x00, 0x00, 0x01, 0x09, 0xf0},
+		},
+	})
+	require.NoError(t, err)
+
+	var tsPackets [][]byte
+	for buf.Len() != 0 {
+		tsPackets = append(tsPackets, buf.Next(astits.MpegTsPacketSize))
+	}
+
+	// packet without sync byte
+	invalid := bytes.Repeat([]byte{0x01}, astits.MpegTsPacketSize)
+
+	var mutex sync.Mutex
+	var pesData [][]byte
+	var decodeErrors int
+
+	dem := &Demuxer{
+		OnData: func(data *astits.DemuxerData) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if data.PES != nil {
+				pesData = append(pesData, data.PES.Data)
+			}
+		},
+		OnDecodeError: func(error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			decodeErrors++
+		},
+	}
+	err = dem.Init()
+	require.NoError(t, err)
+
+	err = dem.Write([][]byte{invalid})
+	require.NoError(t, err)
+
+	err = dem.Write(tsPackets)
+	require.NoError(t, err)
+
+	dem.Close()
+
+	require.Equal(t, 1, decodeErrors)
+	require.Equal(t, [][]byte{{0x00, 0x00, 0x00, 0x01, 0x09, 0xf0}}, pesData)
+}
@@ -0,0 +1,119 @@
+package rtpmpegts
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/asticode/go-astits"
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	syncByte              = 0x47
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/MPEG-TS encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Encoder struct {
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It is rounded down to a multiple of 188.
+	// It defaults to 1460, that results in 7 TS packets per RTP packet.
+	PayloadMaxSize int
+
+	sequenceNumber  uint16
+	tsPacketsPerPkt int
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.tsPacketsPerPkt = e.PayloadMaxSize / astits.MpegTsPacketSize
+	if e.tsPacketsPerPkt == 0 {
+		return fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes TS packets into RTP packets.
+// Each RTP packet contains an integer number of TS packets.
+func (e *Encoder) Encode(tsPackets [][]byte) ([]*rtp.Packet, error) {
+	for _, tsPacket := range tsPackets {
+		if len(tsPacket) != astits.MpegTsPacketSize {
+			return nil, fmt.Errorf("invalid TS packet size (%d)", len(tsPacket))
+		}
+
+		if tsPacket[0] != syncByte {
+			return nil, fmt.Errorf("invalid sync byte")
+		}
+	}
+
+	var ret []*rtp.Packet
+
+	for len(tsPackets) != 0 {
+		n := e.tsPacketsPerPkt
+		if n > len(tsPackets) {
+			n = len(tsPackets)
+		}
+
+		payload := make([]byte, n*astits.MpegTsPacketSize)
+		pos := 0
+
+		for _, tsPacket := range tsPackets[:n] {
+			pos += copy(payload[pos:], tsPacket)
+		}
+
+		ret = append(ret, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    33,
+				SequenceNumber: e.sequenceNumber,
+				SSRC:           *e.SSRC,
+			},
+			Payload: payload,
+		})
+
+		e.sequenceNumber++
+		tsPackets = tsPackets[n:]
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,111 @@
+package rtpmpegts
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func tsPacket(pid byte, cc byte) []byte {
+	return append([]byte{0x47, 0x40, pid, 0x10 | cc}, bytes.Repeat([]byte{0xff}, 184)...)
+}
+
+var cases = []struct {
+	name      string
+	tsPackets [][]byte
+	pkts      []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{tsPacket(0x00, 0)},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    33,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: tsPacket(0x00, 0),
+			},
+		},
+	},
+	{
+		"aggregated",
+		[][]byte{
+			tsPacket(0x00, 0),
+			tsPacket(0x00, 1),
+			tsPacket(0x00, 2),
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    33,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: append(tsPacket(0x00, 0), tsPacket(0x00, 1)...),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    33,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: tsPacket(0x00, 2),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				PayloadMaxSize:        400,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.tsPackets)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	e := &Encoder{}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([][]byte{{0x47, 0x40, 0x00}})
+	require.EqualError(t, err, "invalid TS packet size (3)")
+
+	pkt := tsPacket(0x00, 0)
+	pkt[0] = 0x48
+	_, err = e.Encode([][]byte{pkt})
+	require.EqualError(t, err, "invalid sync byte")
+}
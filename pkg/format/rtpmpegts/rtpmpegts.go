@@ -0,0 +1,2 @@
+// Package rtpmpegts contains a RTP/MPEG-TS decoder and encoder.
+package rtpmpegts
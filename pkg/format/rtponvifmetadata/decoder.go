@@ -0,0 +1,127 @@
+package rtponvifmetadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented document and we didn't receive anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// isDocumentStart checks whether a payload begins with
+// a XML declaration or with a MetadataStream element.
+func isDocumentStart(payload []byte) bool {
+	payload = bytes.TrimPrefix(payload, []byte{0xEF, 0xBB, 0xBF}) // BOM
+	payload = bytes.TrimLeft(payload, " \t\r\n")
+
+	if bytes.HasPrefix(payload, []byte("<?xml")) {
+		return true
+	}
+
+	if !bytes.HasPrefix(payload, []byte("<")) {
+		return false
+	}
+
+	end := bytes.IndexAny(payload, " \t\r\n/>")
+	if end < 0 {
+		return false
+	}
+
+	return bytes.HasSuffix(payload[1:end], []byte("MetadataStream"))
+}
+
+func joinFragments(fragments [][]byte, size int) []byte {
+	ret := make([]byte, size)
+	n := 0
+	for _, p := range fragments {
+		n += copy(ret[n:], p)
+	}
+	return ret
+}
+
+// Decoder is a RTP/ONVIF metadata decoder.
+// Specification: ONVIF Streaming Specification, section 5.1.2.1.1
+type Decoder struct {
+	prevReceived       bool
+	prevMarker         bool
+	prevSeqNum         uint16
+	fragments          [][]byte
+	fragmentsSize      int
+	fragmentNextSeqNum uint16
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes a metadata document from a RTP packet.
+// A document can be split into multiple packets;
+// the last one has the marker bit set.
+// A document starts after a packet with the marker bit set, or with
+// a XML declaration or a MetadataStream element.
+// The document can be parsed with MetadataStream.Unmarshal().
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	afterMarker := d.prevReceived && d.prevMarker && pkt.SequenceNumber == (d.prevSeqNum+1)
+
+	d.prevReceived = true
+	d.prevMarker = pkt.Marker
+	d.prevSeqNum = pkt.SequenceNumber
+
+	if d.fragmentsSize != 0 {
+		if pkt.SequenceNumber == d.fragmentNextSeqNum {
+			d.fragmentsSize += len(pkt.Payload)
+
+			if d.fragmentsSize > maxDocumentSize {
+				errSize := d.fragmentsSize
+				d.resetFragments()
+				return nil, fmt.Errorf("document size (%d) is too big, maximum is %d",
+					errSize, maxDocumentSize)
+			}
+
+			d.fragments = append(d.fragments, pkt.Payload)
+			d.fragmentNextSeqNum++
+
+			if !pkt.Marker {
+				return nil, ErrMorePacketsNeeded
+			}
+
+			doc := joinFragments(d.fragments, d.fragmentsSize)
+			d.resetFragments()
+			return doc, nil
+		}
+
+		d.resetFragments()
+
+		// the packet may be the start of the next document
+		if !isDocumentStart(pkt.Payload) {
+			return nil, fmt.Errorf("discarding document since a RTP packet is missing")
+		}
+	} else if !afterMarker && !isDocumentStart(pkt.Payload) {
+		return nil, ErrNonStartingPacketAndNoPrevious
+	}
+
+	if pkt.Marker {
+		return pkt.Payload, nil
+	}
+
+	d.fragmentsSize = len(pkt.Payload)
+	d.fragments = append(d.fragments, pkt.Payload)
+	d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+	return nil, ErrMorePacketsNeeded
+}
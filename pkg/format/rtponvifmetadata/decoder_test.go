@@ -0,0 +1,113 @@
+package rtponvifmetadata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var doc []byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				doc, err = d.Decode(pkt)
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+			}
+
+			require.Equal(t, ca.doc, doc)
+		})
+	}
+}
+
+func TestDecodeMissingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[1].pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.Decode(cases[1].pkts[2])
+	require.EqualError(t, err, "discarding document since a RTP packet is missing")
+
+	// tail of a document after a loss
+	pkt := cases[1].pkts[2].Clone()
+	pkt.SequenceNumber += 10
+	_, err = d.Decode(pkt)
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+
+	// start of the next document after a loss
+	pkt = cases[1].pkts[0].Clone()
+	pkt.SequenceNumber += 20
+	_, err = d.Decode(pkt)
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	pkt = cases[1].pkts[0].Clone()
+	pkt.SequenceNumber += 22
+	_, err = d.Decode(pkt)
+	require.Equal(t, ErrMorePacketsNeeded, err)
+}
+
+func TestDecodeNonStartingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[1].pkts[1])
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+
+	_, err = d.Decode(cases[1].pkts[2])
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+
+	// a document starts after a packet with the marker bit set
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Marker:         true,
+			SequenceNumber: cases[1].pkts[2].SequenceNumber + 1,
+		},
+		Payload: []byte("<tt:MetadataStream/>"),
+	}
+	doc, err := d.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, []byte("<tt:MetadataStream/>"), doc)
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, am bool, b []byte, bm bool) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				Marker:         am,
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				Marker:         bm,
+				SequenceNumber: 17646,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,179 @@
+package rtponvifmetadata
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	namespaceTopics = "http://www.onvif.org/ver10/topics"
+)
+
+// Vector is a two-dimensional vector.
+type Vector struct {
+	X float64 `xml:"x,attr"`
+	Y float64 `xml:"y,attr"`
+}
+
+// Rectangle is a rectangle.
+type Rectangle struct {
+	Left   float64 `xml:"left,attr"`
+	Top    float64 `xml:"top,attr"`
+	Right  float64 `xml:"right,attr"`
+	Bottom float64 `xml:"bottom,attr"`
+}
+
+// Shape is the shape of an object.
+type Shape struct {
+	BoundingBox     Rectangle `xml:"http://www.onvif.org/ver10/schema BoundingBox"`
+	CenterOfGravity Vector    `xml:"http://www.onvif.org/ver10/schema CenterOfGravity"`
+}
+
+// ClassCandidate is a candidate class of an object.
+type ClassCandidate struct {
+	Likelihood float64 `xml:"Likelihood,attr"`
+	Type       string  `xml:",chardata"`
+}
+
+// Class is the class of an object.
+type Class struct {
+	Types []ClassCandidate `xml:"http://www.onvif.org/ver10/schema Type"`
+}
+
+// Appearance is the appearance of an object.
+type Appearance struct {
+	Shape *Shape `xml:"http://www.onvif.org/ver10/schema Shape"`
+	Class *Class `xml:"http://www.onvif.org/ver10/schema Class"`
+}
+
+// Object is an object detected by video analytics.
+type Object struct {
+	ObjectID   int         `xml:"ObjectId,attr"`
+	Appearance *Appearance `xml:"http://www.onvif.org/ver10/schema Appearance"`
+}
+
+// Frame contains the objects detected in a video frame.
+type Frame struct {
+	UtcTime time.Time `xml:"UtcTime,attr"`
+	Objects []Object  `xml:"http://www.onvif.org/ver10/schema Object"`
+}
+
+// VideoAnalytics contains the output of video analytics.
+type VideoAnalytics struct {
+	Frames []Frame `xml:"http://www.onvif.org/ver10/schema Frame"`
+}
+
+// PTZVector2D is a pan/tilt position.
+type PTZVector2D struct {
+	X     float64 `xml:"x,attr"`
+	Y     float64 `xml:"y,attr"`
+	Space string  `xml:"space,attr,omitempty"`
+}
+
+// PTZVector1D is a zoom position.
+type PTZVector1D struct {
+	X     float64 `xml:"x,attr"`
+	Space string  `xml:"space,attr,omitempty"`
+}
+
+// PTZVector is a PTZ position.
+type PTZVector struct {
+	PanTilt *PTZVector2D `xml:"http://www.onvif.org/ver10/schema PanTilt"`
+	Zoom    *PTZVector1D `xml:"http://www.onvif.org/ver10/schema Zoom"`
+}
+
+// PTZMoveStatus is the move status of a PTZ unit (IDLE, MOVING or UNKNOWN).
+type PTZMoveStatus struct {
+	PanTilt string `xml:"http://www.onvif.org/ver10/schema PanTilt,omitempty"`
+	Zoom    string `xml:"http://www.onvif.org/ver10/schema Zoom,omitempty"`
+}
+
+// PTZStatus is the status of a PTZ unit.
+type PTZStatus struct {
+	Position   *PTZVector     `xml:"http://www.onvif.org/ver10/schema Position"`
+	MoveStatus *PTZMoveStatus `xml:"http://www.onvif.org/ver10/schema MoveStatus"`
+	UtcTime    time.Time      `xml:"http://www.onvif.org/ver10/schema UtcTime"`
+}
+
+// PTZ contains the status of PTZ units.
+type PTZ struct {
+	PTZStatus []PTZStatus `xml:"http://www.onvif.org/ver10/schema PTZStatus"`
+}
+
+// SimpleItem is a name-value pair.
+type SimpleItem struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:"Value,attr"`
+}
+
+// ItemList is a list of items.
+type ItemList struct {
+	SimpleItems []SimpleItem `xml:"http://www.onvif.org/ver10/schema SimpleItem"`
+}
+
+// Message is the content of an event.
+type Message struct {
+	UtcTime           time.Time `xml:"UtcTime,attr"`
+	PropertyOperation string    `xml:"PropertyOperation,attr,omitempty"`
+	Source            *ItemList `xml:"http://www.onvif.org/ver10/schema Source"`
+	Key               *ItemList `xml:"http://www.onvif.org/ver10/schema Key"`
+	Data              *ItemList `xml:"http://www.onvif.org/ver10/schema Data"`
+}
+
+// Topic is the topic of an event.
+type Topic struct {
+	Dialect string `xml:"Dialect,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// MarshalXML implements xml.Marshaler.
+func (t Topic) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// topics are usually expressed with the tns1 prefix, that must be declared.
+	start.Attr = append(start.Attr, xml.Attr{
+		Name:  xml.Name{Local: "xmlns:tns1"},
+		Value: namespaceTopics,
+	})
+
+	type topic Topic
+	return e.EncodeElement(topic(t), start)
+}
+
+// NotificationMessageContent wraps the content of an event.
+type NotificationMessageContent struct {
+	Message Message `xml:"http://www.onvif.org/ver10/schema Message"`
+}
+
+// NotificationMessage is an event.
+type NotificationMessage struct {
+	Topic   Topic                      `xml:"http://docs.oasis-open.org/wsn/b-2 Topic"`
+	Message NotificationMessageContent `xml:"http://docs.oasis-open.org/wsn/b-2 Message"`
+}
+
+// Event contains events.
+type Event struct {
+	NotificationMessages []NotificationMessage `xml:"http://docs.oasis-open.org/wsn/b-2 NotificationMessage"`
+}
+
+// MetadataStream is an ONVIF metadata document.
+// Specification: ONVIF Streaming Specification, section 5.1.2.1.1
+type MetadataStream struct {
+	XMLName        xml.Name         `xml:"http://www.onvif.org/ver10/schema MetadataStream"`
+	VideoAnalytics []VideoAnalytics `xml:"http://www.onvif.org/ver10/schema VideoAnalytics"`
+	PTZ            []PTZ            `xml:"http://www.onvif.org/ver10/schema PTZ"`
+	Events         []Event          `xml:"http://www.onvif.org/ver10/schema Event"`
+}
+
+// Unmarshal decodes a MetadataStream from a XML document.
+func (m *MetadataStream) Unmarshal(buf []byte) error {
+	return xml.Unmarshal(buf, m)
+}
+
+// Marshal encodes a MetadataStream into a XML document.
+func (m MetadataStream) Marshal() ([]byte, error) {
+	buf, err := xml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), buf...), nil
+}
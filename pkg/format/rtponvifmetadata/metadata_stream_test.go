@@ -0,0 +1,141 @@
+package rtponvifmetadata
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testDocument = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<tt:MetadataStream xmlns:tt="http://www.onvif.org/ver10/schema"` +
+	` xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:tns1="http://www.onvif.org/ver10/topics">
+<tt:VideoAnalytics>
+<tt:Frame UtcTime="2008-10-10T12:24:57.321Z">
+<tt:Object ObjectId="12">
+<tt:Appearance>
+<tt:Shape>
+<tt:BoundingBox left="20" top="30" right="100" bottom="80"/>
+<tt:CenterOfGravity x="60" y="50"/>
+</tt:Shape>
+<tt:Class>
+<tt:Type Likelihood="0.9">Human</tt:Type>
+</tt:Class>
+</tt:Appearance>
+</tt:Object>
+</tt:Frame>
+</tt:VideoAnalytics>
+<tt:PTZ>
+<tt:PTZStatus>
+<tt:Position>
+<tt:PanTilt x="0.5" y="-0.25"/>
+<tt:Zoom x="0.1"/>
+</tt:Position>
+<tt:MoveStatus>
+<tt:PanTilt>IDLE</tt:PanTilt>
+<tt:Zoom>MOVING</tt:Zoom>
+</tt:MoveStatus>
+<tt:UtcTime>2008-10-10T12:24:57.321Z</tt:UtcTime>
+</tt:PTZStatus>
+</tt:PTZ>
+<tt:Event>
+<wsnt:NotificationMessage>
+<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">` +
+	`tns1:RuleEngine/CellMotionDetector/Motion</wsnt:Topic>
+<wsnt:Message>
+<tt:Message UtcTime="2008-10-10T12:24:57.321Z" PropertyOperation="Changed">
+<tt:Source>
+<tt:SimpleItem Name="VideoSourceConfigurationToken" Value="1"/>
+</tt:Source>
+<tt:Data>
+<tt:SimpleItem Name="IsMotion" Value="true"/>
+</tt:Data>
+</tt:Message>
+</wsnt:Message>
+</wsnt:NotificationMessage>
+</tt:Event>
+</tt:MetadataStream>`)
+
+var testMetadataStream = MetadataStream{
+	XMLName: xml.Name{
+		Space: "http://www.onvif.org/ver10/schema",
+		Local: "MetadataStream",
+	},
+	VideoAnalytics: []VideoAnalytics{{
+		Frames: []Frame{{
+			UtcTime: time.Date(2008, 10, 10, 12, 24, 57, 321000000, time.UTC),
+			Objects: []Object{{
+				ObjectID: 12,
+				Appearance: &Appearance{
+					Shape: &Shape{
+						BoundingBox:     Rectangle{Left: 20, Top: 30, Right: 100, Bottom: 80},
+						CenterOfGravity: Vector{X: 60, Y: 50},
+					},
+					Class: &Class{
+						Types: []ClassCandidate{{Likelihood: 0.9, Type: "Human"}},
+					},
+				},
+			}},
+		}},
+	}},
+	PTZ: []PTZ{{
+		PTZStatus: []PTZStatus{{
+			Position: &PTZVector{
+				PanTilt: &PTZVector2D{X: 0.5, Y: -0.25},
+				Zoom:    &PTZVector1D{X: 0.1},
+			},
+			MoveStatus: &PTZMoveStatus{
+				PanTilt: "IDLE",
+				Zoom:    "MOVING",
+			},
+			UtcTime: time.Date(2008, 10, 10, 12, 24, 57, 321000000, time.UTC),
+		}},
+	}},
+	Events: []Event{{
+		NotificationMessages: []NotificationMessage{{
+			Topic: Topic{
+				Dialect: "http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet",
+				Value:   "tns1:RuleEngine/CellMotionDetector/Motion",
+			},
+			Message: NotificationMessageContent{
+				Message: Message{
+					UtcTime:           time.Date(2008, 10, 10, 12, 24, 57, 321000000, time.UTC),
+					PropertyOperation: "Changed",
+					Source: &ItemList{
+						SimpleItems: []SimpleItem{{Name: "VideoSourceConfigurationToken", Value: "1"}},
+					},
+					Data: &ItemList{
+						SimpleItems: []SimpleItem{{Name: "IsMotion", Value: "true"}},
+					},
+				},
+			},
+		}},
+	}},
+}
+
+func TestMetadataStreamUnmarshal(t *testing.T) {
+	var m MetadataStream
+	err := m.Unmarshal(testDocument)
+	require.NoError(t, err)
+	require.Equal(t, testMetadataStream, m)
+}
+
+func TestMetadataStreamMarshal(t *testing.T) {
+	buf, err := testMetadataStream.Marshal()
+	require.NoError(t, err)
+
+	var m MetadataStream
+	err = m.Unmarshal(buf)
+	require.NoError(t, err)
+	require.Equal(t, testMetadataStream, m)
+}
+
+func FuzzMetadataStreamUnmarshal(f *testing.F) {
+	f.Add(testDocument)
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var m MetadataStream
+		m.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,7 @@
+// Package rtponvifmetadata contains a RTP/ONVIF metadata decoder and encoder.
+package rtponvifmetadata
+
+const (
+	// maximum size of a metadata document.
+	maxDocumentSize = 1 * 1024 * 1024
+)
@@ -0,0 +1,157 @@
+package rtprawvideo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't receive anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+type segment struct {
+	length int
+	field  int
+	line   int
+	offset int
+}
+
+// Decoder is a RTP/raw video decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4175
+type Decoder struct {
+	// sampling (YCbCr-4:2:2, RGB, etc).
+	Sampling string
+
+	// frame width.
+	Width int
+
+	// frame height.
+	Height int
+
+	// bit depth of each component.
+	Depth int
+
+	// whether the video is interlaced.
+	Interlaced bool
+
+	layout       frameLayout
+	frame        []byte
+	nextSeqNum   uint32
+	currentField int
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return d.layout.init(d.Sampling, d.Width, d.Height, d.Depth, d.Interlaced)
+}
+
+func (d *Decoder) parseSegments(payload []byte) ([]segment, []byte, error) {
+	var segments []segment
+
+	for {
+		if len(payload) < segmentHeaderSize {
+			return nil, nil, fmt.Errorf("payload is too short")
+		}
+
+		seg := segment{
+			length: int(payload[0])<<8 | int(payload[1]),
+			field:  int(payload[2] >> 7),
+			line:   int(payload[2]&0x7F)<<8 | int(payload[3]),
+			offset: int(payload[4]&0x7F)<<8 | int(payload[5]),
+		}
+		continuation := (payload[4] >> 7) != 0
+		payload = payload[segmentHeaderSize:]
+
+		if seg.field != 0 && !d.Interlaced {
+			return nil, nil, fmt.Errorf("received a segment of the second field, but video is progressive")
+		}
+
+		if (seg.length%d.layout.pgroup.size) != 0 ||
+			(seg.line%d.layout.pgroup.height) != 0 ||
+			(seg.offset%d.layout.pgroup.width) != 0 {
+			return nil, nil, fmt.Errorf("segment is not aligned to pgroups")
+		}
+
+		if seg.line >= d.layout.linesPerField() ||
+			(seg.offset+(seg.length/d.layout.pgroup.size)*d.layout.pgroup.width) > d.layout.width {
+			return nil, nil, fmt.Errorf("segment is out of bounds")
+		}
+
+		segments = append(segments, seg)
+
+		if !continuation {
+			break
+		}
+	}
+
+	return segments, payload, nil
+}
+
+// Decode decodes a frame from a RTP packet.
+// Frames are returned as a single planar buffer, that contains
+// the planes of components in order (Y, Cb, Cr with YCbCr samplings,
+// otherwise the order of the sampling name, i.e. R, G, B with RGB).
+// Each plane contains all lines, from top to bottom, and is subsampled
+// according to the sampling.
+// Samples are stored in 1 byte when depth is 8, otherwise in 2 bytes, big endian.
+// Interlaced fields are merged into a single frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	if len(pkt.Payload) < extendedSequenceNumberSize {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	seqNum := uint32(pkt.Payload[0])<<24 | uint32(pkt.Payload[1])<<16 | uint32(pkt.SequenceNumber)
+
+	segments, data, err := d.parseSegments(pkt.Payload[extendedSequenceNumberSize:])
+	if err != nil {
+		d.frame = nil
+		return nil, err
+	}
+
+	if d.frame == nil {
+		if segments[0].field != 0 || segments[0].line != 0 || segments[0].offset != 0 {
+			return nil, ErrNonStartingPacketAndNoPrevious
+		}
+
+		d.frame = make([]byte, d.layout.frameSize())
+	} else if seqNum != d.nextSeqNum {
+		d.frame = nil
+		return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+	}
+
+	d.nextSeqNum = seqNum + 1
+
+	for _, seg := range segments {
+		if len(data) < seg.length {
+			d.frame = nil
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		d.layout.unpack(d.frame, data[:seg.length], seg.field, seg.line, seg.offset)
+		data = data[seg.length:]
+
+		d.currentField = seg.field
+	}
+
+	if !pkt.Marker {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	// in case of interlaced video, the marker bit is set at the end of each field.
+	if d.currentField != (d.layout.fieldCount() - 1) {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	frame := d.frame
+	d.frame = nil
+
+	return frame, nil
+}
@@ -0,0 +1,185 @@
+package rtprawvideo
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/raw video encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4175
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// sampling (YCbCr-4:2:2, RGB, etc).
+	Sampling string
+
+	// frame width.
+	Width int
+
+	// frame height.
+	Height int
+
+	// bit depth of each component.
+	Depth int
+
+	// whether the video is interlaced.
+	Interlaced bool
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	layout         frameLayout
+	sequenceNumber uint32
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	err := e.layout.init(e.Sampling, e.Width, e.Height, e.Depth, e.Interlaced)
+	if err != nil {
+		return err
+	}
+
+	if e.SSRC == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err2 := randUint32()
+		if err2 != nil {
+			return err2
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	if e.PayloadMaxSize < (extendedSequenceNumberSize + segmentHeaderSize + e.layout.pgroup.size) {
+		return fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	e.sequenceNumber = uint32(*e.InitialSequenceNumber)
+	return nil
+}
+
+// Encode encodes a frame into RTP packets.
+// The frame must be a single planar buffer, in the format returned by Decoder.Decode().
+// Multiple scan line segments are put into the same packet when possible.
+func (e *Encoder) Encode(frame []byte) ([]*rtp.Packet, error) {
+	if len(frame) != e.layout.frameSize() {
+		return nil, fmt.Errorf("invalid frame size: %d, expected %d", len(frame), e.layout.frameSize())
+	}
+
+	var ret []*rtp.Packet
+
+	for field := 0; field < e.layout.fieldCount(); field++ {
+		var segments []segment
+		avail := e.PayloadMaxSize - extendedSequenceNumberSize
+
+		for line := 0; line < e.layout.linesPerField(); line += e.layout.pgroup.height {
+			offset := 0
+
+			for offset < e.layout.width {
+				if avail < (segmentHeaderSize + e.layout.pgroup.size) {
+					ret = append(ret, e.writePacket(frame, segments, false))
+					segments = segments[:0]
+					avail = e.PayloadMaxSize - extendedSequenceNumberSize
+				}
+
+				pgroupCount := min(
+					(avail-segmentHeaderSize)/e.layout.pgroup.size,
+					(e.layout.width-offset)/e.layout.pgroup.width)
+
+				seg := segment{
+					length: pgroupCount * e.layout.pgroup.size,
+					field:  field,
+					line:   line,
+					offset: offset,
+				}
+				segments = append(segments, seg)
+
+				avail -= segmentHeaderSize + seg.length
+				offset += pgroupCount * e.layout.pgroup.width
+			}
+		}
+
+		ret = append(ret, e.writePacket(frame, segments, true))
+	}
+
+	return ret, nil
+}
+
+func (e *Encoder) writePacket(frame []byte, segments []segment, marker bool) *rtp.Packet {
+	size := extendedSequenceNumberSize
+	for _, seg := range segments {
+		size += segmentHeaderSize + seg.length
+	}
+
+	payload := make([]byte, size)
+	payload[0] = byte(e.sequenceNumber >> 24)
+	payload[1] = byte(e.sequenceNumber >> 16)
+	n := extendedSequenceNumberSize
+
+	for i, seg := range segments {
+		payload[n] = byte(seg.length >> 8)
+		payload[n+1] = byte(seg.length)
+		payload[n+2] = byte(seg.field<<7) | byte(seg.line>>8)
+		payload[n+3] = byte(seg.line)
+		payload[n+4] = byte(seg.offset >> 8)
+		if i != (len(segments) - 1) {
+			payload[n+4] |= 1 << 7
+		}
+		payload[n+5] = byte(seg.offset)
+		n += segmentHeaderSize
+	}
+
+	for _, seg := range segments {
+		e.layout.pack(payload[n:n+seg.length], frame, seg.field, seg.line, seg.offset)
+		n += seg.length
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: uint16(e.sequenceNumber),
+			SSRC:           *e.SSRC,
+			Marker:         marker,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
@@ -0,0 +1,492 @@
+package rtprawvideo
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+func testFrame(size int) []byte {
+	frame := make([]byte, size)
+	for i := range frame {
+		frame[i] = byte(i)
+	}
+	return frame
+}
+
+var cases = []struct {
+	name           string
+	sampling       string
+	width          int
+	height         int
+	depth          int
+	interlaced     bool
+	payloadMaxSize int
+	frame          []byte
+	pkts           []*rtp.Packet
+}{
+	{
+		"multiple segments",
+		"YCbCr-4:2:2",
+		4,
+		2,
+		8,
+		false,
+		0,
+		[]byte{
+			// Y
+			0x01, 0x03, 0x05, 0x07,
+			0x09, 0x0b, 0x0d, 0x0f,
+			// Cb
+			0x00, 0x04,
+			0x08, 0x0c,
+			// Cr
+			0x02, 0x06,
+			0x0a, 0x0e,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x08, 0x00, 0x00, 0x80, 0x00},
+					[]byte{0x00, 0x08, 0x00, 0x01, 0x00, 0x00},
+					testFrame(16),
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		"YCbCr-4:2:2",
+		8,
+		2,
+		8,
+		false,
+		20,
+		[]byte{
+			// Y
+			0x01, 0x03, 0x05, 0x07, 0x09, 0x0b, 0x0d, 0x0f,
+			0x11, 0x13, 0x15, 0x17, 0x19, 0x1b, 0x1d, 0x1f,
+			// Cb
+			0x00, 0x04, 0x08, 0x0c,
+			0x10, 0x14, 0x18, 0x1c,
+			// Cr
+			0x02, 0x06, 0x0a, 0x0e,
+			0x12, 0x16, 0x1a, 0x1e,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x0c, 0x00, 0x00, 0x00, 0x00},
+					testFrame(32)[0:12],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x04, 0x00, 0x00, 0x00, 0x06},
+					testFrame(32)[12:16],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x0c, 0x00, 0x01, 0x00, 0x00},
+					testFrame(32)[16:28],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17648,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x04, 0x00, 0x01, 0x00, 0x06},
+					testFrame(32)[28:32],
+				),
+			},
+		},
+	},
+	{
+		"interlaced",
+		"RGB",
+		2,
+		2,
+		8,
+		true,
+		0,
+		[]byte{
+			// R
+			0x00, 0x03,
+			0x06, 0x09,
+			// G
+			0x01, 0x04,
+			0x07, 0x0a,
+			// B
+			0x02, 0x05,
+			0x08, 0x0b,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x06, 0x00, 0x00, 0x00, 0x00},
+					testFrame(12)[0:6],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x06, 0x80, 0x00, 0x00, 0x00},
+					testFrame(12)[6:12],
+				),
+			},
+		},
+	},
+	{
+		"4:2:0",
+		"YCbCr-4:2:0",
+		2,
+		4,
+		8,
+		false,
+		0,
+		[]byte{
+			// Y
+			0x00, 0x01,
+			0x02, 0x03,
+			0x06, 0x07,
+			0x08, 0x09,
+			// Cb
+			0x04,
+			0x0a,
+			// Cr
+			0x05,
+			0x0b,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00},
+					[]byte{0x00, 0x06, 0x00, 0x00, 0x80, 0x00},
+					[]byte{0x00, 0x06, 0x00, 0x02, 0x00, 0x00},
+					testFrame(12),
+				),
+			},
+		},
+	},
+	{
+		"10 bit",
+		"YCbCr-4:2:2",
+		2,
+		1,
+		10,
+		false,
+		0,
+		[]byte{
+			// Y
+			0x00, 0x02, 0x03, 0xff,
+			// Cb
+			0x00, 0x01,
+			// Cr
+			0x00, 0x03,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x00, 0x00,
+					0x00, 0x05, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x40, 0x20, 0x0f, 0xff,
+				},
+			},
+		},
+	},
+	{
+		"4:4:4 10 bit",
+		"YCbCr-4:4:4",
+		4,
+		1,
+		10,
+		false,
+		0,
+		[]byte{
+			// Y
+			0x00, 0x01, 0x00, 0x04, 0x00, 0x07, 0x00, 0x0a,
+			// Cb
+			0x00, 0x00, 0x00, 0x03, 0x00, 0x06, 0x00, 0x09,
+			// Cr
+			0x00, 0x02, 0x00, 0x05, 0x00, 0x08, 0x00, 0x0b,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x00, 0x00,
+					0x00, 0x0f, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x10, 0x08, 0x03, 0x01, 0x00, 0x50,
+					0x18, 0x07, 0x02, 0x00, 0x90, 0x28, 0x0b,
+				},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				Sampling:              ca.sampling,
+				Width:                 ca.width,
+				Height:                ca.height,
+				Depth:                 ca.depth,
+				Interlaced:            ca.interlaced,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+				PayloadMaxSize:        ca.payloadMaxSize,
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.frame)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeExtendedSequenceNumber(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           96,
+		Sampling:              "RGB",
+		Width:                 2,
+		Height:                2,
+		Depth:                 8,
+		Interlaced:            true,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0xffff),
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(testFrame(12))
+	require.NoError(t, err)
+	require.Equal(t, uint16(0xffff), pkts[0].SequenceNumber)
+	require.Equal(t, []byte{0x00, 0x00}, pkts[0].Payload[:2])
+	require.Equal(t, uint16(0), pkts[1].SequenceNumber)
+	require.Equal(t, []byte{0x00, 0x01}, pkts[1].Payload[:2])
+
+	d := &Decoder{
+		Sampling:   "RGB",
+		Width:      2,
+		Height:     2,
+		Depth:      8,
+		Interlaced: true,
+	}
+	err = d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	frame, err := d.Decode(pkts[1])
+	require.NoError(t, err)
+	require.Equal(t, testFrame(12), frame)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		Sampling:    "YCbCr-4:2:2",
+		Width:       3,
+		Height:      2,
+		Depth:       8,
+	}
+	err := e.Init()
+	require.EqualError(t, err, "invalid width: 3")
+
+	e = &Encoder{
+		PayloadType: 96,
+		Sampling:    "YCbCr-4:2:2",
+		Width:       4,
+		Height:      2,
+		Depth:       9,
+	}
+	err = e.Init()
+	require.EqualError(t, err, "unsupported depth: 9")
+
+	e = &Encoder{
+		PayloadType: 96,
+		Sampling:    "YCbCr-4:2:2",
+		Width:       4,
+		Height:      2,
+		Depth:       8,
+	}
+	err = e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode(testFrame(15))
+	require.EqualError(t, err, "invalid frame size: 15, expected 16")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		Sampling:    "RGB",
+		Width:       2,
+		Height:      2,
+		Depth:       8,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
+
+func TestEncodeDecodeAllSamplings(t *testing.T) {
+	for _, sampling := range []string{
+		"YCbCr-4:4:4", "YCbCr-4:2:2", "YCbCr-4:1:1", "YCbCr-4:2:0",
+		"RGB", "RGBA", "BGR", "BGRA",
+	} {
+		for _, depth := range []int{8, 10, 12, 16} {
+			t.Run(sampling+"_"+strconv.FormatInt(int64(depth), 10), func(t *testing.T) {
+				e := &Encoder{
+					PayloadType: 96,
+					Sampling:    sampling,
+					Width:       16,
+					Height:      4,
+					Depth:       depth,
+				}
+				err := e.Init()
+				require.NoError(t, err)
+
+				// fill samples with values allowed by the depth
+				frame := make([]byte, e.layout.frameSize())
+				for i := range frame {
+					frame[i] = byte(i)
+					if depth != 8 && (i%2) == 0 {
+						frame[i] &= byte(1<<(depth-8) - 1)
+					}
+				}
+
+				pkts, err := e.Encode(frame)
+				require.NoError(t, err)
+
+				d := &Decoder{
+					Sampling: sampling,
+					Width:    16,
+					Height:   4,
+					Depth:    depth,
+				}
+				err = d.Init()
+				require.NoError(t, err)
+
+				var dec []byte
+
+				for _, pkt := range pkts {
+					dec, err = d.Decode(pkt)
+					if errors.Is(err, ErrMorePacketsNeeded) {
+						continue
+					}
+					require.NoError(t, err)
+				}
+
+				require.Equal(t, frame, dec)
+			})
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package rtprawvideo contains a RTP/raw video decoder and encoder.
+package rtprawvideo
+
+import (
+	"fmt"
+)
+
+const (
+	extendedSequenceNumberSize = 2
+	segmentHeaderSize          = 6
+)
+
+// pgroup is the smallest group of pixels that is aligned to a byte boundary.
+type pgroup struct {
+	// size in bytes.
+	size int
+
+	// horizontal pixels.
+	width int
+
+	// vertical pixels (scan lines).
+	height int
+}
+
+// RFC4175, section 4.3
+func findPGroup(sampling string, depth int) (pgroup, error) {
+	switch sampling {
+	case "YCbCr-4:4:4", "RGB", "BGR":
+		switch depth {
+		case 8:
+			return pgroup{3, 1, 1}, nil
+		case 10:
+			return pgroup{15, 4, 1}, nil
+		case 12:
+			return pgroup{9, 2, 1}, nil
+		case 16:
+			return pgroup{6, 1, 1}, nil
+		}
+
+	case "RGBA", "BGRA":
+		switch depth {
+		case 8:
+			return pgroup{4, 1, 1}, nil
+		case 10:
+			return pgroup{5, 1, 1}, nil
+		case 12:
+			return pgroup{6, 1, 1}, nil
+		case 16:
+			return pgroup{8, 1, 1}, nil
+		}
+
+	case "YCbCr-4:2:2":
+		switch depth {
+		case 8:
+			return pgroup{4, 2, 1}, nil
+		case 10:
+			return pgroup{5, 2, 1}, nil
+		case 12:
+			return pgroup{6, 2, 1}, nil
+		case 16:
+			return pgroup{8, 2, 1}, nil
+		}
+
+	case "YCbCr-4:1:1":
+		switch depth {
+		case 8:
+			return pgroup{6, 4, 1}, nil
+		case 10:
+			return pgroup{15, 8, 1}, nil
+		case 12:
+			return pgroup{9, 4, 1}, nil
+		case 16:
+			return pgroup{12, 4, 1}, nil
+		}
+
+	case "YCbCr-4:2:0":
+		switch depth {
+		case 8:
+			return pgroup{6, 2, 2}, nil
+		case 10:
+			return pgroup{15, 4, 2}, nil
+		case 12:
+			return pgroup{9, 2, 2}, nil
+		case 16:
+			return pgroup{12, 2, 2}, nil
+		}
+
+	default:
+		return pgroup{}, fmt.Errorf("unsupported sampling: %v", sampling)
+	}
+
+	return pgroup{}, fmt.Errorf("unsupported depth: %d", depth)
+}
+
+// component is a sample of a pgroup.
+type component struct {
+	// index of the plane.
+	plane int
+
+	// horizontal and vertical position, relative to the first pixel of the pgroup.
+	x int
+	y int
+}
+
+// samplingLayout describes how samples are placed into pgroups and planes.
+type samplingLayout struct {
+	// horizontal and vertical subsampling of each plane.
+	planes [][2]int
+
+	// pixels covered by components.
+	width  int
+	height int
+
+	// components, in the order in which they are placed into pgroups.
+	components []component
+}
+
+// RFC4175, section 4.3
+func findSamplingLayout(sampling string) samplingLayout {
+	switch sampling {
+	case "YCbCr-4:4:4":
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {1, 1}, {1, 1}},
+			width:      1,
+			height:     1,
+			components: []component{{1, 0, 0}, {0, 0, 0}, {2, 0, 0}},
+		}
+
+	case "YCbCr-4:2:2":
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {2, 1}, {2, 1}},
+			width:      2,
+			height:     1,
+			components: []component{{1, 0, 0}, {0, 0, 0}, {2, 0, 0}, {0, 1, 0}},
+		}
+
+	case "YCbCr-4:1:1":
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {4, 1}, {4, 1}},
+			width:      4,
+			height:     1,
+			components: []component{{1, 0, 0}, {0, 0, 0}, {0, 1, 0}, {2, 0, 0}, {0, 2, 0}, {0, 3, 0}},
+		}
+
+	case "YCbCr-4:2:0":
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {2, 2}, {2, 2}},
+			width:      2,
+			height:     2,
+			components: []component{{0, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0, 1, 1}, {1, 0, 0}, {2, 0, 0}},
+		}
+
+	case "RGBA", "BGRA":
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {1, 1}, {1, 1}, {1, 1}},
+			width:      1,
+			height:     1,
+			components: []component{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {3, 0, 0}},
+		}
+
+	default: // RGB, BGR
+		return samplingLayout{
+			planes:     [][2]int{{1, 1}, {1, 1}, {1, 1}},
+			width:      1,
+			height:     1,
+			components: []component{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}},
+		}
+	}
+}
+
+// frameLayout describes how pgroups are unpacked into a planar frame buffer.
+type frameLayout struct {
+	pgroup     pgroup
+	sampling   samplingLayout
+	depth      int
+	width      int
+	height     int
+	interlaced bool
+
+	// size in bytes of a sample.
+	sampleSize int
+
+	// position and width of each plane.
+	planeOffsets []int
+	planeWidths  []int
+
+	size int
+}
+
+func (l *frameLayout) init(sampling string, width int, height int, depth int, interlaced bool) error {
+	var err error
+	l.pgroup, err = findPGroup(sampling, depth)
+	if err != nil {
+		return err
+	}
+
+	if width <= 0 || width > 0x7FFF || (width%l.pgroup.width) != 0 {
+		return fmt.Errorf("invalid width: %d", width)
+	}
+
+	fieldCount := 1
+	if interlaced {
+		if l.pgroup.height != 1 {
+			return fmt.Errorf("interlaced video with sampling %v is not supported", sampling)
+		}
+		fieldCount = 2
+	}
+
+	if height <= 0 || height > 0x7FFF || (height%(l.pgroup.height*fieldCount)) != 0 {
+		return fmt.Errorf("invalid height: %d", height)
+	}
+
+	l.sampling = findSamplingLayout(sampling)
+	l.depth = depth
+	l.width = width
+	l.height = height
+	l.interlaced = interlaced
+
+	if depth == 8 {
+		l.sampleSize = 1
+	} else {
+		l.sampleSize = 2
+	}
+
+	l.planeOffsets = make([]int, len(l.sampling.planes))
+	l.planeWidths = make([]int, len(l.sampling.planes))
+	l.size = 0
+
+	for i, sub := range l.sampling.planes {
+		l.planeOffsets[i] = l.size
+		l.planeWidths[i] = width / sub[0]
+		l.size += l.planeWidths[i] * (height / sub[1]) * l.sampleSize
+	}
+
+	return nil
+}
+
+func (l *frameLayout) frameSize() int {
+	return l.size
+}
+
+func (l *frameLayout) fieldCount() int {
+	if l.interlaced {
+		return 2
+	}
+	return 1
+}
+
+// linesPerField returns the number of scan lines of each field (or frame).
+func (l *frameLayout) linesPerField() int {
+	return l.height / l.fieldCount()
+}
+
+// samplePosition returns the position in the frame buffer of a sample.
+func (l *frameLayout) samplePosition(c component, x int, y int) int {
+	sub := l.sampling.planes[c.plane]
+	return l.planeOffsets[c.plane] +
+		(((y+c.y)/sub[1])*l.planeWidths[c.plane]+(x+c.x)/sub[0])*l.sampleSize
+}
+
+// frameLine returns the line of the frame that corresponds to the given scan line.
+func (l *frameLayout) frameLine(field int, line int) int {
+	if l.interlaced {
+		return line*2 + field
+	}
+	return line
+}
+
+// unitCount returns the number of component sequences contained in pgroups of the given size.
+func (l *frameLayout) unitCount(size int) int {
+	return (size / l.pgroup.size) * (l.pgroup.width / l.sampling.width)
+}
+
+// unpack unpacks the pgroups of a scan line segment into the frame buffer.
+func (l *frameLayout) unpack(frame []byte, data []byte, field int, line int, offset int) {
+	y := l.frameLine(field, line)
+	mask := uint64(1)<<l.depth - 1
+	var acc uint64
+	accBits := 0
+	n := 0
+
+	for i := 0; i < l.unitCount(len(data)); i++ {
+		x := offset + i*l.sampling.width
+
+		for _, c := range l.sampling.components {
+			for accBits < l.depth {
+				acc = acc<<8 | uint64(data[n])
+				n++
+				accBits += 8
+			}
+			accBits -= l.depth
+			v := (acc >> accBits) & mask
+
+			pos := l.samplePosition(c, x, y)
+			if l.sampleSize == 1 {
+				frame[pos] = byte(v)
+			} else {
+				frame[pos] = byte(v >> 8)
+				frame[pos+1] = byte(v)
+			}
+		}
+	}
+}
+
+// pack packs a scan line segment of the frame buffer into pgroups.
+func (l *frameLayout) pack(data []byte, frame []byte, field int, line int, offset int) {
+	y := l.frameLine(field, line)
+	mask := uint64(1)<<l.depth - 1
+	var acc uint64
+	accBits := 0
+	n := 0
+
+	for i := 0; i < l.unitCount(len(data)); i++ {
+		x := offset + i*l.sampling.width
+
+		for _, c := range l.sampling.components {
+			pos := l.samplePosition(c, x, y)
+			var v uint64
+			if l.sampleSize == 1 {
+				v = uint64(frame[pos])
+			} else {
+				v = uint64(frame[pos])<<8 | uint64(frame[pos+1])
+			}
+
+			acc = acc<<l.depth | (v & mask)
+			accBits += l.depth
+
+			for accBits >= 8 {
+				accBits -= 8
+				data[n] = byte(acc >> accBits)
+				n++
+			}
+		}
+	}
+}
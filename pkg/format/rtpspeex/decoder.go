@@ -0,0 +1,50 @@
+package rtpspeex
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// Decoder is a RTP/Speex decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5574
+type Decoder struct{}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes Speex frames from a RTP packet.
+// Frames are bit-packed inside the payload. Each one is moved into
+// a dedicated buffer, padded with a terminator.
+// Each frame lasts 20ms, therefore the timestamp of the N-th frame is
+// the packet timestamp plus N * (sample rate / 50).
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	r := &bitReader{buf: pkt.Payload}
+	var frames [][]byte
+
+	for {
+		start := r.pos
+
+		n, err := frameBits(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 {
+			break
+		}
+
+		frame := make([]byte, (n+7)/8)
+		copyBits(frame, 0, pkt.Payload, start, n)
+		pad(frame, n)
+		frames = append(frames, frame)
+	}
+
+	if frames == nil {
+		return nil, fmt.Errorf("payload doesn't contain any frame")
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,76 @@
+package rtpspeex
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var frames [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addFrames, err := d.Decode(pkt)
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				frames = append(frames, addFrames...)
+			}
+
+			require.Equal(t, ca.frames, frames)
+		})
+	}
+}
+
+func TestDecodeInband(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	// in-band signaling (mode 14, code 2, 4 bits of payload)
+	// followed by a silence frame (mode 0) and a terminator.
+	frames, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         false,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x71, 0x00, 0x1f},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x71, 0x00, 0x1f}}, frames)
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		frames, err := d.Decode(&rtp.Packet{Payload: b})
+		if err == nil {
+			if len(frames) == 0 {
+				t.Errorf("should not happen")
+			}
+
+			for _, frame := range frames {
+				if len(frame) == 0 {
+					t.Errorf("should not happen")
+				}
+			}
+		}
+	})
+}
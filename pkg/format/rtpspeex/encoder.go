@@ -0,0 +1,151 @@
+package rtpspeex
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/Speex encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5574
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// sample rate of frames.
+	SampleRate int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SampleRate <= 0 {
+		return fmt.Errorf("invalid SampleRate")
+	}
+
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes Speex frames into RTP packets.
+// Each frame must contain a single Speex frame, optionally padded.
+// Frames are bit-packed together as long as they fit into a packet.
+// Timestamps of packets are relative to the first frame.
+func (e *Encoder) Encode(frames [][]byte) ([]*rtp.Packet, error) {
+	sizes := make([]int, len(frames))
+
+	for i, frame := range frames {
+		n, err := frameBits(&bitReader{buf: frame})
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 {
+			return nil, fmt.Errorf("invalid frame")
+		}
+
+		if ((n + 7) / 8) > e.PayloadMaxSize {
+			return nil, fmt.Errorf("frame is too big")
+		}
+
+		sizes[i] = n
+	}
+
+	var rets []*rtp.Packet
+	samplesPerFrame := uint32(e.SampleRate / 50)
+	timestamp := uint32(0)
+	start := 0
+	bits := 0
+
+	for i := range frames {
+		if ((bits + sizes[i] + 7) / 8) > e.PayloadMaxSize {
+			rets = append(rets, e.writePacket(frames[start:i], sizes[start:i], bits, timestamp))
+			timestamp += uint32(i-start) * samplesPerFrame
+			start = i
+			bits = 0
+		}
+
+		bits += sizes[i]
+	}
+
+	if len(frames) != 0 {
+		rets = append(rets, e.writePacket(frames[start:], sizes[start:], bits, timestamp))
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writePacket(frames [][]byte, sizes []int, bits int, timestamp uint32) *rtp.Packet {
+	payload := make([]byte, (bits+7)/8)
+	pos := 0
+
+	for i, frame := range frames {
+		copyBits(payload, pos, frame, 0, sizes[i])
+		pos += sizes[i]
+	}
+
+	pad(payload, pos)
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      timestamp,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
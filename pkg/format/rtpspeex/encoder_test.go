@@ -0,0 +1,157 @@
+package rtpspeex
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var testFrameWB1 = []byte{
+	0x1a, 0x94, 0xa5, 0x29, 0x4a, 0x52, 0x94, 0xa5,
+	0x29, 0x4a, 0x52, 0x94, 0xa5, 0x29, 0x4a, 0x52,
+	0x94, 0xa5, 0x29, 0x4a, 0x9a, 0x52, 0x94, 0xa5,
+	0x27,
+}
+
+var testFrameWB2 = []byte{
+	0x1a, 0x52, 0x94, 0xa5, 0x29, 0x4a, 0x52, 0x94,
+	0xa5, 0x29, 0x4a, 0x52, 0x94, 0xa5, 0x29, 0x4a,
+	0x52, 0x94, 0xa5, 0x29, 0x99, 0x4a, 0x52, 0x94,
+	0xa7,
+}
+
+var cases = []struct {
+	name       string
+	sampleRate int
+	frames     [][]byte
+	pkts       []*rtp.Packet
+}{
+	{
+		"narrowband",
+		8000,
+		[][]byte{
+			{0x0c, 0xa5, 0x29, 0x4a, 0x52, 0x8f},
+			{0x09, 0x4a, 0x52, 0x94, 0xa5, 0x2f},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x0c, 0xa5, 0x29, 0x4a, 0x52, 0x81, 0x29, 0x4a,
+					0x52, 0x94, 0xa5,
+				},
+			},
+		},
+	},
+	{
+		"wideband",
+		16000,
+		[][]byte{
+			testFrameWB1,
+			testFrameWB2,
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x1a, 0x94, 0xa5, 0x29, 0x4a, 0x52, 0x94, 0xa5,
+					0x29, 0x4a, 0x52, 0x94, 0xa5, 0x29, 0x4a, 0x52,
+					0x94, 0xa5, 0x29, 0x4a, 0x9a, 0x52, 0x94, 0xa5,
+					0x21, 0xa5, 0x29, 0x4a, 0x52, 0x94, 0xa5, 0x29,
+					0x4a, 0x52, 0x94, 0xa5, 0x29, 0x4a, 0x52, 0x94,
+					0xa5, 0x29, 0x4a, 0x52, 0x99, 0x94, 0xa5, 0x29,
+					0x4a,
+				},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           96,
+				SampleRate:            ca.sampleRate,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts, err := e.Encode(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeSplit(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           96,
+		SampleRate:            16000,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+		PayloadMaxSize:        30,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode([][]byte{testFrameWB1, testFrameWB2})
+	require.NoError(t, err)
+	require.Equal(t, []*rtp.Packet{
+		{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: testFrameWB1,
+		},
+		{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17646,
+				Timestamp:      320,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: testFrameWB2,
+		},
+	}, pkts)
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		SampleRate:  8000,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
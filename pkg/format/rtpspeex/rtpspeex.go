@@ -0,0 +1,162 @@
+// Package rtpspeex contains a RTP/Speex decoder and encoder.
+package rtpspeex
+
+import (
+	"fmt"
+)
+
+// size in bits of narrowband frames, including the header,
+// indexed by submode.
+var narrowbandFrameBits = [9]int{5, 43, 119, 160, 220, 300, 364, 492, 79}
+
+// size in bits of wideband and ultra-wideband layers, including the header,
+// indexed by submode.
+var widebandLayerBits = [8]int{4, 36, 112, 192, 352, 0, 0, 0}
+
+// size in bits of the payload of in-band signaling messages,
+// indexed by message code.
+var inbandSkipBits = [16]int{1, 1, 4, 4, 4, 4, 4, 4, 8, 8, 16, 16, 32, 32, 64, 64}
+
+const (
+	modeUserInband = 13
+	modeInband     = 14
+	modeTerminator = 15
+
+	// maximum number of layers above narrowband (wideband and ultra-wideband).
+	maxLayers = 2
+)
+
+type bitReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.buf)*8 - r.pos
+}
+
+func (r *bitReader) readBits(n int) (int, error) {
+	if n > r.remaining() {
+		return 0, fmt.Errorf("not enough bits")
+	}
+
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v<<1 | int((r.buf[r.pos>>3]>>(7-(r.pos&0x07)))&0x01)
+		r.pos++
+	}
+
+	return v, nil
+}
+
+func (r *bitReader) skipBits(n int) error {
+	if n > r.remaining() {
+		return fmt.Errorf("not enough bits")
+	}
+	r.pos += n
+	return nil
+}
+
+// frameBits returns the size in bits of the frame that starts at the reader position,
+// including in-band signaling and wideband layers.
+// It returns zero when there are no more frames.
+func frameBits(r *bitReader) (int, error) {
+	start := r.pos
+
+	// in-band signaling and narrowband frame
+	for {
+		if r.remaining() < 5 {
+			return 0, nil
+		}
+
+		wideband, _ := r.readBits(1)
+		if wideband != 0 {
+			return 0, fmt.Errorf("frame doesn't start with a narrowband frame")
+		}
+
+		mode, _ := r.readBits(4)
+
+		switch {
+		case mode == modeTerminator:
+			return 0, nil
+
+		case mode == modeInband:
+			code, err := r.readBits(4)
+			if err != nil {
+				return 0, err
+			}
+
+			err = r.skipBits(inbandSkipBits[code])
+			if err != nil {
+				return 0, err
+			}
+			continue
+
+		case mode == modeUserInband:
+			size, err := r.readBits(4)
+			if err != nil {
+				return 0, err
+			}
+
+			err = r.skipBits(5 + 8*size)
+			if err != nil {
+				return 0, err
+			}
+			continue
+
+		case mode >= len(narrowbandFrameBits):
+			return 0, fmt.Errorf("invalid narrowband mode (%d)", mode)
+		}
+
+		err := r.skipBits(narrowbandFrameBits[mode] - 5)
+		if err != nil {
+			return 0, err
+		}
+		break
+	}
+
+	// wideband and ultra-wideband layers
+	for i := 0; i < maxLayers; i++ {
+		if r.remaining() < 4 {
+			break
+		}
+
+		// peek wideband bit
+		if (r.buf[r.pos>>3]>>(7-(r.pos&0x07)))&0x01 == 0 {
+			break
+		}
+
+		r.pos++
+
+		submode, _ := r.readBits(3)
+		if widebandLayerBits[submode] == 0 {
+			return 0, fmt.Errorf("invalid wideband submode (%d)", submode)
+		}
+
+		err := r.skipBits(widebandLayerBits[submode] - 4)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return r.pos - start, nil
+}
+
+// copyBits copies n bits from src, starting at bit position pos, to dst,
+// starting at bit position dstPos.
+func copyBits(dst []byte, dstPos int, src []byte, pos int, n int) {
+	for i := 0; i < n; i++ {
+		bit := (src[(pos+i)>>3] >> (7 - ((pos + i) & 0x07))) & 0x01
+		dst[(dstPos+i)>>3] |= bit << (7 - ((dstPos + i) & 0x07))
+	}
+}
+
+// pad fills bits after dstPos, until the end of the byte, with a terminator:
+// a zero bit followed by ones.
+func pad(dst []byte, dstPos int) {
+	if (dstPos & 0x07) == 0 {
+		return
+	}
+
+	dst[dstPos>>3] |= 0xFF >> ((dstPos & 0x07) + 1)
+}
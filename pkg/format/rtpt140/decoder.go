@@ -0,0 +1,79 @@
+package rtpt140
+
+import (
+	"errors"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when a packet doesn't contain new text.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// Decoder is a RTP/T.140 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4103
+type Decoder struct {
+	// payload type of RED packets (optional).
+	// When set, packets with this payload type are decoded as RED packets,
+	// and their redundant generations are used to recover lost text.
+	RedundancyPayloadType uint8
+
+	initialized    bool
+	sequenceNumber uint16
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes text from a RTP packet.
+// Text of lost packets is recovered from redundant generations when possible,
+// otherwise it is replaced by a missing text marker (U+FFFD).
+// Duplicate and late packets are discarded, and ErrMorePacketsNeeded is returned
+// when a packet doesn't produce any text.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, error) {
+	var redundant []redundantBlock
+	primary := pkt.Payload
+
+	if d.RedundancyPayloadType != 0 && pkt.PayloadType == d.RedundancyPayloadType {
+		var err error
+		redundant, primary, err = unmarshalRED(pkt.Payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lost := 0
+
+	if d.initialized {
+		diff := int16(pkt.SequenceNumber - d.sequenceNumber)
+		if diff <= 0 {
+			return nil, ErrMorePacketsNeeded
+		}
+		lost = int(diff) - 1
+	}
+
+	d.initialized = true
+	d.sequenceNumber = pkt.SequenceNumber
+
+	var text []byte
+
+	if lost > len(redundant) {
+		text = append(text, missingTextMarker...)
+		lost = len(redundant)
+	}
+
+	// redundant blocks are sorted from the oldest to the newest,
+	// the last one belongs to the previous packet.
+	for _, block := range redundant[len(redundant)-lost:] {
+		text = append(text, block.data...)
+	}
+
+	text = append(text, primary...)
+
+	if len(text) == 0 {
+		return nil, ErrMorePacketsNeeded
+	}
+
+	return text, nil
+}
@@ -0,0 +1,156 @@
+package rtpt140
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				RedundancyPayloadType: 100,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var expected string
+			for _, block := range ca.blocks {
+				expected += block.text
+			}
+
+			var text []byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addText, err := d.Decode(pkt)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+
+				require.NoError(t, err)
+
+				text = append(text, addText...)
+			}
+
+			require.Equal(t, expected, string(text))
+		})
+	}
+}
+
+func TestDecodeRecovery(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           98,
+		RedundancyPayloadType: 100,
+		RedundancyGenerations: 2,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	var pkts []*rtp.Packet
+
+	for i, block := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		var pkt *rtp.Packet
+		pkt, err = e.Encode([]byte(block), uint32(i)*300)
+		require.NoError(t, err)
+		pkts = append(pkts, pkt)
+	}
+
+	d := &Decoder{
+		RedundancyPayloadType: 100,
+	}
+	err = d.Init()
+	require.NoError(t, err)
+
+	var text []byte
+
+	// lose b, c (recovered), e, f, g (only f, g recovered)
+	for _, i := range []int{0, 3, 7} {
+		var addText []byte
+		addText, err = d.Decode(pkts[i])
+		require.NoError(t, err)
+		text = append(text, addText...)
+	}
+
+	// duplicate and late packets
+	for _, i := range []int{3, 7, 2} {
+		_, err = d.Decode(pkts[i])
+		require.Equal(t, ErrMorePacketsNeeded, err)
+	}
+
+	require.Equal(t, "abcd�fgh", string(text))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		payload []byte
+		err     string
+	}{
+		{
+			"missing primary header",
+			[]byte{0xe2, 0x00, 0x00, 0x00},
+			"invalid RED header",
+		},
+		{
+			"truncated header",
+			[]byte{0xe2, 0x00},
+			"invalid RED header",
+		},
+		{
+			"truncated block",
+			[]byte{0xe2, 0x00, 0x00, 0x05, 0x62, 0x01},
+			"invalid RED block length: 5",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				RedundancyPayloadType: 100,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			_, err = d.Decode(&rtp.Packet{
+				Header: rtp.Header{
+					PayloadType: 100,
+				},
+				Payload: ca.payload,
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte) {
+		d := &Decoder{
+			RedundancyPayloadType: 100,
+		}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				PayloadType:    100,
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				PayloadType:    100,
+				SequenceNumber: 17650,
+			},
+			Payload: b,
+		})
+	})
+}
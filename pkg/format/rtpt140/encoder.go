@@ -0,0 +1,167 @@
+package rtpt140
+
+import (
+	"crypto/rand"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+type encoderBlock struct {
+	timestamp uint32
+	data      []byte
+}
+
+// Encoder is a RTP/T.140 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4103
+type Encoder struct {
+	// payload type of T.140 blocks.
+	PayloadType uint8
+
+	// payload type of RED packets.
+	// It is used only when RedundancyGenerations is greater than zero.
+	RedundancyPayloadType uint8
+
+	// number of redundant generations (optional).
+	// When zero, T.140 blocks are sent without redundancy.
+	// RFC4103 recommends 2.
+	RedundancyGenerations int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+	idle           bool
+	history        []*encoderBlock
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.RedundancyGenerations < 0 {
+		return fmt.Errorf("invalid RedundancyGenerations: %d", e.RedundancyGenerations)
+	}
+	if e.RedundancyGenerations > 0 && e.RedundancyPayloadType == 0 {
+		return fmt.Errorf("RedundancyPayloadType is missing")
+	}
+
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	e.idle = true
+	e.history = make([]*encoderBlock, e.RedundancyGenerations)
+	return nil
+}
+
+// Encode encodes a T.140 block into a RTP packet.
+// timestamp is the timestamp of the block and is used to compute
+// the timestamp offsets of redundant generations.
+// When redundancy is enabled, empty blocks should be sent after the last text
+// in order to transmit its redundant generations.
+func (e *Encoder) Encode(block []byte, timestamp uint32) (*rtp.Packet, error) {
+	if !utf8.Valid(block) {
+		return nil, fmt.Errorf("block is not valid UTF-8")
+	}
+
+	var payload []byte
+	var payloadType uint8
+
+	if e.RedundancyGenerations == 0 {
+		if len(block) > e.PayloadMaxSize {
+			return nil, fmt.Errorf("block is too big")
+		}
+
+		payload = make([]byte, len(block))
+		copy(payload, block)
+		payloadType = e.PayloadType
+	} else {
+		if len(block) > maxBlockLength {
+			return nil, fmt.Errorf("block is too big")
+		}
+
+		redundant := make([]redundantBlock, len(e.history))
+
+		for i, prev := range e.history {
+			redundant[i].payloadType = e.PayloadType
+
+			// generations that are missing or too old are sent as empty blocks,
+			// in order to keep their position.
+			if prev != nil && (timestamp-prev.timestamp) <= maxTimestampOffset {
+				redundant[i].timestampOffset = uint16(timestamp - prev.timestamp)
+				redundant[i].data = prev.data
+			}
+		}
+
+		if marshalREDSize(redundant, block) > e.PayloadMaxSize {
+			return nil, fmt.Errorf("block is too big")
+		}
+
+		payload = marshalRED(redundant, e.PayloadType, block)
+		payloadType = e.RedundancyPayloadType
+
+		copy(e.history, e.history[1:])
+		e.history[len(e.history)-1] = &encoderBlock{
+			timestamp: timestamp,
+			data:      append([]byte(nil), block...),
+		}
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    payloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      timestamp,
+			SSRC:           *e.SSRC,
+			// RFC4103: the M-bit shall be set in the first packet
+			// of a session and in the first packet after an idle period.
+			Marker: e.idle && len(block) != 0,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+	e.idle = (len(block) == 0)
+
+	return pkt, nil
+}
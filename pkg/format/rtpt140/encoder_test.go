@@ -0,0 +1,254 @@
+package rtpt140
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+type testBlock struct {
+	timestamp uint32
+	text      string
+}
+
+var cases = []struct {
+	name        string
+	generations int
+	blocks      []testBlock
+	pkts        []*rtp.Packet
+}{
+	{
+		"plain",
+		0,
+		[]testBlock{
+			{0, "Hello"},
+			{300, " wörld"},
+			{600, ""},
+			{900, "!"},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    98,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte("Hello"),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    98,
+					SequenceNumber: 17646,
+					Timestamp:      300,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte(" wörld"),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    98,
+					SequenceNumber: 17647,
+					Timestamp:      600,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    98,
+					SequenceNumber: 17648,
+					Timestamp:      900,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte("!"),
+			},
+		},
+	},
+	{
+		"redundant",
+		2,
+		[]testBlock{
+			{0, "a"},
+			{300, "bc"},
+			{600, ""},
+			{900, ""},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    100,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xe2, 0x00, 0x00, 0x00},
+					[]byte{0xe2, 0x00, 0x00, 0x00},
+					[]byte{0x62},
+					[]byte("a"),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    100,
+					SequenceNumber: 17646,
+					Timestamp:      300,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xe2, 0x00, 0x00, 0x00},
+					[]byte{0xe2, 0x04, 0xb0, 0x01},
+					[]byte{0x62},
+					[]byte("a"),
+					[]byte("bc"),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    100,
+					SequenceNumber: 17647,
+					Timestamp:      600,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xe2, 0x09, 0x60, 0x01},
+					[]byte{0xe2, 0x04, 0xb0, 0x02},
+					[]byte{0x62},
+					[]byte("a"),
+					[]byte("bc"),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    100,
+					SequenceNumber: 17648,
+					Timestamp:      900,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xe2, 0x09, 0x60, 0x02},
+					[]byte{0xe2, 0x04, 0xb0, 0x00},
+					[]byte{0x62},
+					[]byte("bc"),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           98,
+				RedundancyPayloadType: 100,
+				RedundancyGenerations: ca.generations,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkts := make([]*rtp.Packet, len(ca.blocks))
+
+			for i, block := range ca.blocks {
+				pkts[i], err = e.Encode([]byte(block.text), block.timestamp)
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeOldGeneration(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           98,
+		RedundancyPayloadType: 100,
+		RedundancyGenerations: 1,
+		SSRC:                  uint32Ptr(0x9dbb7812),
+		InitialSequenceNumber: uint16Ptr(0x44ed),
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([]byte("a"), 0)
+	require.NoError(t, err)
+
+	pkt, err := e.Encode([]byte("b"), 20000)
+	require.NoError(t, err)
+	require.Equal(t, mergeBytes(
+		[]byte{0xe2, 0x00, 0x00, 0x00},
+		[]byte{0x62},
+		[]byte("b"),
+	), pkt.Payload)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType:           98,
+		RedundancyGenerations: 2,
+	}
+	err := e.Init()
+	require.EqualError(t, err, "RedundancyPayloadType is missing")
+
+	e = &Encoder{
+		PayloadType:           98,
+		RedundancyPayloadType: 100,
+		RedundancyGenerations: 2,
+		PayloadMaxSize:        20,
+	}
+	err = e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode([]byte{0xff, 0xfe}, 0)
+	require.EqualError(t, err, "block is not valid UTF-8")
+
+	_, err = e.Encode([]byte("0123456789ab"), 0)
+	require.EqualError(t, err, "block is too big")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 98,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,100 @@
+// Package rtpt140 contains a RTP/T.140 decoder and encoder.
+package rtpt140
+
+import (
+	"fmt"
+)
+
+const (
+	// maximum timestamp offset of a redundant block.
+	maxTimestampOffset = 0x3FFF
+
+	// maximum length of a redundant block.
+	maxBlockLength = 0x3FF
+
+	// RFC4103: the "missing text marker" (Unicode REPLACEMENT CHARACTER)
+	// is inserted in place of text that cannot be recovered.
+	missingTextMarker = "\uFFFD"
+)
+
+type redundantBlock struct {
+	payloadType     uint8
+	timestampOffset uint16
+	data            []byte
+}
+
+// unmarshalRED decodes a RED payload.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2198
+func unmarshalRED(buf []byte) ([]redundantBlock, []byte, error) {
+	var blocks []redundantBlock
+	n := 0
+
+	for {
+		if len(buf[n:]) < 1 {
+			return nil, nil, fmt.Errorf("invalid RED header")
+		}
+
+		if (buf[n] & 0x80) == 0 {
+			n++
+			break
+		}
+
+		if len(buf[n:]) < 4 {
+			return nil, nil, fmt.Errorf("invalid RED header")
+		}
+
+		blocks = append(blocks, redundantBlock{
+			payloadType:     buf[n] & 0x7F,
+			timestampOffset: uint16(buf[n+1])<<6 | uint16(buf[n+2])>>2,
+			data:            make([]byte, int(buf[n+2]&0x03)<<8|int(buf[n+3])),
+		})
+		n += 4
+	}
+
+	for i := range blocks {
+		le := len(blocks[i].data)
+
+		if len(buf[n:]) < le {
+			return nil, nil, fmt.Errorf("invalid RED block length: %d", le)
+		}
+
+		blocks[i].data = buf[n : n+le]
+		n += le
+	}
+
+	return blocks, buf[n:], nil
+}
+
+func marshalREDSize(blocks []redundantBlock, primary []byte) int {
+	n := 4*len(blocks) + 1 + len(primary)
+	for _, block := range blocks {
+		n += len(block.data)
+	}
+	return n
+}
+
+// marshalRED encodes a RED payload.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2198
+func marshalRED(blocks []redundantBlock, primaryPayloadType uint8, primary []byte) []byte {
+	buf := make([]byte, marshalREDSize(blocks, primary))
+	n := 0
+
+	for _, block := range blocks {
+		buf[n] = 0x80 | block.payloadType
+		buf[n+1] = byte(block.timestampOffset >> 6)
+		buf[n+2] = byte(block.timestampOffset<<2) | byte(len(block.data)>>8)
+		buf[n+3] = byte(len(block.data))
+		n += 4
+	}
+
+	buf[n] = primaryPayloadType
+	n++
+
+	for _, block := range blocks {
+		n += copy(buf[n:], block.data)
+	}
+
+	copy(buf[n:], primary)
+
+	return buf
+}
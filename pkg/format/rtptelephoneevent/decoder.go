@@ -0,0 +1,51 @@
+package rtptelephoneevent
+
+import (
+	"errors"
+
+	"github.com/pion/rtp"
+)
+
+// ErrRedundantEnd is returned when a packet is a retransmission
+// of the end of an event that has already been decoded.
+var ErrRedundantEnd = errors.New("redundant end packet")
+
+// Decoder is a RTP/telephone-event decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type Decoder struct {
+	initialized bool
+	timestamp   uint32
+	ended       bool
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	return nil
+}
+
+// Decode decodes events from a RTP packet.
+// Packets that share the same timestamp belong to the same event;
+// the timestamp of the packet is the start of the event and
+// each packet updates its duration.
+// A packet can also carry the final reports of previous events,
+// that precede the current event (RFC4733, section 2.5.1.5).
+// Once the current event has ended, retransmissions of the end packet
+// are discarded and ErrRedundantEnd is returned.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]*Event, error) {
+	events, err := unmarshalEvents(pkt.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.initialized || pkt.Timestamp != d.timestamp {
+		d.initialized = true
+		d.timestamp = pkt.Timestamp
+		d.ended = false
+	} else if d.ended {
+		return nil, ErrRedundantEnd
+	}
+
+	d.ended = events[len(events)-1].End
+
+	return events, nil
+}
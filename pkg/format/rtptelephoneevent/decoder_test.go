@@ -0,0 +1,124 @@
+package rtptelephoneevent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var events []*Event
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				evts, err := d.Decode(pkt)
+				if errors.Is(err, ErrRedundantEnd) {
+					continue
+				}
+				require.NoError(t, err)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				events = append(events, evts...)
+			}
+
+			require.Equal(t, ca.events, events)
+		})
+	}
+}
+
+func TestDecodeRedundantEnd(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	end := &rtp.Packet{
+		Header: rtp.Header{
+			Timestamp: 1000,
+		},
+		Payload: []byte{0x01, 0x8a, 0x00, 0xa0},
+	}
+
+	evts, err := d.Decode(end)
+	require.NoError(t, err)
+	require.Equal(t, []*Event{{Code: 1, End: true, Volume: 10, Duration: 160}}, evts)
+
+	_, err = d.Decode(end)
+	require.Equal(t, ErrRedundantEnd, err)
+
+	// a new event with the same code is distinguished by its timestamp
+	evts, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Timestamp: 1160,
+		},
+		Payload: []byte{0x01, 0x0a, 0x00, 0x00},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*Event{{Code: 1, End: false, Volume: 10, Duration: 0}}, evts)
+}
+
+func TestDecodeMultipleEvents(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Timestamp: 1320,
+		},
+		Payload: []byte{
+			0x01, 0x8a, 0x00, 0xa0,
+			0x02, 0x8a, 0x00, 0xa0,
+			0x03, 0x0a, 0x00, 0x50,
+		},
+	}
+
+	evts, err := d.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, []*Event{
+		{Code: 1, End: true, Volume: 10, Duration: 160},
+		{Code: 2, End: true, Volume: 10, Duration: 160},
+		{Code: 3, End: false, Volume: 10, Duration: 80},
+	}, evts)
+
+	// the current event ends
+	pkt.Payload[11] = 0xa0
+	pkt.Payload[9] |= 0x80
+
+	evts, err = d.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, &Event{Code: 3, End: true, Volume: 10, Duration: 160}, evts[2])
+
+	_, err = d.Decode(pkt)
+	require.Equal(t, ErrRedundantEnd, err)
+}
+
+func TestDecodeInvalidPayload(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(&rtp.Packet{Payload: []byte{0x01, 0x02}})
+	require.EqualError(t, err, "invalid payload size (2)")
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		d.Decode(&rtp.Packet{Payload: a}) //nolint:errcheck
+		d.Decode(&rtp.Packet{Payload: b}) //nolint:errcheck
+	})
+}
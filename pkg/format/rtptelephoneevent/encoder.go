@@ -0,0 +1,110 @@
+package rtptelephoneevent
+
+import (
+	"crypto/rand"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+
+	// RFC4733: the final packet for each event [...] SHOULD be
+	// sent a total of three times.
+	defaultRedundantEndCount = 2
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/telephone-event encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// number of retransmissions of the end packet (optional).
+	// It defaults to 2.
+	RedundantEndCount *int
+
+	sequenceNumber uint16
+	inProgress     bool
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.RedundantEndCount == nil {
+		v := defaultRedundantEndCount
+		e.RedundantEndCount = &v
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode encodes an event, or an update of an event in progress, into RTP packets.
+// The first packet of each event has the marker bit set.
+// When the event ends, the end packet is retransmitted RedundantEndCount times.
+// Timestamps of packets are relative to the start of the event.
+func (e *Encoder) Encode(evt *Event) ([]*rtp.Packet, error) {
+	payload, err := evt.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	n := 1
+	if evt.End {
+		n += *e.RedundantEndCount
+	}
+
+	ret := make([]*rtp.Packet, n)
+
+	for i := range ret {
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      0,
+				SSRC:           *e.SSRC,
+				Marker:         (i == 0 && !e.inProgress),
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+	}
+
+	e.inProgress = !evt.End
+
+	return ret, nil
+}
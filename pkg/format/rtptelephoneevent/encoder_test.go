@@ -0,0 +1,180 @@
+package rtptelephoneevent
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var cases = []struct {
+	name   string
+	events []*Event
+	pkts   []*rtp.Packet
+}{
+	{
+		"single",
+		[]*Event{{
+			Code:     5,
+			End:      false,
+			Volume:   10,
+			Duration: 0,
+		}},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    101,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x0a, 0x00, 0x00},
+			},
+		},
+	},
+	{
+		"updates and end",
+		[]*Event{
+			{
+				Code:     11,
+				Volume:   10,
+				Duration: 400,
+			},
+			{
+				Code:     11,
+				Volume:   10,
+				Duration: 800,
+			},
+			{
+				Code:     11,
+				End:      true,
+				Volume:   10,
+				Duration: 1000,
+			},
+		},
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    101,
+					SequenceNumber: 17645,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x0a, 0x01, 0x90},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17646,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x0a, 0x03, 0x20},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17647,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x8a, 0x03, 0xe8},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17648,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x8a, 0x03, 0xe8},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17649,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x8a, 0x03, 0xe8},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           101,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			var pkts []*rtp.Packet
+
+			for _, evt := range ca.events {
+				addPkts, err := e.Encode(evt)
+				require.NoError(t, err)
+				pkts = append(pkts, addPkts...)
+			}
+
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeNextEvent(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 101,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	pkts, err := e.Encode(&Event{Code: 1, End: true, Duration: 160})
+	require.NoError(t, err)
+	require.Len(t, pkts, 3)
+	require.True(t, pkts[0].Marker)
+
+	pkts, err = e.Encode(&Event{Code: 2, Duration: 160})
+	require.NoError(t, err)
+	require.Len(t, pkts, 1)
+	require.True(t, pkts[0].Marker)
+}
+
+func TestEncodeInvalidVolume(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 101,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode(&Event{Code: 1, Volume: 64})
+	require.EqualError(t, err, "invalid volume (64)")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 101,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,75 @@
+package rtptelephoneevent
+
+import (
+	"fmt"
+)
+
+const (
+	eventSize = 4
+)
+
+// Event is a telephone event.
+type Event struct {
+	// event code.
+	// DTMF digits 0-9 are coded as 0-9, * as 10, # as 11, A-D as 12-15.
+	Code uint8
+
+	// whether the event has ended.
+	End bool
+
+	// volume, expressed as power level in -dBm0 (0-63).
+	Volume uint8
+
+	// duration of the event since its start, in clock units.
+	Duration uint16
+}
+
+func (e *Event) unmarshal(buf []byte) error {
+	if len(buf) != eventSize {
+		return fmt.Errorf("invalid payload size (%d)", len(buf))
+	}
+
+	e.Code = buf[0]
+	e.End = (buf[1] >> 7) != 0
+	e.Volume = buf[1] & 0x3F
+	e.Duration = uint16(buf[2])<<8 | uint16(buf[3])
+
+	return nil
+}
+
+func unmarshalEvents(buf []byte) ([]*Event, error) {
+	if len(buf) == 0 || (len(buf)%eventSize) != 0 {
+		return nil, fmt.Errorf("invalid payload size (%d)", len(buf))
+	}
+
+	events := make([]*Event, len(buf)/eventSize)
+
+	for i := range events {
+		events[i] = &Event{}
+		err := events[i].unmarshal(buf[i*eventSize : (i+1)*eventSize])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+func (e Event) marshal() ([]byte, error) {
+	if e.Volume > 63 {
+		return nil, fmt.Errorf("invalid volume (%d)", e.Volume)
+	}
+
+	buf := make([]byte, eventSize)
+	buf[0] = e.Code
+
+	if e.End {
+		buf[1] = 1 << 7
+	}
+
+	buf[1] |= e.Volume
+	buf[2] = byte(e.Duration >> 8)
+	buf[3] = byte(e.Duration)
+
+	return buf, nil
+}
@@ -0,0 +1,2 @@
+// Package rtptelephoneevent contains a RTP/telephone-event decoder and encoder.
+package rtptelephoneevent
@@ -0,0 +1,161 @@
+package rtpulpfec
+
+import (
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	historySize = 64
+)
+
+type historyEntry struct {
+	sequenceNumber uint16
+	byts           []byte
+}
+
+// Decoder is a RTP/ULPFEC decoder.
+// It recovers lost media packets by combining FEC packets
+// with media packets that have been received.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5109
+type Decoder struct {
+	history   []*historyEntry
+	ssrc      uint32
+	ssrcKnown bool
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	d.history = make([]*historyEntry, historySize)
+	return nil
+}
+
+// AddMedia adds a received media packet to the packets that can be used for recovery.
+func (d *Decoder) AddMedia(pkt *rtp.Packet) {
+	byts, err := pkt.Marshal()
+	if err != nil {
+		return
+	}
+
+	d.history[pkt.SequenceNumber%historySize] = &historyEntry{
+		sequenceNumber: pkt.SequenceNumber,
+		byts:           byts,
+	}
+	d.ssrc = pkt.SSRC
+	d.ssrcKnown = true
+}
+
+func (d *Decoder) findMedia(seqNum uint16) []byte {
+	entry := d.history[seqNum%historySize]
+	if entry == nil || entry.sequenceNumber != seqNum {
+		return nil
+	}
+	return entry.byts
+}
+
+// Decode decodes a FEC packet.
+// It returns the media packets that have been recovered, if any.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]*rtp.Packet, error) {
+	if len(pkt.Payload) < (fecHeaderSize + levelHeaderShortSize) {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	if (pkt.Payload[0] >> 7) != 0 {
+		return nil, fmt.Errorf("extension flag is not supported")
+	}
+
+	longMask := (pkt.Payload[0] >> 6 & 0x01) != 0
+	snBase := uint16(pkt.Payload[2])<<8 | uint16(pkt.Payload[3])
+
+	levelHeaderSize := levelHeaderShortSize
+	maskLen := maskShortLen
+	if longMask {
+		levelHeaderSize = levelHeaderLongSize
+		maskLen = maskLongLen
+	}
+
+	if len(pkt.Payload) < (fecHeaderSize + levelHeaderSize) {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	protectionLen := int(uint16(pkt.Payload[fecHeaderSize])<<8 | uint16(pkt.Payload[fecHeaderSize+1]))
+	fecPayload := pkt.Payload[fecHeaderSize+levelHeaderSize:]
+
+	if len(fecPayload) < protectionLen {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	mask := pkt.Payload[fecHeaderSize+2 : fecHeaderSize+levelHeaderSize]
+
+	var received [][]byte
+	missing := -1
+
+	for i := 0; i < maskLen; i++ {
+		if (mask[i/8] & (1 << (7 - i%8))) == 0 {
+			continue
+		}
+
+		seqNum := snBase + uint16(i)
+
+		byts := d.findMedia(seqNum)
+		if byts != nil {
+			received = append(received, byts)
+			continue
+		}
+
+		// at most a single packet can be recovered
+		if missing >= 0 {
+			return nil, nil
+		}
+		missing = int(seqNum)
+	}
+
+	if missing < 0 || !d.ssrcKnown {
+		return nil, nil
+	}
+
+	recovery := make([]byte, fecHeaderSize)
+	copy(recovery, pkt.Payload[:fecHeaderSize])
+	recoveredPayload := make([]byte, protectionLen)
+	copy(recoveredPayload, fecPayload[:protectionLen])
+
+	for _, byts := range received {
+		if (len(byts) - rtpFixedHeaderSize) > protectionLen {
+			return nil, fmt.Errorf("protected packet is bigger than protection length")
+		}
+
+		xorRecoveryFields(recovery, byts)
+		xorPayload(recoveredPayload, byts)
+	}
+
+	le := int(uint16(recovery[8])<<8 | uint16(recovery[9]))
+	if le > protectionLen {
+		return nil, fmt.Errorf("recovered packet is bigger than protection length")
+	}
+
+	byts := make([]byte, rtpFixedHeaderSize+le)
+	byts[0] = rtpVersion<<6 | (recovery[0] & recoveryBitsMask)
+	byts[1] = recovery[1]
+	byts[2] = byte(missing >> 8)
+	byts[3] = byte(missing)
+	copy(byts[4:8], recovery[4:8])
+	byts[8] = byte(d.ssrc >> 24)
+	byts[9] = byte(d.ssrc >> 16)
+	byts[10] = byte(d.ssrc >> 8)
+	byts[11] = byte(d.ssrc)
+	copy(byts[rtpFixedHeaderSize:], recoveredPayload[:le])
+
+	var recovered rtp.Packet
+	err := recovered.Unmarshal(byts)
+	if err != nil {
+		return nil, err
+	}
+
+	d.history[recovered.SequenceNumber%historySize] = &historyEntry{
+		sequenceNumber: recovered.SequenceNumber,
+		byts:           byts,
+	}
+
+	return []*rtp.Packet{&recovered}, nil
+}
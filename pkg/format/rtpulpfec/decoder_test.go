@@ -0,0 +1,83 @@
+package rtpulpfec
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			for lost := range ca.media {
+				d := &Decoder{}
+				err := d.Init()
+				require.NoError(t, err)
+
+				for i, pkt := range ca.media {
+					if i != lost {
+						d.AddMedia(pkt)
+					}
+				}
+
+				recovered, err := d.Decode(ca.fec)
+				require.NoError(t, err)
+				require.Len(t, recovered, 1)
+
+				byts1, err := recovered[0].Marshal()
+				require.NoError(t, err)
+				byts2, err := ca.media[lost].Marshal()
+				require.NoError(t, err)
+				require.Equal(t, byts2, byts1)
+
+				// packet has already been recovered
+				recovered, err = d.Decode(ca.fec)
+				require.NoError(t, err)
+				require.Equal(t, []*rtp.Packet(nil), recovered)
+			}
+		})
+	}
+}
+
+func TestDecodeMultipleLosses(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	d.AddMedia(cases[1].media[0])
+
+	recovered, err := d.Decode(cases[1].fec)
+	require.NoError(t, err)
+	require.Equal(t, []*rtp.Packet(nil), recovered)
+}
+
+func FuzzDecoder(f *testing.F) {
+	for _, ca := range cases {
+		f.Add(ca.fec.Payload)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		if err != nil {
+			panic(err)
+		}
+
+		d.AddMedia(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				SequenceNumber: 100,
+				SSRC:           0x11223344,
+			},
+			Payload: []byte{1, 2, 3},
+		})
+
+		d.Decode(&rtp.Packet{ //nolint:errcheck
+			Header: rtp.Header{
+				Version: 2,
+			},
+			Payload: b,
+		})
+	})
+}
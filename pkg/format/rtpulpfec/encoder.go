@@ -0,0 +1,132 @@
+package rtpulpfec
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Encoder is a RTP/ULPFEC encoder.
+// It generates FEC packets that allow to recover a lost packet
+// among a group of media packets.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5109
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+// Encode generates a FEC packet that protects the given media packets.
+// Media packets must have consecutive sequence numbers.
+func (e *Encoder) Encode(pkts []*rtp.Packet) (*rtp.Packet, error) {
+	if len(pkts) == 0 || len(pkts) > maxProtectedPackets {
+		return nil, fmt.Errorf("number of packets must be between 1 and %d", maxProtectedPackets)
+	}
+
+	byts := make([][]byte, len(pkts))
+	protectionLen := 0
+
+	for i, pkt := range pkts {
+		if pkt.SequenceNumber != pkts[0].SequenceNumber+uint16(i) {
+			return nil, fmt.Errorf("packets are not consecutive")
+		}
+
+		var err error
+		byts[i], err = pkt.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		if le := len(byts[i]) - rtpFixedHeaderSize; le > protectionLen {
+			protectionLen = le
+		}
+	}
+
+	longMask := len(pkts) > maskShortLen
+
+	levelHeaderSize := levelHeaderShortSize
+	if longMask {
+		levelHeaderSize = levelHeaderLongSize
+	}
+
+	payload := make([]byte, fecHeaderSize+levelHeaderSize+protectionLen)
+
+	for _, b := range byts {
+		xorRecoveryFields(payload, b)
+		xorPayload(payload[fecHeaderSize+levelHeaderSize:], b)
+	}
+
+	if longMask {
+		payload[0] |= 1 << 6
+	}
+
+	payload[2] = byte(pkts[0].SequenceNumber >> 8)
+	payload[3] = byte(pkts[0].SequenceNumber)
+
+	payload[fecHeaderSize] = byte(protectionLen >> 8)
+	payload[fecHeaderSize+1] = byte(protectionLen)
+
+	for i := range pkts {
+		payload[fecHeaderSize+2+i/8] |= 1 << (7 - i%8)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      pkts[len(pkts)-1].Timestamp,
+			SSRC:           *e.SSRC,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt, nil
+}
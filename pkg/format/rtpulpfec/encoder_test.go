@@ -0,0 +1,141 @@
+package rtpulpfec
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+var cases = []struct {
+	name  string
+	media []*rtp.Packet
+	fec   *rtp.Packet
+}{
+	{
+		"short mask",
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 100,
+					Timestamp:      1000,
+					SSRC:           0x11223344,
+				},
+				Payload: []byte{1, 2, 3},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 101,
+					Timestamp:      1000,
+					SSRC:           0x11223344,
+				},
+				Payload: []byte{4, 5},
+			},
+		},
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    127,
+				SequenceNumber: 17645,
+				Timestamp:      1000,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{
+				0x00, 0x80, 0x00, 0x64, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x01, 0x00, 0x03, 0xc0, 0x00, 0x05, 0x07,
+				0x03,
+			},
+		},
+	},
+	{
+		"long mask",
+		func() []*rtp.Packet {
+			var pkts []*rtp.Packet
+			for i := 0; i < 20; i++ {
+				pkts = append(pkts, &rtp.Packet{
+					Header: rtp.Header{
+						Version:        2,
+						PayloadType:    96,
+						SequenceNumber: 65530 + uint16(i),
+						Timestamp:      1000,
+						SSRC:           0x11223344,
+					},
+					Payload: []byte{byte(i)},
+				})
+			}
+			return pkts
+		}(),
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    127,
+				SequenceNumber: 17645,
+				Timestamp:      1000,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{
+				0x40, 0x00, 0xff, 0xfa, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x01, 0xff, 0xff, 0xf0, 0x00,
+				0x00, 0x00, 0x00,
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:           127,
+				SSRC:                  uint32Ptr(0x9dbb7812),
+				InitialSequenceNumber: uint16Ptr(0x44ed),
+			}
+			err := e.Init()
+			require.NoError(t, err)
+
+			pkt, err := e.Encode(ca.media)
+			require.NoError(t, err)
+			require.Equal(t, ca.fec, pkt)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 127,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+
+	_, err = e.Encode(nil)
+	require.EqualError(t, err, "number of packets must be between 1 and 48")
+
+	_, err = e.Encode([]*rtp.Packet{
+		{Header: rtp.Header{SequenceNumber: 1}},
+		{Header: rtp.Header{SequenceNumber: 3}},
+	})
+	require.EqualError(t, err, "packets are not consecutive")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 127,
+	}
+	err := e.Init()
+	require.NoError(t, err)
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+}
@@ -0,0 +1,38 @@
+// Package rtpulpfec contains a RTP/ULPFEC decoder and encoder.
+package rtpulpfec
+
+const (
+	fecHeaderSize        = 10
+	levelHeaderShortSize = 4
+	levelHeaderLongSize  = 8
+	maskShortLen         = 16
+	maskLongLen          = 48
+	rtpFixedHeaderSize   = 12
+	maxProtectedPackets  = maskLongLen
+
+	// P, X and CC bits of the first header byte
+	recoveryBitsMask = 0x3f
+)
+
+// xorRecoveryFields combines the header fields of a marshaled RTP packet
+// into the recovery fields of a FEC header.
+func xorRecoveryFields(dst []byte, pkt []byte) {
+	dst[0] ^= pkt[0] & recoveryBitsMask
+	dst[1] ^= pkt[1]
+	dst[4] ^= pkt[4]
+	dst[5] ^= pkt[5]
+	dst[6] ^= pkt[6]
+	dst[7] ^= pkt[7]
+
+	le := uint16(len(pkt) - rtpFixedHeaderSize)
+	dst[8] ^= byte(le >> 8)
+	dst[9] ^= byte(le)
+}
+
+// xorPayload combines the part of a marshaled RTP packet
+// that follows the fixed header into a FEC payload.
+func xorPayload(dst []byte, pkt []byte) {
+	for i, b := range pkt[rtpFixedHeaderSize:] {
+		dst[i] ^= b
+	}
+}
@@ -0,0 +1,226 @@
+package rtpvorbis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented packet and we didn't received anything before.
+// It's normal to receive this when decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+func joinFragments(fragments [][]byte, size int) []byte {
+	ret := make([]byte, size)
+	n := 0
+	for _, p := range fragments {
+		n += copy(ret[n:], p)
+	}
+	return ret
+}
+
+// Decoder is a RTP/Vorbis decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5215
+type Decoder struct {
+	// packed configuration (optional).
+	// It is the content of the "configuration" format parameter.
+	Configuration []byte
+
+	configurations map[uint32][][]byte
+	ident          uint32
+
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsIdent      uint32
+	fragmentsType       uint8
+	fragmentNextSeqNum  uint16
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() error {
+	d.configurations = make(map[uint32][][]byte)
+
+	if d.Configuration != nil {
+		var err error
+		d.configurations, err = UnmarshalConfiguration(d.Configuration)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Headers returns the identification, comment and setup headers
+// of the configuration used by the last decoded packets.
+func (d *Decoder) Headers() [][]byte {
+	return d.configurations[d.ident]
+}
+
+// Decode decodes Vorbis packets from a RTP packet.
+// In-band configurations are stored and can be retrieved with Headers().
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, error) {
+	if len(pkt.Payload) < 4 {
+		d.resetFragments()
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	ident := uint32(pkt.Payload[0])<<16 | uint32(pkt.Payload[1])<<8 | uint32(pkt.Payload[2])
+	fragmentType := pkt.Payload[3] >> 6
+	dataType := (pkt.Payload[3] >> 4) & 0x03
+	count := int(pkt.Payload[3] & 0x0F)
+	payload := pkt.Payload[4:]
+
+	var packets [][]byte
+
+	switch fragmentType {
+	case fragmentTypeNone:
+		d.resetFragments()
+
+		if count == 0 {
+			return nil, fmt.Errorf("invalid packet count")
+		}
+
+		for i := 0; i < count; i++ {
+			if len(payload) < 2 {
+				return nil, fmt.Errorf("payload is too short")
+			}
+
+			size := int(uint16(payload[0])<<8 | uint16(payload[1]))
+			payload = payload[2:]
+
+			if size == 0 || size > len(payload) {
+				return nil, fmt.Errorf("invalid packet size")
+			}
+
+			packets = append(packets, payload[:size])
+			payload = payload[size:]
+		}
+
+		d.firstPacketReceived = true
+
+	case fragmentTypeStart:
+		d.resetFragments()
+
+		if len(payload) < 2 {
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		size := int(uint16(payload[0])<<8 | uint16(payload[1]))
+		payload = payload[2:]
+
+		if size == 0 || size > len(payload) {
+			return nil, fmt.Errorf("invalid fragment size")
+		}
+
+		d.fragments = append(d.fragments, payload[:size])
+		d.fragmentsSize = size
+		d.fragmentsIdent = ident
+		d.fragmentsType = dataType
+		d.fragmentNextSeqNum = pkt.SequenceNumber + 1
+		d.firstPacketReceived = true
+
+		return nil, ErrMorePacketsNeeded
+
+	default:
+		if d.fragmentsSize == 0 {
+			if !d.firstPacketReceived {
+				return nil, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, fmt.Errorf("received a non-starting fragment")
+		}
+
+		if pkt.SequenceNumber != d.fragmentNextSeqNum {
+			d.resetFragments()
+			return nil, fmt.Errorf("discarding frame since a RTP packet is missing")
+		}
+
+		if ident != d.fragmentsIdent || dataType != d.fragmentsType {
+			d.resetFragments()
+			return nil, fmt.Errorf("fragment doesn't belong to the current packet")
+		}
+
+		if len(payload) < 2 {
+			d.resetFragments()
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		size := int(uint16(payload[0])<<8 | uint16(payload[1]))
+		payload = payload[2:]
+
+		if size == 0 || size > len(payload) {
+			d.resetFragments()
+			return nil, fmt.Errorf("invalid fragment size")
+		}
+
+		d.fragmentsSize += size
+
+		if d.fragmentsSize > maxPacketSize {
+			errSize := d.fragmentsSize
+			d.resetFragments()
+			return nil, fmt.Errorf("packet size (%d) is too big, maximum is %d",
+				errSize, maxPacketSize)
+		}
+
+		d.fragments = append(d.fragments, payload[:size])
+		d.fragmentNextSeqNum++
+
+		if fragmentType != fragmentTypeEnd {
+			return nil, ErrMorePacketsNeeded
+		}
+
+		packets = [][]byte{joinFragments(d.fragments, d.fragmentsSize)}
+		d.resetFragments()
+	}
+
+	switch dataType {
+	case dataTypeRaw:
+		if _, ok := d.configurations[ident]; !ok {
+			return nil, fmt.Errorf("received packets with unknown configuration (%d)", ident)
+		}
+
+		d.ident = ident
+		return packets, nil
+
+	case dataTypeConfiguration:
+		for _, packet := range packets {
+			headers, err := unmarshalHeaders(packet)
+			if err != nil {
+				return nil, err
+			}
+
+			// copy headers since they are stored
+			for i, header := range headers {
+				headers[i] = append([]byte(nil), header...)
+			}
+
+			if _, ok := d.configurations[ident]; !ok && len(d.configurations) >= maxConfigurations {
+				return nil, fmt.Errorf("configuration count exceeds maximum allowed (%d)", maxConfigurations)
+			}
+
+			d.configurations[ident] = headers
+		}
+
+		return nil, ErrMorePacketsNeeded
+
+	case dataTypeComment:
+		// legacy comment packets are not needed to decode the stream
+		return nil, ErrMorePacketsNeeded
+	}
+
+	return nil, fmt.Errorf("unsupported data type (%d)", dataType)
+}
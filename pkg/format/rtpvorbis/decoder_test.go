@@ -0,0 +1,175 @@
+package rtpvorbis
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+var testHeaders = [][]byte{
+	{0x01, 0x76, 0x6f, 0x72, 0x62, 0x69, 0x73},
+	{0x03, 0x76, 0x6f, 0x72, 0x62, 0x69, 0x73},
+	bytes.Repeat([]byte{0x05}, 300),
+}
+
+func TestDecode(t *testing.T) {
+	conf, err := MarshalConfiguration(0x123456, testHeaders)
+	require.NoError(t, err)
+
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				Configuration: conf,
+			}
+			err := d.Init()
+			require.NoError(t, err)
+
+			var packets [][]byte
+
+			for _, pkt := range ca.pkts {
+				clone := pkt.Clone()
+
+				addPackets, err := d.Decode(pkt)
+
+				// test input integrity
+				require.Equal(t, clone, pkt)
+
+				if errors.Is(err, ErrMorePacketsNeeded) {
+					continue
+				}
+
+				require.NoError(t, err)
+				packets = append(packets, addPackets...)
+			}
+
+			require.Equal(t, ca.packets, packets)
+			require.Equal(t, testHeaders, d.Headers())
+		})
+	}
+}
+
+func TestDecodeConfiguration(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(cases[0].pkts[0])
+	require.EqualError(t, err, "received packets with unknown configuration (1193046)")
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         false,
+			PayloadType:    96,
+			SequenceNumber: 17644,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{
+			0x12, 0x34, 0x56, 0x11, 0x00, 0x09, 0x02, 0x02,
+			0x01, 0x01, 0x02, 0x03, 0x05, 0x06, 0x07,
+		},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	packets, err := d.Decode(cases[0].pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, cases[0].packets, packets)
+	require.Equal(t, [][]byte{
+		{0x01, 0x02},
+		{0x03},
+		{0x05, 0x06, 0x07},
+	}, d.Headers())
+}
+
+func TestConfigurationMarshal(t *testing.T) {
+	conf, err := MarshalConfiguration(0x123456, testHeaders)
+	require.NoError(t, err)
+	require.Equal(t, mergeBytes(
+		[]byte{
+			0x00, 0x00, 0x00, 0x01, 0x12, 0x34, 0x56, 0x01,
+			0x3a, 0x02, 0x07, 0x07,
+		},
+		testHeaders[0],
+		testHeaders[1],
+		testHeaders[2],
+	), conf)
+
+	confs, err := UnmarshalConfiguration(conf)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32][][]byte{0x123456: testHeaders}, confs)
+}
+
+func TestDecodeErrorMissingPacket(t *testing.T) {
+	d := &Decoder{}
+	err := d.Init()
+	require.NoError(t, err)
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         false,
+			PayloadType:    96,
+			SequenceNumber: 17645,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x12, 0x34, 0x56, 0x40, 0x00, 0x02, 0x01, 0x02},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         false,
+			PayloadType:    96,
+			SequenceNumber: 17647,
+			SSRC:           0x9dbb7812,
+		},
+		Payload: []byte{0x12, 0x34, 0x56, 0xc0, 0x00, 0x02, 0x03, 0x04},
+	})
+	require.EqualError(t, err, "discarding frame since a RTP packet is missing")
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a []byte, b []byte) {
+		d := &Decoder{}
+		err := d.Init()
+		require.NoError(t, err)
+
+		packets, err := d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				SequenceNumber: 17645,
+			},
+			Payload: a,
+		})
+
+		if errors.Is(err, ErrMorePacketsNeeded) {
+			packets, err = d.Decode(&rtp.Packet{
+				Header: rtp.Header{
+					SequenceNumber: 17646,
+				},
+				Payload: b,
+			})
+		}
+
+		if err == nil {
+			if len(packets) == 0 {
+				t.Errorf("should not happen")
+			}
+
+			for _, packet := range packets {
+				if len(packet) == 0 {
+					t.Errorf("should not happen")
+				}
+			}
+		}
+	})
+}
+
+func FuzzUnmarshalConfiguration(f *testing.F) {
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		UnmarshalConfiguration(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,230 @@
+package rtpvorbis
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion            = 2
+	defaultPayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+func packetCount(avail, le int) int {
+	n := le / avail
+	if (le % avail) != 0 {
+		n++
+	}
+	return n
+}
+
+// Encoder is a RTP/Vorbis encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5215
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	// identifier of the configuration (24 bits).
+	Ident uint32
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() error {
+	if e.Ident > 0xFFFFFF {
+		return fmt.Errorf("invalid Ident")
+	}
+
+	if e.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		v2 := uint16(v)
+		e.InitialSequenceNumber = &v2
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = defaultPayloadMaxSize
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+	return nil
+}
+
+func lenPacked(packets [][]byte, addPacket []byte) int {
+	n := 4 // header
+
+	for _, packet := range packets {
+		n += 2 + len(packet)
+	}
+
+	if addPacket != nil {
+		n += 2 + len(addPacket)
+	}
+
+	return n
+}
+
+// Encode encodes Vorbis packets into RTP packets.
+// Vorbis packets that fit into a RTP packet are packed together,
+// the others are fragmented.
+func (e *Encoder) Encode(packets [][]byte) ([]*rtp.Packet, error) {
+	return e.encode(packets, dataTypeRaw)
+}
+
+// EncodeConfiguration encodes the identification, comment and setup headers
+// into in-band configuration packets.
+func (e *Encoder) EncodeConfiguration(headers [][]byte) ([]*rtp.Packet, error) {
+	if len(headers) == 0 || len(headers) > 256 {
+		return nil, fmt.Errorf("invalid header count")
+	}
+
+	buf := make([]byte, lenHeaders(headers))
+	marshalHeaders(buf, headers)
+
+	return e.encode([][]byte{buf}, dataTypeConfiguration)
+}
+
+func (e *Encoder) encode(packets [][]byte, dataType uint8) ([]*rtp.Packet, error) {
+	var rets []*rtp.Packet
+	var batch [][]byte
+
+	for _, packet := range packets {
+		if len(packet) == 0 {
+			return nil, fmt.Errorf("invalid packet")
+		}
+
+		if len(batch) < maxPacketsPerPayload &&
+			lenPacked(batch, packet) <= e.PayloadMaxSize {
+			batch = append(batch, packet)
+			continue
+		}
+
+		if batch != nil {
+			rets = append(rets, e.writePacked(batch, dataType))
+			batch = nil
+		}
+
+		if lenPacked(nil, packet) <= e.PayloadMaxSize {
+			batch = [][]byte{packet}
+		} else {
+			rets = append(rets, e.writeFragmented(packet, dataType)...)
+		}
+	}
+
+	if batch != nil {
+		rets = append(rets, e.writePacked(batch, dataType))
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writeHeader(buf []byte, fragmentType uint8, dataType uint8, count int) {
+	buf[0] = byte(e.Ident >> 16)
+	buf[1] = byte(e.Ident >> 8)
+	buf[2] = byte(e.Ident)
+	buf[3] = fragmentType<<6 | dataType<<4 | byte(count)
+}
+
+func (e *Encoder) writePacked(packets [][]byte, dataType uint8) *rtp.Packet {
+	payload := make([]byte, lenPacked(packets, nil))
+	e.writeHeader(payload, fragmentTypeNone, dataType, len(packets))
+	n := 4
+
+	for _, packet := range packets {
+		payload[n] = byte(len(packet) >> 8)
+		payload[n+1] = byte(len(packet))
+		n += 2
+		n += copy(payload[n:], packet)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
+
+func (e *Encoder) writeFragmented(packet []byte, dataType uint8) []*rtp.Packet {
+	avail := e.PayloadMaxSize - 6
+	le := len(packet)
+	packetCount := packetCount(avail, le)
+
+	ret := make([]*rtp.Packet, packetCount)
+	le = avail
+
+	for i := range ret {
+		var fragmentType uint8
+		switch {
+		case i == 0:
+			fragmentType = fragmentTypeStart
+		case i == (packetCount - 1):
+			fragmentType = fragmentTypeEnd
+			le = len(packet)
+		default:
+			fragmentType = fragmentTypeContinuation
+		}
+
+		payload := make([]byte, 6+le)
+		e.writeHeader(payload, fragmentType, dataType, 0)
+		payload[4] = byte(le >> 8)
+		payload[5] = byte(le)
+		copy(payload[6:], packet)
+		packet = packet[le:]
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				SSRC:           *e.SSRC,
+				Marker:         false,
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+	}
+
+	return ret
+}
@@ -0,0 +1,214 @@
+// Package rtpvorbis contains a RTP/Vorbis decoder and encoder.
+package rtpvorbis
+
+import (
+	"fmt"
+)
+
+const (
+	// maximum size of a Vorbis packet or of a packed configuration.
+	maxPacketSize = 1 * 1024 * 1024
+
+	// maximum number of complete Vorbis packets in a RTP packet.
+	maxPacketsPerPayload = 15
+
+	// maximum number of configurations stored by the decoder.
+	maxConfigurations = 16
+)
+
+// fragment types.
+const (
+	fragmentTypeNone         = 0
+	fragmentTypeStart        = 1
+	fragmentTypeContinuation = 2
+	fragmentTypeEnd          = 3
+)
+
+// Vorbis data types.
+const (
+	dataTypeRaw           = 0
+	dataTypeConfiguration = 1
+	dataTypeComment       = 2
+)
+
+// lenLacing returns the size of a Xiph lacing value.
+func lenLacing(v int) int {
+	return v/255 + 1
+}
+
+func marshalLacing(buf []byte, v int) int {
+	n := 0
+	for v >= 255 {
+		buf[n] = 255
+		n++
+		v -= 255
+	}
+	buf[n] = byte(v)
+	return n + 1
+}
+
+func unmarshalLacing(buf []byte) (int, int, error) {
+	v := 0
+	n := 0
+
+	for {
+		if n >= len(buf) {
+			return 0, 0, fmt.Errorf("invalid lacing value")
+		}
+
+		b := buf[n]
+		n++
+		v += int(b)
+
+		if b != 255 {
+			return v, n, nil
+		}
+	}
+}
+
+// unmarshalHeaders decodes the number of headers, their lacing sizes and
+// their content. Size of the last header is deduced from the remaining data.
+func unmarshalHeaders(buf []byte) ([][]byte, error) {
+	if len(buf) < 1 {
+		return nil, fmt.Errorf("invalid headers (invalid size)")
+	}
+
+	count := int(buf[0]) + 1
+	buf = buf[1:]
+
+	sizes := make([]int, count)
+	total := 0
+
+	for i := 0; i < (count - 1); i++ {
+		v, n, err := unmarshalLacing(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[n:]
+		sizes[i] = v
+		total += v
+	}
+
+	if total > len(buf) {
+		return nil, fmt.Errorf("invalid headers (invalid size)")
+	}
+	sizes[count-1] = len(buf) - total
+
+	headers := make([][]byte, count)
+	for i, size := range sizes {
+		headers[i] = buf[:size]
+		buf = buf[size:]
+	}
+
+	return headers, nil
+}
+
+func lenHeaders(headers [][]byte) int {
+	n := 1 // number of headers
+
+	for i, header := range headers {
+		if i != (len(headers) - 1) {
+			n += lenLacing(len(header))
+		}
+		n += len(header)
+	}
+
+	return n
+}
+
+func marshalHeaders(buf []byte, headers [][]byte) int {
+	buf[0] = byte(len(headers) - 1)
+	n := 1
+
+	for i, header := range headers {
+		if i != (len(headers) - 1) {
+			n += marshalLacing(buf[n:], len(header))
+		}
+	}
+
+	for _, header := range headers {
+		n += copy(buf[n:], header)
+	}
+
+	return n
+}
+
+// UnmarshalConfiguration decodes a packed configuration,
+// that is the content of the "configuration" format parameter.
+// It returns, for each configuration identifier, the identification, comment
+// and setup headers.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5215#section-3.2.1
+func UnmarshalConfiguration(buf []byte) (map[uint32][][]byte, error) {
+	if len(buf) < 4 {
+		return nil, fmt.Errorf("invalid configuration (invalid size)")
+	}
+
+	count := uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+	buf = buf[4:]
+
+	ret := make(map[uint32][][]byte)
+
+	for i := uint32(0); i < count; i++ {
+		if len(buf) < 6 {
+			return nil, fmt.Errorf("invalid configuration (invalid size)")
+		}
+
+		ident := uint32(buf[0])<<16 | uint32(buf[1])<<8 | uint32(buf[2])
+		length := int(uint16(buf[3])<<8 | uint16(buf[4]))
+		buf = buf[5:]
+
+		// length is the size of headers, without the lacing values
+		headersCount := int(buf[0]) + 1
+		n := 1
+		for j := 0; j < (headersCount - 1); j++ {
+			_, l, err := unmarshalLacing(buf[n:])
+			if err != nil {
+				return nil, err
+			}
+			n += l
+		}
+
+		if len(buf) < (n + length) {
+			return nil, fmt.Errorf("invalid configuration (invalid size)")
+		}
+
+		headers, err := unmarshalHeaders(buf[:n+length])
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[n+length:]
+
+		ret[ident] = headers
+	}
+
+	return ret, nil
+}
+
+// MarshalConfiguration encodes a packed configuration
+// that contains the identification, comment and setup headers
+// associated with a configuration identifier.
+func MarshalConfiguration(ident uint32, headers [][]byte) ([]byte, error) {
+	if len(headers) == 0 || len(headers) > 256 {
+		return nil, fmt.Errorf("invalid header count")
+	}
+
+	length := 0
+	for _, header := range headers {
+		length += len(header)
+	}
+
+	if length > 0xFFFF {
+		return nil, fmt.Errorf("headers are too big")
+	}
+
+	buf := make([]byte, 4+5+lenHeaders(headers))
+	buf[3] = 1
+	buf[4] = byte(ident >> 16)
+	buf[5] = byte(ident >> 8)
+	buf[6] = byte(ident)
+	buf[7] = byte(length >> 8)
+	buf[8] = byte(length)
+	marshalHeaders(buf[9:], headers)
+
+	return buf, nil
+}
@@ -0,0 +1,99 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pion/rtp"
+)
+
+// RTX is the RTP retransmission payload format.
+// Packets of this format carry retransmissions of the packets of
+// another format of the same media, identified by the associated payload type (apt).
+// Specification: https://datatracker.ietf.org/doc/html/rfc4588
+type RTX struct {
+	PayloadTyp            uint8
+	ClockRat              int
+	AssociatedPayloadType uint8
+
+	// (optional) time, in milliseconds, in which a retransmission can be requested.
+	RTXTime *int
+}
+
+func (f *RTX) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	clockRate, err := strconv.ParseUint(ctx.clock, 10, 31)
+	if err != nil || clockRate == 0 {
+		return fmt.Errorf("invalid clock rate: '%s'", ctx.clock)
+	}
+	f.ClockRat = int(clockRate)
+
+	aptFound := false
+
+	for key, val := range ctx.fmtp {
+		switch key {
+		case "apt":
+			tmp, err := strconv.ParseUint(val, 10, 7)
+			if err != nil {
+				return fmt.Errorf("invalid apt: %v", val)
+			}
+
+			f.AssociatedPayloadType = uint8(tmp)
+			aptFound = true
+
+		case "rtx-time":
+			tmp, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid rtx-time: %v", val)
+			}
+
+			v := int(tmp)
+			f.RTXTime = &v
+		}
+	}
+
+	if !aptFound {
+		return fmt.Errorf("apt is missing")
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *RTX) Codec() string {
+	return "RTX"
+}
+
+// ClockRate implements Format.
+func (f *RTX) ClockRate() int {
+	return f.ClockRat
+}
+
+// PayloadType implements Format.
+func (f *RTX) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *RTX) RTPMap() string {
+	return "rtx/" + strconv.FormatInt(int64(f.ClockRat), 10)
+}
+
+// FMTP implements Format.
+func (f *RTX) FMTP() map[string]string {
+	fmtp := map[string]string{
+		"apt": strconv.FormatUint(uint64(f.AssociatedPayloadType), 10),
+	}
+
+	if f.RTXTime != nil {
+		fmtp["rtx-time"] = strconv.FormatInt(int64(*f.RTXTime), 10)
+	}
+
+	return fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (f *RTX) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
@@ -0,0 +1,162 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpt140"
+)
+
+// RFC4103: If no "red" format is described, [...] it is RECOMMENDED
+// that two redundant generations are used.
+const t140DefaultRedundancyGenerations = 2
+
+// T140 is the RTP format for real-time text (T.140).
+// Text can be protected against packet losses by a RED format
+// of the same media, that refers to this format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4103
+type T140 struct {
+	PayloadTyp uint8
+
+	// maximum number of characters per second (optional).
+	CPS *int
+}
+
+func (f *T140) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	for key, val := range ctx.fmtp {
+		if key == "cps" {
+			tmp, err := strconv.ParseUint(val, 10, 31)
+			if err != nil {
+				return fmt.Errorf("invalid cps: %v", val)
+			}
+
+			v := int(tmp)
+			f.CPS = &v
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *T140) Codec() string {
+	return "T140"
+}
+
+// ClockRate implements Format.
+func (f *T140) ClockRate() int {
+	return 1000
+}
+
+// PayloadType implements Format.
+func (f *T140) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *T140) RTPMap() string {
+	return "t140/1000"
+}
+
+// FMTP implements Format.
+func (f *T140) FMTP() map[string]string {
+	if f.CPS == nil {
+		return nil
+	}
+
+	return map[string]string{
+		"cps": strconv.FormatInt(int64(*f.CPS), 10),
+	}
+}
+
+// PTSEqualsDTS implements Format.
+func (f *T140) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+func (f *T140) redundancyGenerations(red *RED) (int, error) {
+	if len(red.PayloadTypes) == 0 {
+		return t140DefaultRedundancyGenerations, nil
+	}
+
+	for _, pt := range red.PayloadTypes {
+		if pt != f.PayloadTyp {
+			return 0, fmt.Errorf("RED format does not refer to this format")
+		}
+	}
+
+	return len(red.PayloadTypes) - 1, nil
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *T140) CreateDecoder() (*rtpt140.Decoder, error) {
+	d := &rtpt140.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateDecoderWithRedundancy creates a decoder able to decode the content of the format,
+// transmitted with or without the given RED format.
+func (f *T140) CreateDecoderWithRedundancy(red *RED) (*rtpt140.Decoder, error) {
+	_, err := f.redundancyGenerations(red)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &rtpt140.Decoder{
+		RedundancyPayloadType: red.PayloadTyp,
+	}
+
+	err = d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *T140) CreateEncoder() (*rtpt140.Encoder, error) {
+	e := &rtpt140.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// CreateEncoderWithRedundancy creates an encoder able to encode the content of the format,
+// wrapped into the given RED format.
+// The number of redundant generations is taken from the RED format.
+func (f *T140) CreateEncoderWithRedundancy(red *RED) (*rtpt140.Encoder, error) {
+	generations, err := f.redundancyGenerations(red)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &rtpt140.Encoder{
+		PayloadType:           f.PayloadTyp,
+		RedundancyPayloadType: red.PayloadTyp,
+		RedundancyGenerations: generations,
+	}
+
+	err = e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,78 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestT140Attributes(t *testing.T) {
+	format := &T140{
+		PayloadTyp: 98,
+	}
+	require.Equal(t, "T140", format.Codec())
+	require.Equal(t, 1000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestT140DecEncoder(t *testing.T) {
+	format := &T140{
+		PayloadTyp: 98,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkt, err := enc.Encode([]byte("hello"), 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), byts)
+}
+
+func TestT140DecEncoderWithRedundancy(t *testing.T) {
+	format := &T140{
+		PayloadTyp: 98,
+	}
+
+	red := &RED{
+		PayloadTyp:   100,
+		ClockRat:     1000,
+		PayloadTypes: []uint8{98, 98, 98},
+	}
+
+	enc, err := format.CreateEncoderWithRedundancy(red)
+	require.NoError(t, err)
+	require.Equal(t, 2, enc.RedundancyGenerations)
+
+	pkt1, err := enc.Encode([]byte("hello"), 0)
+	require.NoError(t, err)
+	require.Equal(t, red.PayloadType(), pkt1.PayloadType)
+
+	pkt2, err := enc.Encode([]byte(" world"), 300)
+	require.NoError(t, err)
+
+	dec, err := format.CreateDecoderWithRedundancy(red)
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkt1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), byts)
+
+	byts, err = dec.Decode(pkt2)
+	require.NoError(t, err)
+	require.Equal(t, []byte(" world"), byts)
+
+	_, err = format.CreateEncoderWithRedundancy(&RED{
+		PayloadTyp:   100,
+		ClockRat:     1000,
+		PayloadTypes: []uint8{97, 97},
+	})
+	require.EqualError(t, err, "RED format does not refer to this format")
+}
@@ -0,0 +1,158 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtptelephoneevent"
+)
+
+// TelephoneEventRange is a range of telephone events.
+type TelephoneEventRange struct {
+	First uint8
+	Last  uint8
+}
+
+// TelephoneEvent is the RTP format for DTMF digits, telephony tones and signals.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type TelephoneEvent struct {
+	PayloadTyp uint8
+	SampleRate int
+
+	// supported events (optional).
+	// When missing, events 0-15 (DTMF) are supported.
+	Events []TelephoneEventRange
+}
+
+func parseTelephoneEvents(val string) ([]TelephoneEventRange, error) {
+	var ret []TelephoneEventRange
+
+	for _, part := range strings.Split(val, ",") {
+		tmp := strings.SplitN(strings.TrimSpace(part), "-", 2)
+
+		first, err := strconv.ParseUint(tmp[0], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event range: %v", val)
+		}
+
+		last := first
+
+		if len(tmp) == 2 {
+			last, err = strconv.ParseUint(tmp[1], 10, 8)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid event range: %v", val)
+			}
+		}
+
+		ret = append(ret, TelephoneEventRange{
+			First: uint8(first),
+			Last:  uint8(last),
+		})
+	}
+
+	return ret, nil
+}
+
+func (f *TelephoneEvent) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	tmp := strings.SplitN(ctx.clock, "/", 2)
+
+	sampleRate, err := strconv.ParseUint(tmp[0], 10, 31)
+	if err != nil || sampleRate == 0 {
+		return fmt.Errorf("invalid sample rate: '%s'", tmp[0])
+	}
+	f.SampleRate = int(sampleRate)
+
+	if len(tmp) >= 2 {
+		channelCount, err := strconv.ParseUint(tmp[1], 10, 31)
+		if err != nil || channelCount != 1 {
+			return fmt.Errorf("invalid channel count: '%s'", tmp[1])
+		}
+	}
+
+	// the event list is a parameter without value
+	for _, param := range decodeFMTPValuelessParams(ctx.fmtpRaw) {
+		f.Events, err = parseTelephoneEvents(param)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *TelephoneEvent) Codec() string {
+	return "telephone-event"
+}
+
+// ClockRate implements Format.
+func (f *TelephoneEvent) ClockRate() int {
+	return f.SampleRate
+}
+
+// PayloadType implements Format.
+func (f *TelephoneEvent) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *TelephoneEvent) RTPMap() string {
+	return "telephone-event/" + strconv.FormatInt(int64(f.SampleRate), 10)
+}
+
+// FMTP implements Format.
+func (f *TelephoneEvent) FMTP() map[string]string {
+	if len(f.Events) == 0 {
+		return nil
+	}
+
+	tmp := make([]string, len(f.Events))
+
+	for i, r := range f.Events {
+		if r.First == r.Last {
+			tmp[i] = strconv.FormatUint(uint64(r.First), 10)
+		} else {
+			tmp[i] = strconv.FormatUint(uint64(r.First), 10) + "-" + strconv.FormatUint(uint64(r.Last), 10)
+		}
+	}
+
+	return map[string]string{
+		strings.Join(tmp, ","): FMTPValueless,
+	}
+}
+
+// PTSEqualsDTS implements Format.
+func (f *TelephoneEvent) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *TelephoneEvent) CreateDecoder() (*rtptelephoneevent.Decoder, error) {
+	d := &rtptelephoneevent.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *TelephoneEvent) CreateEncoder() (*rtptelephoneevent.Encoder, error) {
+	e := &rtptelephoneevent.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
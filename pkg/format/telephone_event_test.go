@@ -0,0 +1,41 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtptelephoneevent"
+)
+
+func TestTelephoneEventAttributes(t *testing.T) {
+	format := &TelephoneEvent{
+		PayloadTyp: 101,
+		SampleRate: 8000,
+	}
+	require.Equal(t, "telephone-event", format.Codec())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestTelephoneEventDecEncoder(t *testing.T) {
+	format := &TelephoneEvent{
+		PayloadTyp: 101,
+		SampleRate: 8000,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode(&rtptelephoneevent.Event{Code: 3, Volume: 10, Duration: 160})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	evts, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, []*rtptelephoneevent.Event{{Code: 3, Volume: 10, Duration: 160}}, evts)
+}
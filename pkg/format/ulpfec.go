@@ -0,0 +1,87 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpulpfec"
+)
+
+// ULPFEC is the RTP payload format for generic forward error correction.
+// Packets of this format allow to recover lost packets of the medias
+// that belong to the same FEC group.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5109
+type ULPFEC struct {
+	PayloadTyp uint8
+	ClockRat   int
+}
+
+func (f *ULPFEC) unmarshal(ctx *unmarshalContext) error {
+	f.PayloadTyp = ctx.payloadType
+
+	clockRate, err := strconv.ParseUint(ctx.clock, 10, 31)
+	if err != nil || clockRate == 0 {
+		return fmt.Errorf("invalid clock rate: '%s'", ctx.clock)
+	}
+	f.ClockRat = int(clockRate)
+
+	return nil
+}
+
+// Codec implements Format.
+func (f *ULPFEC) Codec() string {
+	return "ULPFEC"
+}
+
+// ClockRate implements Format.
+func (f *ULPFEC) ClockRate() int {
+	return f.ClockRat
+}
+
+// PayloadType implements Format.
+func (f *ULPFEC) PayloadType() uint8 {
+	return f.PayloadTyp
+}
+
+// RTPMap implements Format.
+func (f *ULPFEC) RTPMap() string {
+	return "ulpfec/" + strconv.FormatInt(int64(f.ClockRat), 10)
+}
+
+// FMTP implements Format.
+func (f *ULPFEC) FMTP() map[string]string {
+	return nil
+}
+
+// PTSEqualsDTS implements Format.
+func (f *ULPFEC) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *ULPFEC) CreateDecoder() (*rtpulpfec.Decoder, error) {
+	d := &rtpulpfec.Decoder{}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *ULPFEC) CreateEncoder() (*rtpulpfec.Encoder, error) {
+	e := &rtpulpfec.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestULPFECAttributes(t *testing.T) {
+	format := &ULPFEC{
+		PayloadTyp: 125,
+		ClockRat:   90000,
+	}
+	require.Equal(t, "ULPFEC", format.Codec())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestULPFECDecEncoder(t *testing.T) {
+	format := &ULPFEC{
+		PayloadTyp: 125,
+		ClockRat:   90000,
+	}
+
+	media := []*rtp.Packet{
+		{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 100,
+				Timestamp:      1000,
+				SSRC:           0x11223344,
+			},
+			Payload: []byte{1, 2, 3, 4},
+		},
+		{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 101,
+				Timestamp:      1000,
+				SSRC:           0x11223344,
+			},
+			Payload: []byte{5, 6, 7, 8},
+		},
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkt, err := enc.Encode(media)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	dec.AddMedia(media[0])
+
+	recovered, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Len(t, recovered, 1)
+	require.Equal(t, uint16(101), recovered[0].SequenceNumber)
+	require.Equal(t, []byte{5, 6, 7, 8}, recovered[0].Payload)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpvorbis"
 )
 
 // Vorbis is the RTP format for the Vorbis codec.
@@ -90,3 +92,36 @@ func (f *Vorbis) FMTP() map[string]string {
 func (f *Vorbis) PTSEqualsDTS(*rtp.Packet) bool {
 	return true
 }
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (f *Vorbis) CreateDecoder() (*rtpvorbis.Decoder, error) {
+	d := &rtpvorbis.Decoder{
+		Configuration: f.Configuration,
+	}
+
+	err := d.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (f *Vorbis) CreateEncoder() (*rtpvorbis.Encoder, error) {
+	e := &rtpvorbis.Encoder{
+		PayloadType: f.PayloadTyp,
+	}
+
+	// use the identifier of the first packed configuration
+	if len(f.Configuration) >= 7 {
+		e.Ident = uint32(f.Configuration[4])<<16 | uint32(f.Configuration[5])<<8 | uint32(f.Configuration[6])
+	}
+
+	err := e.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/pion/rtp"
 	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpvorbis"
 )
 
 func TestVorbisAttributes(t *testing.T) {
@@ -18,3 +20,33 @@ func TestVorbisAttributes(t *testing.T) {
 	require.Equal(t, 48000, format.ClockRate())
 	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
 }
+
+func TestVorbisDecEncoder(t *testing.T) {
+	conf, err := rtpvorbis.MarshalConfiguration(0x123456, [][]byte{
+		{0x01, 0x02},
+		{0x03, 0x04},
+		{0x05, 0x06},
+	})
+	require.NoError(t, err)
+
+	format := &Vorbis{
+		PayloadTyp:    96,
+		SampleRate:    48000,
+		ChannelCount:  2,
+		Configuration: conf,
+	}
+
+	enc, err := format.CreateEncoder()
+	require.NoError(t, err)
+
+	pkts, err := enc.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}})
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec, err := format.CreateDecoder()
+	require.NoError(t, err)
+
+	byts, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
+}
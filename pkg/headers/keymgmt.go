@@ -0,0 +1,79 @@
+package headers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/mikey"
+)
+
+// KeyMgmt is a KeyMgmt header.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4567#section-3.2
+type KeyMgmt struct {
+	// URL of the media the key refers to.
+	URL string
+
+	// MIKEY message that contains the key.
+	MikeyMessage *mikey.Message
+}
+
+// Unmarshal decodes a KeyMgmt header.
+func (h *KeyMgmt) Unmarshal(v base.HeaderValue) error {
+	if len(v) == 0 {
+		return fmt.Errorf("value not provided")
+	}
+
+	if len(v) > 1 {
+		return fmt.Errorf("value provided multiple times (%v)", v)
+	}
+
+	kvs, err := keyValParse(v[0], ';')
+	if err != nil {
+		return err
+	}
+
+	prot, ok := kvs["prot"]
+	if !ok {
+		return fmt.Errorf("protocol not provided")
+	}
+
+	if prot != "mikey" {
+		return fmt.Errorf("unsupported protocol: %v", prot)
+	}
+
+	for k, v := range kvs {
+		switch strings.TrimSpace(k) {
+		case "uri":
+			h.URL = v
+
+		case "data":
+			byts, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return fmt.Errorf("invalid data: %w", err)
+			}
+
+			var msg mikey.Message
+			err = msg.Unmarshal(byts)
+			if err != nil {
+				return fmt.Errorf("invalid data: %w", err)
+			}
+			h.MikeyMessage = &msg
+		}
+	}
+
+	if h.MikeyMessage == nil {
+		return fmt.Errorf("data not provided")
+	}
+
+	return nil
+}
+
+// Marshal encodes a KeyMgmt header.
+func (h KeyMgmt) Marshal() base.HeaderValue {
+	byts, _ := h.MikeyMessage.Marshal()
+
+	return base.HeaderValue{`prot=mikey; uri="` + h.URL + `"; data="` +
+		base64.StdEncoding.EncodeToString(byts) + `"`}
+}
@@ -0,0 +1,109 @@
+package headers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/mikey"
+)
+
+var casesKeyMgmt = []struct {
+	name string
+	vin  base.HeaderValue
+	vout base.HeaderValue
+	h    KeyMgmt
+}{
+	{
+		"standard",
+		base.HeaderValue{`prot=mikey; uri="rtsp://example.com/stream/trackID=0"; ` +
+			`data="AQAFABI0VngBAAAAAATSAAAAAAsA6PP9DmuFHrgKBAECAwQBAAAACQABAQIBAQsBCgAAAA4AMAAGAQIDBAUGAAIHCAA="`},
+		base.HeaderValue{`prot=mikey; uri="rtsp://example.com/stream/trackID=0"; ` +
+			`data="AQAFABI0VngBAAAAAATSAAAAAAsA6PP9DmuFHrgKBAECAwQBAAAACQABAQIBAQsBCgAAAA4AMAAGAQIDBAUGAAIHCAA="`},
+		KeyMgmt{
+			URL: "rtsp://example.com/stream/trackID=0",
+			MikeyMessage: &mikey.Message{
+				CSBID: 0x12345678,
+				CryptoSessions: []mikey.CryptoSession{{
+					PolicyNo: 0,
+					SSRC:     1234,
+					ROC:      0,
+				}},
+				Timestamp: 0xe8f3fd0e6b851eb8,
+				RAND:      []byte{1, 2, 3, 4},
+				Policies: []mikey.Policy{{
+					No: 0,
+					Params: []mikey.PolicyParam{
+						{Type: mikey.PolicyParamEncrAlg, Value: []byte{mikey.EncrAlgAESCM}},
+						{Type: mikey.PolicyParamAuthAlg, Value: []byte{mikey.AuthAlgHMACSHA1}},
+						{Type: mikey.PolicyParamAuthTagLen, Value: []byte{10}},
+					},
+				}},
+				Keys: []mikey.KeyData{{
+					Type: mikey.KeyDataTypeTEKSalt,
+					Key:  []byte{1, 2, 3, 4, 5, 6},
+					Salt: []byte{7, 8},
+				}},
+			},
+		},
+	},
+}
+
+func TestKeyMgmtUnmarshal(t *testing.T) {
+	for _, ca := range casesKeyMgmt {
+		t.Run(ca.name, func(t *testing.T) {
+			var h KeyMgmt
+			err := h.Unmarshal(ca.vin)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestKeyMgmtMarshal(t *testing.T) {
+	for _, ca := range casesKeyMgmt {
+		t.Run(ca.name, func(t *testing.T) {
+			req := ca.h.Marshal()
+			require.Equal(t, ca.vout, req)
+		})
+	}
+}
+
+func FuzzKeyMgmtUnmarshal(f *testing.F) {
+	for _, ca := range casesKeyMgmt {
+		f.Add(ca.vin[0])
+	}
+
+	f.Add(`prot=mikey; data="AA=="`)
+
+	f.Fuzz(func(_ *testing.T, b string) {
+		var h KeyMgmt
+		err := h.Unmarshal(base.HeaderValue{b})
+		if err != nil {
+			return
+		}
+
+		h.Marshal()
+	})
+}
+
+func TestKeyMgmtAdditionalErrors(t *testing.T) {
+	func() {
+		var h KeyMgmt
+		err := h.Unmarshal(base.HeaderValue{})
+		require.Error(t, err)
+	}()
+
+	func() {
+		var h KeyMgmt
+		err := h.Unmarshal(base.HeaderValue{"a", "b"})
+		require.Error(t, err)
+	}()
+
+	func() {
+		var h KeyMgmt
+		err := h.Unmarshal(base.HeaderValue{`prot=other; data="AA=="`})
+		require.EqualError(t, err, "unsupported protocol: other")
+	}()
+}
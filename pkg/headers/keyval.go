@@ -16,20 +16,40 @@ func readKey(str string, separator byte) (string, string) {
 	return str[:i], str[i:]
 }
 
+func readQuotedValue(origstr string, str string) (string, string, error) {
+	i := 1
+	for {
+		if i >= len(str) {
+			return "", "", fmt.Errorf("apexes not closed (%v)", origstr)
+		}
+
+		if str[i] == '"' {
+			return str[1:i], str[i+1:], nil
+		}
+
+		i++
+	}
+}
+
 func readValue(origstr string, str string, separator byte) (string, string, error) {
 	if len(str) > 0 && str[0] == '"' {
-		i := 1
-		for {
-			if i >= len(str) {
-				return "", "", fmt.Errorf("apexes not closed (%v)", origstr)
-			}
+		v, rest, err := readQuotedValue(origstr, str)
+		if err != nil {
+			return "", "", err
+		}
 
-			if str[i] == '"' {
-				return str[1:i], str[i+1:], nil
+		// RTSP 2.0 allows lists of quoted values separated by slashes,
+		// like dest_addr="192.0.2.5:3456"/"192.0.2.5:3457"
+		for len(rest) >= 2 && rest[0] == '/' && rest[1] == '"' {
+			var v2 string
+			v2, rest, err = readQuotedValue(origstr, rest[1:])
+			if err != nil {
+				return "", "", err
 			}
-
-			i++
+			v += "/" + v2
 		}
+
+		return v, rest, nil
 	}
 
 	i := 0
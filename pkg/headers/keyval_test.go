@@ -51,6 +51,14 @@ var casesKeyVal = []struct {
 			"key2": "v2",
 		},
 	},
+	{
+		"with apexes and slashes",
+		`key1="v1"/"v2", key2="v3"`,
+		map[string]string{
+			"key1": "v1/v2",
+			"key2": "v3",
+		},
+	},
 	{
 		"no val key1",
 		`key1, key2="v2"`,
@@ -0,0 +1,205 @@
+package headers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+)
+
+func unmarshalSeconds(v string) (time.Duration, error) {
+	tmp, err := strconv.ParseFloat(v, 64)
+	if err != nil || tmp < 0 {
+		return 0, fmt.Errorf("invalid value (%v)", v)
+	}
+	return time.Duration(tmp * float64(time.Second)), nil
+}
+
+func marshalSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// MediaPropertiesSeeking is the random access property of a media.
+type MediaPropertiesSeeking int
+
+// random access properties.
+const (
+	MediaPropertiesSeekingRandomAccess MediaPropertiesSeeking = iota
+	MediaPropertiesSeekingBeginningOnly
+	MediaPropertiesSeekingNoSeeking
+)
+
+// String implements fmt.Stringer.
+func (s MediaPropertiesSeeking) String() string {
+	switch s {
+	case MediaPropertiesSeekingRandomAccess:
+		return "Random-Access"
+
+	case MediaPropertiesSeekingBeginningOnly:
+		return "Beginning-Only"
+	}
+	return "No-Seeking"
+}
+
+// MediaPropertiesModifications is the content modifications property of a media.
+type MediaPropertiesModifications int
+
+// content modifications properties.
+const (
+	MediaPropertiesModificationsImmutable MediaPropertiesModifications = iota
+	MediaPropertiesModificationsDynamic
+	MediaPropertiesModificationsTimeProgressing
+)
+
+// String implements fmt.Stringer.
+func (m MediaPropertiesModifications) String() string {
+	switch m {
+	case MediaPropertiesModificationsImmutable:
+		return "Immutable"
+
+	case MediaPropertiesModificationsDynamic:
+		return "Dynamic"
+	}
+	return "Time-Progressing"
+}
+
+// MediaProperties is a Media-Properties header (RTSP 2.0).
+type MediaProperties struct {
+	// (optional) random access property
+	Seeking *MediaPropertiesSeeking
+
+	// (optional) maximum interval between random access points.
+	// It is used only when Seeking is MediaPropertiesSeekingRandomAccess.
+	RandomAccessMaxDelta *time.Duration
+
+	// (optional) content modifications property
+	Modifications *MediaPropertiesModifications
+
+	// whether the media is available without time limits
+	Unlimited bool
+
+	// (optional) time after which the media is no longer available
+	TimeLimited *time.Time
+
+	// (optional) amount of time after which the media is no longer available
+	TimeDuration *time.Duration
+
+	// (optional) supported scales, either single values or ranges (min:max)
+	Scales []string
+}
+
+// Unmarshal decodes a Media-Properties header.
+func (h *MediaProperties) Unmarshal(v base.HeaderValue) error {
+	if len(v) == 0 {
+		return fmt.Errorf("value not provided")
+	}
+
+	if len(v) > 1 {
+		return fmt.Errorf("value provided multiple times (%v)", v)
+	}
+
+	kvs, err := keyValParse(v[0], ',')
+	if err != nil {
+		return err
+	}
+
+	for k, v := range kvs {
+		switch k {
+		case "Random-Access":
+			s := MediaPropertiesSeekingRandomAccess
+			h.Seeking = &s
+
+			if v != "" {
+				d, err2 := unmarshalSeconds(v)
+				if err2 != nil {
+					return err2
+				}
+				h.RandomAccessMaxDelta = &d
+			}
+
+		case "Beginning-Only":
+			s := MediaPropertiesSeekingBeginningOnly
+			h.Seeking = &s
+
+		case "No-Seeking":
+			s := MediaPropertiesSeekingNoSeeking
+			h.Seeking = &s
+
+		case "Immutable":
+			m := MediaPropertiesModificationsImmutable
+			h.Modifications = &m
+
+		case "Dynamic":
+			m := MediaPropertiesModificationsDynamic
+			h.Modifications = &m
+
+		case "Time-Progressing":
+			m := MediaPropertiesModificationsTimeProgressing
+			h.Modifications = &m
+
+		case "Unlimited":
+			h.Unlimited = true
+
+		case "Time-Limited":
+			t, err2 := time.Parse("20060102T150405Z", v)
+			if err2 != nil {
+				return fmt.Errorf("invalid value (%v)", v)
+			}
+			h.TimeLimited = &t
+
+		case "Time-Duration":
+			d, err2 := unmarshalSeconds(v)
+			if err2 != nil {
+				return err2
+			}
+			h.TimeDuration = &d
+
+		case "Scales":
+			for _, scale := range strings.Split(v, ",") {
+				h.Scales = append(h.Scales, strings.TrimSpace(scale))
+			}
+
+		default:
+			// ignore non-standard keys
+		}
+	}
+
+	return nil
+}
+
+// Marshal encodes a Media-Properties header.
+func (h MediaProperties) Marshal() base.HeaderValue {
+	var rets []string
+
+	if h.Seeking != nil {
+		if *h.Seeking == MediaPropertiesSeekingRandomAccess && h.RandomAccessMaxDelta != nil {
+			rets = append(rets, h.Seeking.String()+"="+marshalSeconds(*h.RandomAccessMaxDelta))
+		} else {
+			rets = append(rets, h.Seeking.String())
+		}
+	}
+
+	if h.Modifications != nil {
+		rets = append(rets, h.Modifications.String())
+	}
+
+	if h.Unlimited {
+		rets = append(rets, "Unlimited")
+	}
+
+	if h.TimeLimited != nil {
+		rets = append(rets, "Time-Limited="+h.TimeLimited.UTC().Format("20060102T150405Z"))
+	}
+
+	if h.TimeDuration != nil {
+		rets = append(rets, "Time-Duration="+marshalSeconds(*h.TimeDuration))
+	}
+
+	if h.Scales != nil {
+		rets = append(rets, `Scales="`+strings.Join(h.Scales, ", ")+`"`)
+	}
+
+	return base.HeaderValue{strings.Join(rets, ", ")}
+}
@@ -0,0 +1,111 @@
+package headers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+)
+
+func seekingPtr(v MediaPropertiesSeeking) *MediaPropertiesSeeking {
+	return &v
+}
+
+func modificationsPtr(v MediaPropertiesModifications) *MediaPropertiesModifications {
+	return &v
+}
+
+var casesMediaProperties = []struct {
+	name string
+	vin  base.HeaderValue
+	vout base.HeaderValue
+	h    MediaProperties
+}{
+	{
+		"on-demand",
+		base.HeaderValue{`Random-Access=2.5, Unlimited, Immutable, Scales="-20, -10, -4, 0.5:1.5, 4, 8, 10, 15, 20"`},
+		base.HeaderValue{`Random-Access=2.5, Immutable, Unlimited, Scales="-20, -10, -4, 0.5:1.5, 4, 8, 10, 15, 20"`},
+		MediaProperties{
+			Seeking:              seekingPtr(MediaPropertiesSeekingRandomAccess),
+			RandomAccessMaxDelta: durationPtr(2500 * time.Millisecond),
+			Modifications:        modificationsPtr(MediaPropertiesModificationsImmutable),
+			Unlimited:            true,
+			Scales:               []string{"-20", "-10", "-4", "0.5:1.5", "4", "8", "10", "15", "20"},
+		},
+	},
+	{
+		"live",
+		base.HeaderValue{`No-Seeking, Time-Progressing, Time-Duration=0.0`},
+		base.HeaderValue{`No-Seeking, Time-Progressing, Time-Duration=0`},
+		MediaProperties{
+			Seeking:       seekingPtr(MediaPropertiesSeekingNoSeeking),
+			Modifications: modificationsPtr(MediaPropertiesModificationsTimeProgressing),
+			TimeDuration:  durationPtr(0),
+		},
+	},
+	{
+		"time limited",
+		base.HeaderValue{`Beginning-Only, Dynamic, Time-Limited=20081031T120000Z`},
+		base.HeaderValue{`Beginning-Only, Dynamic, Time-Limited=20081031T120000Z`},
+		MediaProperties{
+			Seeking:       seekingPtr(MediaPropertiesSeekingBeginningOnly),
+			Modifications: modificationsPtr(MediaPropertiesModificationsDynamic),
+			TimeLimited:   timePtr(time.Date(2008, 10, 31, 12, 0, 0, 0, time.UTC)),
+		},
+	},
+}
+
+func TestMediaPropertiesUnmarshal(t *testing.T) {
+	for _, ca := range casesMediaProperties {
+		t.Run(ca.name, func(t *testing.T) {
+			var h MediaProperties
+			err := h.Unmarshal(ca.vin)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestMediaPropertiesMarshal(t *testing.T) {
+	for _, ca := range casesMediaProperties {
+		t.Run(ca.name, func(t *testing.T) {
+			req := ca.h.Marshal()
+			require.Equal(t, ca.vout, req)
+		})
+	}
+}
+
+func FuzzMediaPropertiesUnmarshal(f *testing.F) {
+	for _, ca := range casesMediaProperties {
+		f.Add(ca.vin[0])
+	}
+
+	f.Add("Random-Access=")
+	f.Add("Time-Limited=a")
+
+	f.Fuzz(func(_ *testing.T, b string) {
+		var h MediaProperties
+		err := h.Unmarshal(base.HeaderValue{b})
+		if err != nil {
+			return
+		}
+
+		h.Marshal()
+	})
+}
+
+func TestMediaPropertiesAdditionalErrors(t *testing.T) {
+	func() {
+		var h MediaProperties
+		err := h.Unmarshal(base.HeaderValue{})
+		require.Error(t, err)
+	}()
+
+	func() {
+		var h MediaProperties
+		err := h.Unmarshal(base.HeaderValue{"a", "b"})
+		require.Error(t, err)
+	}()
+}
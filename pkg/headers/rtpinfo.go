@@ -11,6 +11,7 @@ import (
 // RTPInfoEntry is an entry of a RTP-Info header.
 type RTPInfoEntry struct {
 	URL            string
+	SSRC           *uint32 // RTSP 2.0 only
 	SequenceNumber *uint16
 	Timestamp      *uint32
 }
@@ -18,8 +19,13 @@ type RTPInfoEntry struct {
 // RTPInfo is a RTP-Info header.
 type RTPInfo []*RTPInfoEntry
 
-// Unmarshal decodes a RTP-Info header.
+// Unmarshal decodes a RTP-Info header in the RTSP 1.0 syntax.
 func (h *RTPInfo) Unmarshal(v base.HeaderValue) error {
+	return h.UnmarshalVersion(v, base.Version10)
+}
+
+// UnmarshalVersion decodes a RTP-Info header in the syntax of the given RTSP version.
+func (h *RTPInfo) UnmarshalVersion(v base.HeaderValue, version base.Version) error {
 	if len(v) == 0 {
 		return fmt.Errorf("value not provided")
 	}
@@ -28,7 +34,15 @@ func (h *RTPInfo) Unmarshal(v base.HeaderValue) error {
 		return fmt.Errorf("value provided multiple times (%v)", v)
 	}
 
-	for _, part := range strings.Split(v[0], ",") {
+	if version == base.Version20 {
+		return h.unmarshal20(v[0])
+	}
+
+	return h.unmarshal10(v[0])
+}
+
+func (h *RTPInfo) unmarshal10(v string) error {
+	for _, part := range strings.Split(v, ",") {
 		e := &RTPInfoEntry{}
 
 		// remove leading spaces
@@ -63,6 +77,13 @@ func (h *RTPInfo) Unmarshal(v base.HeaderValue) error {
 				vi2 := uint32(vi)
 				e.Timestamp = &vi2
 
+			case "ssrc":
+				// ssrc is a non-standard key in RTSP 1.0,
+				// while ssrc=X:seq=N is the RTSP 2.0 syntax
+				if strings.Contains(v, ":") {
+					return fmt.Errorf("RTP-Info uses the RTSP 2.0 syntax")
+				}
+
 			default:
 				// ignore non-standard keys
 			}
@@ -78,8 +99,120 @@ func (h *RTPInfo) Unmarshal(v base.HeaderValue) error {
 	return nil
 }
 
-// Marshal encodes a RTP-Info header.
+// splitOutsideQuotes splits a string by a separator that is not enclosed in quotes.
+func splitOutsideQuotes(str string, separator byte) []string {
+	var ret []string
+	quoted := false
+	start := 0
+
+	for i := 0; i < len(str); i++ {
+		switch {
+		case str[i] == '"':
+			quoted = !quoted
+
+		case str[i] == separator && !quoted:
+			ret = append(ret, str[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(ret, str[start:])
+}
+
+func (h *RTPInfo) unmarshal20(v string) error {
+	for _, part := range splitOutsideQuotes(v, ',') {
+		part = strings.TrimLeft(part, " ")
+
+		if !strings.HasPrefix(part, "url=") {
+			return fmt.Errorf("URL is missing")
+		}
+		part = part[len("url="):]
+
+		if len(part) == 0 || part[0] != '"' {
+			return fmt.Errorf("URL is not quoted")
+		}
+
+		u, rest, err := readQuotedValue(part, part)
+		if err != nil {
+			return err
+		}
+
+		params := strings.Fields(rest)
+
+		if len(params) == 0 {
+			*h = append(*h, &RTPInfoEntry{URL: u})
+			continue
+		}
+
+		// each SSRC of the stream has its own sequence number and timestamp
+		for _, param := range params {
+			e := &RTPInfoEntry{URL: u}
+
+			if !strings.HasPrefix(param, "ssrc=") {
+				return fmt.Errorf("invalid parameter: %v", param)
+			}
+			param = param[len("ssrc="):]
+
+			ssrcStr, riParams, _ := strings.Cut(param, ":")
+
+			tmp, err := strconv.ParseUint(ssrcStr, 16, 32)
+			if err != nil {
+				return err
+			}
+			ssrc := uint32(tmp)
+			e.SSRC = &ssrc
+
+			kvs, err := keyValParse(riParams, ';')
+			if err != nil {
+				return err
+			}
+
+			for k, v := range kvs {
+				switch k {
+				case "seq":
+					vi, err := strconv.ParseUint(v, 10, 16)
+					if err != nil {
+						return err
+					}
+					vi2 := uint16(vi)
+					e.SequenceNumber = &vi2
+
+				case "rtptime":
+					vi, err := strconv.ParseUint(v, 10, 32)
+					if err != nil {
+						return err
+					}
+					vi2 := uint32(vi)
+					e.Timestamp = &vi2
+
+				default:
+					// ignore generic parameters
+				}
+			}
+
+			*h = append(*h, e)
+		}
+	}
+
+	return nil
+}
+
+// Marshal encodes a RTP-Info header in the RTSP 1.0 syntax.
 func (h RTPInfo) Marshal() base.HeaderValue {
+	return h.MarshalVersion(base.Version10)
+}
+
+// MarshalVersion encodes a RTP-Info header in the syntax of the given RTSP version.
+// In RTSP 2.0, entries without SSRC contain the URL only.
+func (h RTPInfo) MarshalVersion(version base.Version) base.HeaderValue {
+	if version == base.Version20 {
+		return h.marshal20()
+	}
+
+	return h.marshal10()
+}
+
+func (h RTPInfo) marshal10() base.HeaderValue {
 	rets := make([]string, len(h))
 
 	for i, e := range h {
@@ -99,3 +232,29 @@ func (h RTPInfo) Marshal() base.HeaderValue {
 
 	return base.HeaderValue{strings.Join(rets, ",")}
 }
+
+func (h RTPInfo) marshal20() base.HeaderValue {
+	rets := make([]string, len(h))
+
+	for i, e := range h {
+		ret := `url="` + e.URL + `"`
+
+		if e.SSRC != nil {
+			var tmp []string
+
+			if e.SequenceNumber != nil {
+				tmp = append(tmp, "seq="+strconv.FormatUint(uint64(*e.SequenceNumber), 10))
+			}
+
+			if e.Timestamp != nil {
+				tmp = append(tmp, "rtptime="+strconv.FormatUint(uint64(*e.Timestamp), 10))
+			}
+
+			ret += " ssrc=" + fmt.Sprintf("%08X", *e.SSRC) + ":" + strings.Join(tmp, ";")
+		}
+
+		rets[i] = ret
+	}
+
+	return base.HeaderValue{strings.Join(rets, ", ")}
+}
@@ -131,6 +131,66 @@ var casesRTPInfo = []struct {
 	},
 }
 
+var casesRTPInfo20 = []struct {
+	name string
+	vin  base.HeaderValue
+	vout base.HeaderValue
+	h    RTPInfo
+}{
+	{
+		"single value",
+		base.HeaderValue{`url="rtsp://example.com/foo/audio" ssrc=0A13C760:seq=45102;rtptime=12345678`},
+		base.HeaderValue{`url="rtsp://example.com/foo/audio" ssrc=0A13C760:seq=45102;rtptime=12345678`},
+		RTPInfo{
+			{
+				URL:            "rtsp://example.com/foo/audio",
+				SSRC:           uint32Ptr(0x0A13C760),
+				SequenceNumber: uint16Ptr(45102),
+				Timestamp:      uint32Ptr(12345678),
+			},
+		},
+	},
+	{
+		"multiple value",
+		base.HeaderValue{`url="rtsp://example.com/foo/audio" ssrc=0A13C760:seq=45102;rtptime=12345678,` +
+			`url="rtsp://example.com/foo/video" ssrc=9A9DE123:seq=30211;rtptime=29567112`},
+		base.HeaderValue{`url="rtsp://example.com/foo/audio" ssrc=0A13C760:seq=45102;rtptime=12345678, ` +
+			`url="rtsp://example.com/foo/video" ssrc=9A9DE123:seq=30211;rtptime=29567112`},
+		RTPInfo{
+			{
+				URL:            "rtsp://example.com/foo/audio",
+				SSRC:           uint32Ptr(0x0A13C760),
+				SequenceNumber: uint16Ptr(45102),
+				Timestamp:      uint32Ptr(12345678),
+			},
+			{
+				URL:            "rtsp://example.com/foo/video",
+				SSRC:           uint32Ptr(0x9A9DE123),
+				SequenceNumber: uint16Ptr(30211),
+				Timestamp:      uint32Ptr(29567112),
+			},
+		},
+	},
+	{
+		"multiple ssrcs",
+		base.HeaderValue{`url="rtsp://example.com/foo,bar" ssrc=0A13C760:seq=45102 ssrc=9A9DE123:rtptime=29567112`},
+		base.HeaderValue{`url="rtsp://example.com/foo,bar" ssrc=0A13C760:seq=45102, ` +
+			`url="rtsp://example.com/foo,bar" ssrc=9A9DE123:rtptime=29567112`},
+		RTPInfo{
+			{
+				URL:            "rtsp://example.com/foo,bar",
+				SSRC:           uint32Ptr(0x0A13C760),
+				SequenceNumber: uint16Ptr(45102),
+			},
+			{
+				URL:       "rtsp://example.com/foo,bar",
+				SSRC:      uint32Ptr(0x9A9DE123),
+				Timestamp: uint32Ptr(29567112),
+			},
+		},
+	},
+}
+
 func TestRTPInfoUnmarshal(t *testing.T) {
 	for _, ca := range casesRTPInfo {
 		t.Run(ca.name, func(t *testing.T) {
@@ -151,6 +211,26 @@ func TestRTPInfoMarshal(t *testing.T) {
 	}
 }
 
+func TestRTPInfoUnmarshal20(t *testing.T) {
+	for _, ca := range casesRTPInfo20 {
+		t.Run(ca.name, func(t *testing.T) {
+			var h RTPInfo
+			err := h.UnmarshalVersion(ca.vin, base.Version20)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestRTPInfoMarshal20(t *testing.T) {
+	for _, ca := range casesRTPInfo20 {
+		t.Run(ca.name, func(t *testing.T) {
+			req := ca.h.MarshalVersion(base.Version20)
+			require.Equal(t, ca.vout, req)
+		})
+	}
+}
+
 func FuzzRTPInfoUnmarshal(f *testing.F) {
 	for _, ca := range casesRTPInfo {
 		f.Add(ca.vin[0])
@@ -167,6 +247,22 @@ func FuzzRTPInfoUnmarshal(f *testing.F) {
 	})
 }
 
+func FuzzRTPInfoUnmarshal20(f *testing.F) {
+	for _, ca := range casesRTPInfo20 {
+		f.Add(ca.vin[0])
+	}
+
+	f.Fuzz(func(_ *testing.T, b string) {
+		var h RTPInfo
+		err := h.UnmarshalVersion(base.HeaderValue{b}, base.Version20)
+		if err != nil {
+			return
+		}
+
+		h.MarshalVersion(base.Version20)
+	})
+}
+
 func TestRTPInfoAdditionalErrors(t *testing.T) {
 	func() {
 		var h RTPInfo
@@ -179,4 +275,16 @@ func TestRTPInfoAdditionalErrors(t *testing.T) {
 		err := h.Unmarshal(base.HeaderValue{"a", "b"})
 		require.Error(t, err)
 	}()
+
+	func() {
+		var h RTPInfo
+		err := h.Unmarshal(casesRTPInfo20[0].vin)
+		require.EqualError(t, err, "RTP-Info uses the RTSP 2.0 syntax")
+	}()
+
+	func() {
+		var h RTPInfo
+		err := h.UnmarshalVersion(casesRTPInfo[0].vin, base.Version20)
+		require.EqualError(t, err, "URL is not quoted")
+	}()
 }
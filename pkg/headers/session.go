@@ -35,7 +35,8 @@ func (h *Session) Unmarshal(v base.HeaderValue) error {
 		return nil
 	}
 
-	h.Session = v0[:i]
+	// RTSP 2.0 allows whitespace around separators
+	h.Session = strings.TrimRight(v0[:i], " ")
 	v0 = v0[i+1:]
 
 	v0 = strings.TrimLeft(v0, " ")
@@ -46,8 +47,8 @@ func (h *Session) Unmarshal(v base.HeaderValue) error {
 	}
 
 	for k, v := range kvs {
-		if k == "timeout" {
-			iv, err := strconv.ParseUint(v, 10, 32)
+		if strings.TrimSpace(k) == "timeout" {
+			iv, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
 			if err != nil {
 				return err
 			}
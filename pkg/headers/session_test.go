@@ -40,6 +40,15 @@ var casesSession = []struct {
 			Timeout: uintPtr(47),
 		},
 	},
+	{
+		"rtsp 2.0 with spaces",
+		base.HeaderValue{`uZ3ci0K+Ld-M ; timeout = 60`},
+		base.HeaderValue{`uZ3ci0K+Ld-M;timeout=60`},
+		Session{
+			Session: "uZ3ci0K+Ld-M",
+			Timeout: uintPtr(60),
+		},
+	},
 }
 
 func TestSessionUnmarshal(t *testing.T) {
@@ -38,6 +38,78 @@ func parsePorts(val string) (*[2]int, error) {
 	return &[2]int{0, 0}, fmt.Errorf("invalid ports (%v)", val)
 }
 
+// TransportAddr is an address of a RTSP 2.0 Transport header (dest_addr, src_addr).
+type TransportAddr struct {
+	// (optional) host
+	Host string
+
+	// (optional) port
+	Port int
+}
+
+func (a *TransportAddr) unmarshal(v string) error {
+	if strings.HasPrefix(v, ":") {
+		port, err := strconv.ParseUint(v[1:], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid address (%v)", v)
+		}
+
+		a.Host = ""
+		a.Port = int(port)
+		return nil
+	}
+
+	host, portStr, err := net.SplitHostPort(v)
+	if err != nil {
+		// port is optional
+		a.Host = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+		a.Port = 0
+		return nil
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid address (%v)", v)
+	}
+
+	a.Host = host
+	a.Port = int(port)
+	return nil
+}
+
+func (a TransportAddr) marshal() string {
+	if a.Port == 0 {
+		if strings.IndexByte(a.Host, ':') >= 0 {
+			return "[" + a.Host + "]"
+		}
+		return a.Host
+	}
+
+	return net.JoinHostPort(a.Host, strconv.FormatInt(int64(a.Port), 10))
+}
+
+func parseAddrs(val string) ([]TransportAddr, error) {
+	parts := strings.Split(val, "/")
+	addrs := make([]TransportAddr, len(parts))
+
+	for i, part := range parts {
+		err := addrs[i].unmarshal(part)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return addrs, nil
+}
+
+func marshalAddrs(addrs []TransportAddr) string {
+	parts := make([]string, len(addrs))
+	for i, addr := range addrs {
+		parts[i] = `"` + addr.marshal() + `"`
+	}
+	return strings.Join(parts, "/")
+}
+
 // TransportProtocol is a transport protocol.
 type TransportProtocol int
 
@@ -55,6 +127,26 @@ func (p TransportProtocol) String() string {
 	return "RTP/AVP/TCP"
 }
 
+// TransportProfile is a transport profile.
+type TransportProfile int
+
+// transport profiles.
+const (
+	// RTP/AVP
+	TransportProfileAVP TransportProfile = iota
+
+	// RTP/SAVP (SRTP)
+	TransportProfileSAVP
+)
+
+// String implements fmt.Stringer.
+func (p TransportProfile) String() string {
+	if p == TransportProfileAVP {
+		return "RTP/AVP"
+	}
+	return "RTP/SAVP"
+}
+
 // TransportDelivery is a delivery method.
 type TransportDelivery int
 
@@ -115,6 +207,9 @@ type Transport struct {
 	// protocol of the stream
 	Protocol TransportProtocol
 
+	// profile of the stream
+	Profile TransportProfile
+
 	// (optional) delivery method of the stream
 	Delivery *TransportDelivery
 
@@ -139,6 +234,12 @@ type Transport struct {
 	// (optional) server ports
 	ServerPorts *[2]int
 
+	// (optional) destination addresses (RTSP 2.0)
+	DestAddr []TransportAddr
+
+	// (optional) source addresses (RTSP 2.0)
+	SrcAddr []TransportAddr
+
 	// (optional) SSRC of the packets of the stream
 	SSRC *uint32
 
@@ -171,10 +272,22 @@ func (h *Transport) Unmarshal(v base.HeaderValue) error {
 		switch k {
 		case "RTP/AVP", "RTP/AVP/UDP":
 			h.Protocol = TransportProtocolUDP
+			h.Profile = TransportProfileAVP
 			protocolFound = true
 
 		case "RTP/AVP/TCP":
 			h.Protocol = TransportProtocolTCP
+			h.Profile = TransportProfileAVP
+			protocolFound = true
+
+		case "RTP/SAVP", "RTP/SAVP/UDP":
+			h.Protocol = TransportProtocolUDP
+			h.Profile = TransportProfileSAVP
+			protocolFound = true
+
+		case "RTP/SAVP/TCP":
+			h.Protocol = TransportProtocolTCP
+			h.Profile = TransportProfileSAVP
 			protocolFound = true
 
 		case "unicast":
@@ -246,6 +359,20 @@ func (h *Transport) Unmarshal(v base.HeaderValue) error {
 			}
 			h.ServerPorts = ports
 
+		case "dest_addr":
+			addrs, err2 := parseAddrs(v)
+			if err2 != nil {
+				return err2
+			}
+			h.DestAddr = addrs
+
+		case "src_addr":
+			addrs, err2 := parseAddrs(v)
+			if err2 != nil {
+				return err2
+			}
+			h.SrcAddr = addrs
+
 		case "ssrc":
 			v = strings.TrimLeft(v, " ")
 
@@ -284,7 +411,11 @@ func (h *Transport) Unmarshal(v base.HeaderValue) error {
 func (h Transport) Marshal() base.HeaderValue {
 	var rets []string
 
-	rets = append(rets, h.Protocol.String())
+	if h.Protocol == TransportProtocolUDP {
+		rets = append(rets, h.Profile.String())
+	} else {
+		rets = append(rets, h.Profile.String()+"/TCP")
+	}
 
 	if h.Delivery != nil {
 		rets = append(rets, h.Delivery.String())
@@ -322,6 +453,14 @@ func (h Transport) Marshal() base.HeaderValue {
 			"-"+strconv.FormatInt(int64(h.ServerPorts[1]), 10))
 	}
 
+	if h.DestAddr != nil {
+		rets = append(rets, "dest_addr="+marshalAddrs(h.DestAddr))
+	}
+
+	if h.SrcAddr != nil {
+		rets = append(rets, "src_addr="+marshalAddrs(h.SrcAddr))
+	}
+
 	if h.SSRC != nil {
 		tmp := make([]byte, 4)
 		tmp[0] = byte(*h.SSRC >> 24)
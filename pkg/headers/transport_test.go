@@ -61,6 +61,28 @@ var casesTransport = []struct {
 			Ports:       &[2]int{7000, 7001},
 		},
 	},
+	{
+		"udp unicast play request, srtp",
+		base.HeaderValue{`RTP/SAVP;unicast;client_port=3456-3457;mode=play`},
+		base.HeaderValue{`RTP/SAVP;unicast;client_port=3456-3457;mode=play`},
+		Transport{
+			Protocol:    TransportProtocolUDP,
+			Profile:     TransportProfileSAVP,
+			Delivery:    deliveryPtr(TransportDeliveryUnicast),
+			ClientPorts: &[2]int{3456, 3457},
+			Mode:        transportModePtr(TransportModePlay),
+		},
+	},
+	{
+		"tcp play request / response, srtp",
+		base.HeaderValue{`RTP/SAVP/TCP;interleaved=0-1`},
+		base.HeaderValue{`RTP/SAVP/TCP;interleaved=0-1`},
+		Transport{
+			Protocol:       TransportProtocolTCP,
+			Profile:        TransportProfileSAVP,
+			InterleavedIDs: &[2]int{0, 1},
+		},
+	},
 	{
 		"tcp play request / response",
 		base.HeaderValue{`RTP/AVP/TCP;interleaved=0-1`},
@@ -168,6 +190,42 @@ var casesTransport = []struct {
 			ServerPorts: &[2]int{56002, 56003},
 		},
 	},
+	{
+		"rtsp 2.0 udp unicast play request",
+		base.HeaderValue{`RTP/AVP/UDP;unicast;dest_addr=":3456"/":3457";mode=play`},
+		base.HeaderValue{`RTP/AVP;unicast;dest_addr=":3456"/":3457";mode=play`},
+		Transport{
+			Protocol: TransportProtocolUDP,
+			Delivery: deliveryPtr(TransportDeliveryUnicast),
+			DestAddr: []TransportAddr{{Port: 3456}, {Port: 3457}},
+			Mode:     transportModePtr(TransportModePlay),
+		},
+	},
+	{
+		"rtsp 2.0 udp unicast play response",
+		base.HeaderValue{`RTP/AVP/UDP;unicast;dest_addr="192.0.2.5:3456"/"192.0.2.5:3457";` +
+			`src_addr="[2001:db8::1]:6256"/"[2001:db8::1]:6257";ssrc=2A3F93ED`},
+		base.HeaderValue{`RTP/AVP;unicast;dest_addr="192.0.2.5:3456"/"192.0.2.5:3457";` +
+			`src_addr="[2001:db8::1]:6256"/"[2001:db8::1]:6257";ssrc=2A3F93ED`},
+		Transport{
+			Protocol: TransportProtocolUDP,
+			Delivery: deliveryPtr(TransportDeliveryUnicast),
+			DestAddr: []TransportAddr{{Host: "192.0.2.5", Port: 3456}, {Host: "192.0.2.5", Port: 3457}},
+			SrcAddr:  []TransportAddr{{Host: "2001:db8::1", Port: 6256}, {Host: "2001:db8::1", Port: 6257}},
+			SSRC:     uint32Ptr(0x2A3F93ED),
+		},
+	},
+	{
+		"rtsp 2.0 udp multicast play response",
+		base.HeaderValue{`RTP/AVP/UDP;multicast;dest_addr="224.0.1.11:9000"/"224.0.1.11:9001";ttl=127`},
+		base.HeaderValue{`RTP/AVP;multicast;ttl=127;dest_addr="224.0.1.11:9000"/"224.0.1.11:9001"`},
+		Transport{
+			Protocol: TransportProtocolUDP,
+			Delivery: deliveryPtr(TransportDeliveryMulticast),
+			TTL:      uintPtr(127),
+			DestAddr: []TransportAddr{{Host: "224.0.1.11", Port: 9000}, {Host: "224.0.1.11", Port: 9001}},
+		},
+	},
 }
 
 func TestTransportUnmarshal(t *testing.T) {
@@ -277,4 +335,10 @@ func TestTransportAdditionalErrors(t *testing.T) {
 		err := h.Unmarshal(base.HeaderValue{"a", "b"})
 		require.Error(t, err)
 	}()
+
+	func() {
+		var h Transport
+		err := h.Unmarshal(base.HeaderValue{`RTP/AVP;dest_addr=":abc"`})
+		require.EqualError(t, err, "invalid address (:abc)")
+	}()
 }
@@ -36,6 +36,26 @@ func (e ErrClientSessionHeaderInvalid) Error() string {
 	return fmt.Sprintf("invalid session header: %v", e.Err)
 }
 
+// ErrClientMediaPropertiesHeaderInvalid is an error that can be returned by a client.
+type ErrClientMediaPropertiesHeaderInvalid struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrClientMediaPropertiesHeaderInvalid) Error() string {
+	return fmt.Sprintf("invalid media properties header: %v", e.Err)
+}
+
+// ErrClientKeyMgmtHeaderInvalid is an error that can be returned by a client.
+type ErrClientKeyMgmtHeaderInvalid struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrClientKeyMgmtHeaderInvalid) Error() string {
+	return fmt.Sprintf("invalid KeyMgmt header: %v", e.Err)
+}
+
 // ErrClientBadStatusCode is an error that can be returned by a client.
 type ErrClientBadStatusCode struct {
 	Code    base.StatusCode
@@ -231,6 +251,14 @@ func (e ErrClientRTSPSTCP) Error() string {
 	return "RTSPS can be used only with TCP"
 }
 
+// ErrClientHTTPTunnelTCP is an error that can be returned by a client.
+type ErrClientHTTPTunnelTCP struct{}
+
+// Error implements the error interface.
+func (e ErrClientHTTPTunnelTCP) Error() string {
+	return "HTTP tunneling can be used only with TCP"
+}
+
 // ErrClientUnhandledMethod is an error that can be returned by a client.
 type ErrClientUnhandledMethod struct {
 	Method base.Method
@@ -341,3 +369,52 @@ type ErrClientSDPInvalid struct {
 func (e ErrClientSDPInvalid) Error() string {
 	return fmt.Sprintf("invalid SDP: %v", e.Err)
 }
+
+// ErrClientSRTPKeyNotProvided is an error that can be returned by a client.
+type ErrClientSRTPKeyNotProvided struct{}
+
+// Error implements the error interface.
+func (e ErrClientSRTPKeyNotProvided) Error() string {
+	return "media is secured with SRTP but the SRTP key has not been provided"
+}
+
+// ErrClientSRTPWithoutTLS is an error that can be returned by a client.
+type ErrClientSRTPWithoutTLS struct{}
+
+// Error implements the error interface.
+func (e ErrClientSRTPWithoutTLS) Error() string {
+	return "SRTP keys can't be exchanged over an unencrypted connection. " +
+		"Use RTSPS or enable InsecureSRTPKeyExchange"
+}
+
+// ErrClientTransportHeaderInvalidProfile is an error that can be returned by a client.
+type ErrClientTransportHeaderInvalidProfile struct{}
+
+// Error implements the error interface.
+func (e ErrClientTransportHeaderInvalidProfile) Error() string {
+	return "transport profile of the server doesn't match the one of the media"
+}
+
+// ErrClientGoodbye is an error that can be returned by a client.
+type ErrClientGoodbye struct{}
+
+// Error implements the error interface.
+func (e ErrClientGoodbye) Error() string {
+	return "server sent a RTCP BYE packet"
+}
+
+// ErrClientMediaNotSetup is an error that can be returned by a client.
+type ErrClientMediaNotSetup struct{}
+
+// Error implements the error interface.
+func (e ErrClientMediaNotSetup) Error() string {
+	return "media has not been setupped"
+}
+
+// ErrClientRemoteSSRCUnknown is an error that can be returned by a client.
+type ErrClientRemoteSSRCUnknown struct{}
+
+// Error implements the error interface.
+func (e ErrClientRemoteSSRCUnknown) Error() string {
+	return "SSRC of the sender is not known yet, since no RTP packets have been received"
+}
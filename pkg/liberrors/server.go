@@ -66,6 +66,9 @@ type ErrServerSDPInvalid = ErrClientSDPInvalid
 // ErrServerTransportHeaderInvalid is an error that can be returned by a server.
 type ErrServerTransportHeaderInvalid = ErrClientTransportHeaderInvalid
 
+// ErrServerKeyMgmtHeaderInvalid is an error that can be returned by a server.
+type ErrServerKeyMgmtHeaderInvalid = ErrClientKeyMgmtHeaderInvalid
+
 // ErrServerMediaAlreadySetup is an error that can be returned by a server.
 type ErrServerMediaAlreadySetup struct{}
 
@@ -282,3 +285,84 @@ type ErrServerAuth struct{}
 func (e ErrServerAuth) Error() string {
 	return "authentication error"
 }
+
+// ErrServerHTTPTunnelCookieMissing is an error that can be returned by a server.
+type ErrServerHTTPTunnelCookieMissing struct{}
+
+// Error implements the error interface.
+func (e ErrServerHTTPTunnelCookieMissing) Error() string {
+	return "x-sessioncookie header is missing"
+}
+
+// ErrServerHTTPTunnelInvalidMethod is an error that can be returned by a server.
+type ErrServerHTTPTunnelInvalidMethod struct {
+	Method string
+}
+
+// Error implements the error interface.
+func (e ErrServerHTTPTunnelInvalidMethod) Error() string {
+	return fmt.Sprintf("invalid HTTP tunnel method: %v", e.Method)
+}
+
+// ErrServerHTTPTunnelNotFound is an error that can be returned by a server.
+type ErrServerHTTPTunnelNotFound struct{}
+
+// Error implements the error interface.
+func (e ErrServerHTTPTunnelNotFound) Error() string {
+	return "no HTTP tunnel found for given x-sessioncookie"
+}
+
+// ErrServerHTTPTunnelJoined is an error that can be returned by a server.
+type ErrServerHTTPTunnelJoined struct{}
+
+// Error implements the error interface.
+func (e ErrServerHTTPTunnelJoined) Error() string {
+	return "connection has been joined to a HTTP tunnel"
+}
+
+// ErrServerHTTPTunnelTimeout is an error that can be returned by a server.
+type ErrServerHTTPTunnelTimeout struct{}
+
+// Error implements the error interface.
+func (e ErrServerHTTPTunnelTimeout) Error() string {
+	return "timed out while waiting for the POST half of the HTTP tunnel"
+}
+
+// ErrServerSRTPKeyNotProvided is an error that can be returned by a server.
+type ErrServerSRTPKeyNotProvided struct{}
+
+// Error implements the error interface.
+func (e ErrServerSRTPKeyNotProvided) Error() string {
+	return "media is secured with SRTP but the SRTP key has not been provided"
+}
+
+// ErrServerSRTPWithoutTLS is an error that can be returned by a server.
+type ErrServerSRTPWithoutTLS struct{}
+
+// Error implements the error interface.
+func (e ErrServerSRTPWithoutTLS) Error() string {
+	return "SRTP keys can't be exchanged over an unencrypted connection. " +
+		"Set TLSConfig or enable InsecureSRTPKeyExchange"
+}
+
+// ErrServerTransportHeaderInvalidProfile is an error that can be returned by a server.
+type ErrServerTransportHeaderInvalidProfile struct{}
+
+// Error implements the error interface.
+func (e ErrServerTransportHeaderInvalidProfile) Error() string {
+	return "transport profile doesn't match the one of the media"
+}
+
+// ErrServerSessionGoodbye is an error that can be returned by a server.
+type ErrServerSessionGoodbye struct{}
+
+// Error implements the error interface.
+func (e ErrServerSessionGoodbye) Error() string {
+	return "client sent a RTCP BYE packet"
+}
+
+// ErrServerMediaNotSetup is an error that can be returned by a server.
+type ErrServerMediaNotSetup = ErrClientMediaNotSetup
+
+// ErrServerRemoteSSRCUnknown is an error that can be returned by a server.
+type ErrServerRemoteSSRCUnknown = ErrClientRemoteSSRCUnknown
@@ -0,0 +1,387 @@
+// Package mikey contains functions to encode and decode MIKEY messages (RFC3830).
+package mikey
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	version = 1
+
+	dataTypePSKInit = 0
+
+	csIDMapTypeSRTPID = 0
+
+	payloadLast    = 0
+	payloadKEMAC   = 1
+	payloadT       = 5
+	payloadSP      = 10
+	payloadRAND    = 11
+	payloadKeyData = 20
+
+	tsTypeNTPUTC = 0
+
+	protTypeSRTP = 0
+
+	encrAlgNULL = 0
+	macAlgNULL  = 0
+)
+
+// SRTP policy parameter types (RFC3830, section 6.10.1).
+const (
+	PolicyParamEncrAlg        = 0
+	PolicyParamEncrKeyLen     = 1
+	PolicyParamAuthAlg        = 2
+	PolicyParamAuthKeyLen     = 3
+	PolicyParamSaltKeyLen     = 4
+	PolicyParamSRTPEncrOnOff  = 7
+	PolicyParamSRTCPEncrOnOff = 8
+	PolicyParamSRTPAuthOnOff  = 10
+	PolicyParamAuthTagLen     = 11
+)
+
+// SRTP encryption and authentication algorithms (RFC3830, section 6.10.1, RFC7714, section 14.2).
+const (
+	EncrAlgNULL   = 0
+	EncrAlgAESCM  = 1
+	EncrAlgAESGCM = 6
+
+	AuthAlgNULL     = 0
+	AuthAlgHMACSHA1 = 1
+)
+
+// KeyDataType is the type of a key data sub-payload.
+type KeyDataType uint8
+
+// key data types.
+const (
+	KeyDataTypeTGK     KeyDataType = 0
+	KeyDataTypeTGKSalt KeyDataType = 1
+	KeyDataTypeTEK     KeyDataType = 2
+	KeyDataTypeTEKSalt KeyDataType = 3
+)
+
+func (t KeyDataType) hasSalt() bool {
+	return t == KeyDataTypeTGKSalt || t == KeyDataTypeTEKSalt
+}
+
+// CryptoSession is an entry of the SRTP-ID map of the common header.
+type CryptoSession struct {
+	PolicyNo uint8
+	SSRC     uint32
+	ROC      uint32
+}
+
+// PolicyParam is a parameter of a security policy.
+type PolicyParam struct {
+	Type  uint8
+	Value []byte
+}
+
+// Policy is a SRTP security policy.
+type Policy struct {
+	No     uint8
+	Params []PolicyParam
+}
+
+// Param returns the value of a parameter.
+func (p Policy) Param(typ uint8) ([]byte, bool) {
+	for _, pa := range p.Params {
+		if pa.Type == typ {
+			return pa.Value, true
+		}
+	}
+	return nil, false
+}
+
+// KeyData is a key data sub-payload.
+type KeyData struct {
+	Type KeyDataType
+	Key  []byte
+	Salt []byte
+}
+
+// Message is a MIKEY message that uses the pre-shared key method,
+// with the NULL encryption and MAC algorithms.
+// This is the form used to carry SRTP keys inside SDP (RFC4567).
+type Message struct {
+	CSBID          uint32
+	CryptoSessions []CryptoSession
+	Timestamp      uint64
+	RAND           []byte
+	Policies       []Policy
+	Keys           []KeyData
+}
+
+func unmarshalKEMAC(buf []byte) ([]KeyData, int, error) {
+	if len(buf) < 4 {
+		return nil, 0, fmt.Errorf("KEMAC payload is too short")
+	}
+
+	if buf[1] != encrAlgNULL {
+		return nil, 0, fmt.Errorf("unsupported encryption algorithm: %d", buf[1])
+	}
+
+	encrLen := int(binary.BigEndian.Uint16(buf[2:]))
+	if len(buf) < (4 + encrLen + 1) {
+		return nil, 0, fmt.Errorf("KEMAC payload is too short")
+	}
+
+	if buf[4+encrLen] != macAlgNULL {
+		return nil, 0, fmt.Errorf("unsupported MAC algorithm: %d", buf[4+encrLen])
+	}
+
+	var keys []KeyData
+	sub := buf[4 : 4+encrLen]
+	nextPayload := byte(payloadKeyData)
+
+	for len(sub) != 0 {
+		if nextPayload != payloadKeyData {
+			return nil, 0, fmt.Errorf("unsupported sub-payload: %d", nextPayload)
+		}
+
+		if len(sub) < 4 {
+			return nil, 0, fmt.Errorf("key data sub-payload is too short")
+		}
+
+		nextPayload = sub[0]
+		kd := KeyData{Type: KeyDataType(sub[1] >> 4)}
+
+		if (sub[1] & 0x0F) != 0 {
+			return nil, 0, fmt.Errorf("unsupported key validity")
+		}
+
+		keyLen := int(binary.BigEndian.Uint16(sub[2:]))
+		sub = sub[4:]
+
+		if len(sub) < keyLen {
+			return nil, 0, fmt.Errorf("key data sub-payload is too short")
+		}
+		kd.Key = sub[:keyLen]
+		sub = sub[keyLen:]
+
+		if kd.Type.hasSalt() {
+			if len(sub) < 2 {
+				return nil, 0, fmt.Errorf("key data sub-payload is too short")
+			}
+
+			saltLen := int(binary.BigEndian.Uint16(sub))
+			sub = sub[2:]
+
+			if len(sub) < saltLen {
+				return nil, 0, fmt.Errorf("key data sub-payload is too short")
+			}
+			kd.Salt = sub[:saltLen]
+			sub = sub[saltLen:]
+		}
+
+		keys = append(keys, kd)
+	}
+
+	return keys, 4 + encrLen + 1, nil
+}
+
+// Unmarshal decodes a Message.
+func (m *Message) Unmarshal(buf []byte) error {
+	if len(buf) < 10 {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	if buf[0] != version {
+		return fmt.Errorf("unsupported version: %d", buf[0])
+	}
+
+	if buf[1] != dataTypePSKInit {
+		return fmt.Errorf("unsupported data type: %d", buf[1])
+	}
+
+	nextPayload := buf[2]
+	m.CSBID = binary.BigEndian.Uint32(buf[4:])
+	csCount := int(buf[8])
+
+	if buf[9] != csIDMapTypeSRTPID {
+		return fmt.Errorf("unsupported CS ID map type: %d", buf[9])
+	}
+
+	buf = buf[10:]
+
+	if len(buf) < (csCount * 9) {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	m.CryptoSessions = make([]CryptoSession, csCount)
+	for i := range m.CryptoSessions {
+		m.CryptoSessions[i] = CryptoSession{
+			PolicyNo: buf[0],
+			SSRC:     binary.BigEndian.Uint32(buf[1:]),
+			ROC:      binary.BigEndian.Uint32(buf[5:]),
+		}
+		buf = buf[9:]
+	}
+
+	m.Timestamp = 0
+	m.RAND = nil
+	m.Policies = nil
+	m.Keys = nil
+
+	for nextPayload != payloadLast {
+		if len(buf) < 2 {
+			return fmt.Errorf("buffer is too short")
+		}
+
+		payloadType := nextPayload
+		nextPayload = buf[0]
+
+		switch payloadType {
+		case payloadT:
+			if buf[1] != tsTypeNTPUTC {
+				return fmt.Errorf("unsupported timestamp type: %d", buf[1])
+			}
+
+			if len(buf) < 10 {
+				return fmt.Errorf("T payload is too short")
+			}
+
+			m.Timestamp = binary.BigEndian.Uint64(buf[2:])
+			buf = buf[10:]
+
+		case payloadRAND:
+			l := int(buf[1])
+			if len(buf) < (2 + l) {
+				return fmt.Errorf("RAND payload is too short")
+			}
+
+			m.RAND = buf[2 : 2+l]
+			buf = buf[2+l:]
+
+		case payloadSP:
+			if len(buf) < 5 {
+				return fmt.Errorf("SP payload is too short")
+			}
+
+			if buf[2] != protTypeSRTP {
+				return fmt.Errorf("unsupported protocol type: %d", buf[2])
+			}
+
+			p := Policy{No: buf[1]}
+			l := int(binary.BigEndian.Uint16(buf[3:]))
+			buf = buf[5:]
+
+			if len(buf) < l {
+				return fmt.Errorf("SP payload is too short")
+			}
+
+			params := buf[:l]
+			buf = buf[l:]
+
+			for len(params) != 0 {
+				if len(params) < 2 || len(params) < (2+int(params[1])) {
+					return fmt.Errorf("SP payload is too short")
+				}
+
+				p.Params = append(p.Params, PolicyParam{
+					Type:  params[0],
+					Value: params[2 : 2+int(params[1])],
+				})
+				params = params[2+int(params[1]):]
+			}
+
+			m.Policies = append(m.Policies, p)
+
+		case payloadKEMAC:
+			keys, n, err := unmarshalKEMAC(buf)
+			if err != nil {
+				return err
+			}
+
+			m.Keys = append(m.Keys, keys...)
+			buf = buf[n:]
+
+		default:
+			return fmt.Errorf("unsupported payload: %d", payloadType)
+		}
+	}
+
+	return nil
+}
+
+func (m Message) marshalKEMAC(buf []byte) []byte {
+	var encr []byte
+
+	for i, kd := range m.Keys {
+		next := byte(payloadKeyData)
+		if i == len(m.Keys)-1 {
+			next = payloadLast
+		}
+
+		encr = append(encr, next, byte(kd.Type)<<4)
+		encr = binary.BigEndian.AppendUint16(encr, uint16(len(kd.Key)))
+		encr = append(encr, kd.Key...)
+
+		if kd.Type.hasSalt() {
+			encr = binary.BigEndian.AppendUint16(encr, uint16(len(kd.Salt)))
+			encr = append(encr, kd.Salt...)
+		}
+	}
+
+	buf = append(buf, payloadLast, encrAlgNULL)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(encr)))
+	buf = append(buf, encr...)
+	return append(buf, macAlgNULL)
+}
+
+// Marshal encodes a Message.
+func (m Message) Marshal() ([]byte, error) {
+	if len(m.CryptoSessions) > 255 {
+		return nil, fmt.Errorf("too many crypto sessions")
+	}
+
+	if len(m.RAND) > 255 {
+		return nil, fmt.Errorf("RAND is too long")
+	}
+
+	buf := []byte{version, dataTypePSKInit, payloadT, 0}
+	buf = binary.BigEndian.AppendUint32(buf, m.CSBID)
+	buf = append(buf, byte(len(m.CryptoSessions)), csIDMapTypeSRTPID)
+
+	for _, cs := range m.CryptoSessions {
+		buf = append(buf, cs.PolicyNo)
+		buf = binary.BigEndian.AppendUint32(buf, cs.SSRC)
+		buf = binary.BigEndian.AppendUint32(buf, cs.ROC)
+	}
+
+	buf = append(buf, payloadRAND, tsTypeNTPUTC)
+	buf = binary.BigEndian.AppendUint64(buf, m.Timestamp)
+
+	next := byte(payloadSP)
+	if len(m.Policies) == 0 {
+		next = payloadKEMAC
+	}
+
+	buf = append(buf, next, byte(len(m.RAND)))
+	buf = append(buf, m.RAND...)
+
+	for i, p := range m.Policies {
+		next = payloadSP
+		if i == len(m.Policies)-1 {
+			next = payloadKEMAC
+		}
+
+		var params []byte
+		for _, pa := range p.Params {
+			if len(pa.Value) > 255 {
+				return nil, fmt.Errorf("policy parameter is too long")
+			}
+
+			params = append(params, pa.Type, byte(len(pa.Value)))
+			params = append(params, pa.Value...)
+		}
+
+		buf = append(buf, next, p.No, protTypeSRTP)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(params)))
+		buf = append(buf, params...)
+	}
+
+	return m.marshalKEMAC(buf), nil
+}
@@ -0,0 +1,110 @@
+package mikey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesMessage = []struct {
+	name string
+	byts []byte
+	msg  Message
+}{
+	{
+		"srtp aes-cm",
+		[]byte{
+			0x01, 0x00, 0x05, 0x00, 0x12, 0x34, 0x56, 0x78,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x04, 0xd2, 0x00,
+			0x00, 0x00, 0x00, 0x0b, 0x00, 0xe8, 0xf3, 0xfd,
+			0x0e, 0x6b, 0x85, 0x1e, 0xb8, 0x0a, 0x04, 0x01,
+			0x02, 0x03, 0x04, 0x01, 0x00, 0x00, 0x00, 0x09,
+			0x00, 0x01, 0x01, 0x02, 0x01, 0x01, 0x0b, 0x01,
+			0x0a, 0x00, 0x00, 0x00, 0x0e, 0x00, 0x30, 0x00,
+			0x06, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x00,
+			0x02, 0x07, 0x08, 0x00,
+		},
+		Message{
+			CSBID: 0x12345678,
+			CryptoSessions: []CryptoSession{{
+				PolicyNo: 0,
+				SSRC:     1234,
+				ROC:      0,
+			}},
+			Timestamp: 0xe8f3fd0e6b851eb8,
+			RAND:      []byte{1, 2, 3, 4},
+			Policies: []Policy{{
+				No: 0,
+				Params: []PolicyParam{
+					{Type: PolicyParamEncrAlg, Value: []byte{EncrAlgAESCM}},
+					{Type: PolicyParamAuthAlg, Value: []byte{AuthAlgHMACSHA1}},
+					{Type: PolicyParamAuthTagLen, Value: []byte{10}},
+				},
+			}},
+			Keys: []KeyData{{
+				Type: KeyDataTypeTEKSalt,
+				Key:  []byte{1, 2, 3, 4, 5, 6},
+				Salt: []byte{7, 8},
+			}},
+		},
+	},
+}
+
+func TestMessageUnmarshal(t *testing.T) {
+	for _, ca := range casesMessage {
+		t.Run(ca.name, func(t *testing.T) {
+			var msg Message
+			err := msg.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.msg, msg)
+		})
+	}
+}
+
+func TestMessageMarshal(t *testing.T) {
+	for _, ca := range casesMessage {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.msg.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestMessageUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"buffer is too short",
+		},
+		{
+			"version",
+			[]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			"unsupported version: 2",
+		},
+		{
+			"data type",
+			[]byte{0x01, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			"unsupported data type: 2",
+		},
+		{
+			"encrypted kemac",
+			[]byte{
+				0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+			},
+			"unsupported encryption algorithm: 2",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var msg Message
+			err := msg.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package recorder contains a utility to save RTP streams into fragmented MP4 segments.
+package recorder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+const (
+	defaultSegmentDuration = 1 * time.Hour
+	defaultPartDuration    = 1 * time.Second
+)
+
+var timeNow = time.Now
+
+// avoid an int64 overflow and preserve resolution by splitting division into two parts:
+// first add the integer part, then the decimal part.
+func multiplyAndDivide(v, m, d int64) int64 {
+	secs := v / d
+	dec := v % d
+	return (secs*m + dec*m/d)
+}
+
+func timestampToDuration(v int64, clockRate int) time.Duration {
+	return time.Duration(multiplyAndDivide(v, int64(time.Second), int64(clockRate)))
+}
+
+func durationToTimestamp(d time.Duration, clockRate int) int64 {
+	return multiplyAndDivide(int64(d), int64(clockRate), int64(time.Second))
+}
+
+func segmentPath(pathFormat string, t time.Time) string {
+	return strings.NewReplacer(
+		"%Y", strconv.FormatInt(int64(t.Year()), 10),
+		"%m", fmt.Sprintf("%02d", int(t.Month())),
+		"%d", fmt.Sprintf("%02d", t.Day()),
+		"%H", fmt.Sprintf("%02d", t.Hour()),
+		"%M", fmt.Sprintf("%02d", t.Minute()),
+		"%S", fmt.Sprintf("%02d", t.Second()),
+		"%f", fmt.Sprintf("%06d", t.Nanosecond()/1000),
+	).Replace(pathFormat)
+}
+
+// Recorder saves RTP packets into fragmented MP4 segments.
+//
+// Packets are decoded with the decoders provided by the format package.
+// Formats that cannot be stored into fMP4 are ignored.
+// Segments always start with a random access sample of the first video track,
+// or of the first track if there are no video tracks.
+type Recorder struct {
+	// Session description.
+	Desc *description.Session
+
+	// Path of segments.
+	// It can contain the following variables:
+	// %Y year, %m month, %d day, %H hour, %M minute, %S second, %f microsecond.
+	PathFormat string
+
+	// Minimum duration of each segment.
+	// A new segment is started after this duration is reached, as soon as a random access sample is received.
+	// It defaults to 1 hour.
+	SegmentDuration time.Duration
+
+	// Duration of each fMP4 part.
+	// Parts are appended to the current segment every time this duration is reached.
+	// It defaults to 1 second.
+	PartDuration time.Duration
+
+	// Called when a segment is created.
+	OnSegmentCreate func(path string)
+
+	// Called when a segment is completed.
+	OnSegmentComplete func(path string, duration time.Duration)
+
+	mutex              sync.Mutex
+	tracks             map[format.Format]*track
+	leadingTrack       *track
+	startDTS           time.Duration
+	codecsChanged      bool
+	nextSequenceNumber uint32
+	seg                *segment
+}
+
+// Initialize initializes a Recorder.
+func (r *Recorder) Initialize() error {
+	if r.PathFormat == "" {
+		return fmt.Errorf("PathFormat is empty")
+	}
+
+	if r.SegmentDuration == 0 {
+		r.SegmentDuration = defaultSegmentDuration
+	}
+	if r.PartDuration == 0 {
+		r.PartDuration = defaultPartDuration
+	}
+
+	r.tracks = make(map[format.Format]*track)
+	id := 1
+
+	for _, medi := range r.Desc.Medias {
+		for _, forma := range medi.Formats {
+			t := &track{
+				r:         r,
+				id:        id,
+				clockRate: forma.ClockRate(),
+				isVideo:   medi.Type == description.MediaTypeVideo,
+			}
+
+			var err error
+			t.decode, t.lastCodec, err = newTrackDecoder(forma)
+			if err != nil {
+				return err
+			}
+
+			if t.decode == nil {
+				continue
+			}
+
+			r.tracks[forma] = t
+			id++
+
+			if r.leadingTrack == nil || (t.isVideo && !r.leadingTrack.isVideo) {
+				r.leadingTrack = t
+			}
+		}
+	}
+
+	if len(r.tracks) == 0 {
+		return fmt.Errorf("none of the formats can be recorded")
+	}
+
+	return nil
+}
+
+// Close closes the Recorder and completes the current segment.
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.seg == nil {
+		return nil
+	}
+
+	err := r.seg.close(r.seg.endDTS)
+	r.seg = nil
+	return err
+}
+
+// WritePacketRTP writes a RTP packet.
+// pts is the presentation timestamp of the packet, obtained with Client.PacketPTS2
+// or ServerSession.PacketPTS2.
+func (r *Recorder) WritePacketRTP(forma format.Format, pkt *rtp.Packet, pts int64) error {
+	t, ok := r.tracks[forma]
+	if !ok {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	samples, err := t.decode(pkt, pts)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range samples {
+		err = t.writeSample(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) writeCompletedSample(t *track, s *sample) error {
+	dts := timestampToDuration(s.dts, t.clockRate)
+
+	if r.seg == nil {
+		if t != r.leadingTrack || s.IsNonSyncSample {
+			return nil
+		}
+
+		r.startDTS = dts
+
+		err := r.createSegment(dts)
+		if err != nil {
+			return err
+		}
+	} else if t == r.leadingTrack && !s.IsNonSyncSample &&
+		((dts-r.seg.startDTS) >= r.SegmentDuration || r.codecsChanged || s.codec != t.codec) {
+		err := r.seg.close(dts)
+		r.seg = nil
+		if err != nil {
+			return err
+		}
+
+		err = r.createSegment(dts)
+		if err != nil {
+			return err
+		}
+	}
+
+	// codec of a non-leading track has changed.
+	// drop samples until a new segment is started.
+	if s.codec != t.codec {
+		r.codecsChanged = true
+		return nil
+	}
+
+	// sample precedes the beginning of the recording.
+	if dts < r.startDTS {
+		return nil
+	}
+
+	return r.seg.writeSample(t, s, dts)
+}
+
+func (r *Recorder) createSegment(startDTS time.Duration) error {
+	r.codecsChanged = false
+
+	for _, t := range r.tracks {
+		t.codec = t.lastCodec
+	}
+
+	seg := &segment{
+		r:        r,
+		startDTS: startDTS,
+		endDTS:   startDTS,
+	}
+	err := seg.initialize()
+	if err != nil {
+		return err
+	}
+
+	r.seg = seg
+	return nil
+}
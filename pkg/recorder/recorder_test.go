@@ -0,0 +1,427 @@
+package recorder
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+var testH264Format = &format.H264{
+	PayloadTyp: 96,
+	SPS: []byte{
+		0x67, 0x42, 0xc0, 0x28, 0xd9, 0x00, 0x78, 0x02,
+		0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00, 0x04,
+		0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60, 0xc9,
+		0x20,
+	},
+	PPS: []byte{
+		0x44, 0x01, 0xc0, 0x25, 0x2f, 0x05, 0x32, 0x40,
+	},
+	PacketizationMode: 1,
+}
+
+var testMPEG4AudioFormat = &format.MPEG4Audio{
+	PayloadTyp: 97,
+	Config: &mpeg4audio.Config{
+		Type:         mpeg4audio.ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+	},
+	SizeLength:       13,
+	IndexLength:      3,
+	IndexDeltaLength: 3,
+}
+
+func TestSegmentPath(t *testing.T) {
+	require.Equal(t,
+		"rec/2008-05-20_22-15-25-000125.mp4",
+		segmentPath("rec/%Y-%m-%d_%H-%M-%S-%f.mp4",
+			time.Date(2008, 5, 20, 22, 15, 25, 125000, time.UTC)))
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gortsplib-recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	n := 0
+	timeNow = func() time.Time {
+		n++
+		return time.Date(2008, 5, 20, 22, 15, n, 0, time.UTC)
+	}
+	defer func() { timeNow = time.Now }()
+
+	var created []string
+	var completed []time.Duration
+
+	r := &Recorder{
+		Desc: &description.Session{
+			Medias: []*description.Media{
+				{
+					Type:    description.MediaTypeAudio,
+					Formats: []format.Format{testMPEG4AudioFormat},
+				},
+				{
+					Type:    description.MediaTypeVideo,
+					Formats: []format.Format{testH264Format},
+				},
+				{
+					Type:    description.MediaTypeApplication,
+					Formats: []format.Format{&format.Generic{PayloadTyp: 98, RTPMa: "private/90000"}},
+				},
+			},
+		},
+		PathFormat:      filepath.Join(dir, "%Y-%m-%d_%H-%M-%S-%f.mp4"),
+		SegmentDuration: 1 * time.Second,
+		PartDuration:    300 * time.Millisecond,
+		OnSegmentCreate: func(path string) {
+			created = append(created, path)
+		},
+		OnSegmentComplete: func(_ string, duration time.Duration) {
+			completed = append(completed, duration)
+		},
+	}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	videoEnc, err := testH264Format.CreateEncoder()
+	require.NoError(t, err)
+
+	audioEnc, err := testMPEG4AudioFormat.CreateEncoder()
+	require.NoError(t, err)
+
+	audioPTS := int64(0)
+
+	for i := 0; i < 6; i++ {
+		var au [][]byte
+		if (i % 2) == 0 {
+			au = [][]byte{{0x05, 0x01, byte(i)}} // IDR
+		} else {
+			au = [][]byte{{0x01, 0x01, byte(i)}} // non-IDR
+		}
+
+		pkts, err2 := videoEnc.Encode(au)
+		require.NoError(t, err2)
+
+		for _, pkt := range pkts {
+			err2 = r.WritePacketRTP(testH264Format, pkt, int64(i)*45000)
+			require.NoError(t, err2)
+		}
+
+		for audioPTS < int64(i+1)*44100/2 {
+			pkts, err2 = audioEnc.Encode([][]byte{{1, 2, 3, 4}})
+			require.NoError(t, err2)
+
+			for _, pkt := range pkts {
+				err2 = r.WritePacketRTP(testMPEG4AudioFormat, pkt, audioPTS)
+				require.NoError(t, err2)
+			}
+
+			audioPTS += mpeg4audio.SamplesPerAccessUnit
+		}
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		filepath.Join(dir, "2008-05-20_22-15-01-000000.mp4"),
+		filepath.Join(dir, "2008-05-20_22-15-02-000000.mp4"),
+		filepath.Join(dir, "2008-05-20_22-15-03-000000.mp4"),
+	}, created)
+
+	require.Len(t, completed, 3)
+	require.Equal(t, 1*time.Second, completed[0])
+	require.Equal(t, 1*time.Second, completed[1])
+
+	videoSamples := 0
+	prevSequenceNumber := int64(-1)
+
+	for i, path := range created {
+		byts, err2 := os.ReadFile(path)
+		require.NoError(t, err2)
+
+		var init fmp4.Init
+		err2 = init.Unmarshal(bytes.NewReader(byts))
+		require.NoError(t, err2)
+
+		require.Equal(t, []*fmp4.InitTrack{
+			{
+				ID:        1,
+				TimeScale: 44100,
+				Codec:     &fmp4.CodecMPEG4Audio{Config: *testMPEG4AudioFormat.Config},
+			},
+			{
+				ID:        2,
+				TimeScale: 90000,
+				Codec: &fmp4.CodecH264{
+					SPS: testH264Format.SPS,
+					PPS: testH264Format.PPS,
+				},
+			},
+		}, init.Tracks)
+
+		var parts fmp4.Parts
+		err2 = parts.Unmarshal(byts)
+		require.NoError(t, err2)
+		require.NotEmpty(t, parts)
+
+		firstVideoSample := true
+
+		for _, part := range parts {
+			require.Equal(t, prevSequenceNumber+1, int64(part.SequenceNumber))
+			prevSequenceNumber = int64(part.SequenceNumber)
+
+			for _, track := range part.Tracks {
+				if track.ID != 2 {
+					continue
+				}
+
+				if firstVideoSample {
+					require.Equal(t, uint64(i*90000), track.BaseTime)
+					require.False(t, track.Samples[0].IsNonSyncSample)
+					firstVideoSample = false
+				}
+
+				for _, sample := range track.Samples {
+					require.Equal(t, uint32(45000), sample.Duration)
+				}
+
+				videoSamples += len(track.Samples)
+			}
+		}
+	}
+
+	// the last sample is discarded since its duration is unknown.
+	require.Equal(t, 5, videoSamples)
+}
+
+func TestRecorderNoSupportedFormats(t *testing.T) {
+	r := &Recorder{
+		Desc: &description.Session{
+			Medias: []*description.Media{{
+				Type:    description.MediaTypeVideo,
+				Formats: []format.Format{&format.VP8{PayloadTyp: 96}},
+			}},
+		},
+		PathFormat: "%Y.mp4",
+	}
+	err := r.Initialize()
+	require.EqualError(t, err, "none of the formats can be recorded")
+}
+
+func TestRecorderH264Interleaved(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gortsplib-recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	forma := &format.H264{
+		PayloadTyp:        96,
+		SPS:               testH264Format.SPS,
+		PPS:               testH264Format.PPS,
+		PacketizationMode: 2,
+	}
+
+	var created []string
+
+	r := &Recorder{
+		Desc: &description.Session{
+			Medias: []*description.Media{{
+				Type:    description.MediaTypeVideo,
+				Formats: []format.Format{forma},
+			}},
+		},
+		PathFormat:      filepath.Join(dir, "%Y-%m-%d_%H-%M-%S-%f.mp4"),
+		SegmentDuration: 10 * time.Second,
+		PartDuration:    300 * time.Millisecond,
+		OnSegmentCreate: func(path string) {
+			created = append(created, path)
+		},
+	}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	enc, err := forma.CreateEncoder()
+	require.NoError(t, err)
+
+	for i := 0; i < 6; i++ {
+		var au [][]byte
+		if i == 0 {
+			au = [][]byte{{0x05, 0x01, byte(i)}} // IDR
+		} else {
+			au = [][]byte{{0x01, 0x01, byte(i)}} // non-IDR
+		}
+
+		pkts, err2 := enc.Encode(au)
+		require.NoError(t, err2)
+
+		for _, pkt := range pkts {
+			pkt.Timestamp += uint32(i) * 3000
+			err2 = r.WritePacketRTP(forma, pkt, int64(i)*3000)
+			require.NoError(t, err2)
+		}
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+
+	byts, err := os.ReadFile(created[0])
+	require.NoError(t, err)
+
+	var parts fmp4.Parts
+	err = parts.Unmarshal(byts)
+	require.NoError(t, err)
+
+	videoSamples := 0
+
+	for _, part := range parts {
+		for _, track := range part.Tracks {
+			for _, sample := range track.Samples {
+				require.Equal(t, uint32(3000), sample.Duration)
+			}
+			videoSamples += len(track.Samples)
+		}
+	}
+
+	// the last access unit is still in the de-interleaving buffer,
+	// and the last sample is discarded since its duration is unknown.
+	require.Equal(t, 4, videoSamples)
+}
+
+func TestRecorderH265Reordered(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gortsplib-recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	forma := &format.H265{
+		PayloadTyp: 96,
+		VPS: []byte{
+			0x40, 0x1, 0xc, 0x1, 0xff, 0xff, 0x1, 0x60,
+			0x0, 0x0, 0x3, 0x0, 0x90, 0x0, 0x0, 0x3,
+			0x0, 0x0, 0x3, 0x0, 0x78, 0x99, 0x98, 0x9,
+		},
+		SPS: []byte{
+			0x42, 0x1, 0x1, 0x1, 0x60, 0x0, 0x0, 0x3,
+			0x0, 0x90, 0x0, 0x0, 0x3, 0x0, 0x0, 0x3,
+			0x0, 0x78, 0xa0, 0x3, 0xc0, 0x80, 0x10, 0xe5,
+			0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x0,
+			0x0, 0x3, 0x0, 0x10, 0x0, 0x0, 0x3, 0x1,
+			0xe0, 0x80,
+		},
+		PPS: []byte{
+			0x44, 0x1, 0xc1, 0x72, 0xb4, 0x62, 0x40,
+		},
+		MaxDONDiff:     2,
+		DepackBufNALUs: 1,
+	}
+
+	var created []string
+
+	r := &Recorder{
+		Desc: &description.Session{
+			Medias: []*description.Media{{
+				Type:    description.MediaTypeVideo,
+				Formats: []format.Format{forma},
+			}},
+		},
+		PathFormat:      filepath.Join(dir, "%Y-%m-%d_%H-%M-%S-%f.mp4"),
+		SegmentDuration: 10 * time.Second,
+		PartDuration:    300 * time.Millisecond,
+		OnSegmentCreate: func(path string) {
+			created = append(created, path)
+		},
+	}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	enc, err := forma.CreateEncoder()
+	require.NoError(t, err)
+
+	for _, au := range []struct {
+		nalu []byte
+		pts  int64
+	}{
+		{
+			[]byte{ // IDR_W_RADL
+				0x26, 0x1, 0xaf, 0x8, 0x42, 0x23, 0x48, 0x8a, 0x43, 0xe2,
+			},
+			0,
+		},
+		{
+			[]byte{ // TRAIL_R
+				0x02, 0x01, 0xd0, 0x19, 0x5f, 0x8c, 0xb4, 0x42,
+				0x49, 0x20, 0x40, 0x11, 0x16, 0x92, 0x93, 0xea,
+				0x54, 0x57, 0x4e, 0x0a,
+			},
+			9000,
+		},
+		{
+			[]byte{ // TRAIL_R
+				0x02, 0x01, 0xe0, 0x44, 0x97, 0xe0, 0x81, 0x20,
+				0x44, 0x52, 0x62, 0x7a, 0x1b, 0x88, 0x0b, 0x21,
+				0x26, 0x5f, 0x10, 0x9c,
+			},
+			6000,
+		},
+		{
+			[]byte{ // TRAIL_N
+				0x00, 0x01, 0xe0, 0x24, 0xff, 0xfa, 0x24, 0x0a,
+				0x42, 0x25, 0x8c, 0x18, 0xe6, 0x1c, 0xea, 0x5a,
+				0x5d, 0x07, 0xc1, 0x8f,
+			},
+			3000,
+		},
+		{
+			[]byte{ // TRAIL_R
+				0x02, 0x01, 0xd0, 0x30, 0x97, 0xd7, 0xdc, 0xf9,
+				0x0c, 0x10, 0x11, 0x11, 0x20, 0x42, 0x11, 0x18,
+				0x63, 0xa5, 0x18, 0x55,
+			},
+			18000,
+		},
+	} {
+		pkts, err2 := enc.Encode([][]byte{au.nalu})
+		require.NoError(t, err2)
+
+		for _, pkt := range pkts {
+			pkt.Timestamp += uint32(au.pts)
+			err2 = r.WritePacketRTP(forma, pkt, au.pts)
+			require.NoError(t, err2)
+		}
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+
+	byts, err := os.ReadFile(created[0])
+	require.NoError(t, err)
+
+	var parts fmp4.Parts
+	err = parts.Unmarshal(byts)
+	require.NoError(t, err)
+
+	videoSamples := 0
+
+	for _, part := range parts {
+		for _, track := range part.Tracks {
+			videoSamples += len(track.Samples)
+		}
+	}
+
+	// the last two access units are still in the de-packetization buffer,
+	// and the last sample is discarded since its duration is unknown.
+	require.Equal(t, 2, videoSamples)
+}
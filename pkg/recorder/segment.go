@@ -0,0 +1,157 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4/seekablebuffer"
+)
+
+type part struct {
+	startDTS time.Duration
+	tracks   map[*track]*fmp4.PartTrack
+}
+
+type segment struct {
+	r        *Recorder
+	startDTS time.Duration
+	endDTS   time.Duration
+
+	path   string
+	f      *os.File
+	tracks []*track
+	part   *part
+}
+
+func (s *segment) initialize() error {
+	for _, t := range s.r.tracks {
+		if t.codec != nil {
+			s.tracks = append(s.tracks, t)
+		}
+	}
+
+	sort.Slice(s.tracks, func(i, j int) bool {
+		return s.tracks[i].id < s.tracks[j].id
+	})
+
+	init := fmp4.Init{}
+
+	for _, t := range s.tracks {
+		init.Tracks = append(init.Tracks, &fmp4.InitTrack{
+			ID:        t.id,
+			TimeScale: uint32(t.clockRate),
+			Codec:     t.codec,
+		})
+	}
+
+	var buf seekablebuffer.Buffer
+	err := init.Marshal(&buf)
+	if err != nil {
+		return err
+	}
+
+	s.path = segmentPath(s.r.PathFormat, timeNow())
+
+	err = os.MkdirAll(filepath.Dir(s.path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	s.f, err = os.Create(s.path)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.f.Write(buf.Bytes())
+	if err != nil {
+		s.f.Close()
+		os.Remove(s.path)
+		return err
+	}
+
+	if s.r.OnSegmentCreate != nil {
+		s.r.OnSegmentCreate(s.path)
+	}
+
+	return nil
+}
+
+func (s *segment) close(endDTS time.Duration) error {
+	err := s.flushPart()
+
+	err2 := s.f.Close()
+	if err == nil {
+		err = err2
+	}
+
+	if s.r.OnSegmentComplete != nil {
+		s.r.OnSegmentComplete(s.path, endDTS-s.startDTS)
+	}
+
+	return err
+}
+
+func (s *segment) writeSample(t *track, smp *sample, dts time.Duration) error {
+	if s.part != nil && (dts-s.part.startDTS) >= s.r.PartDuration {
+		err := s.flushPart()
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.part == nil {
+		s.part = &part{
+			startDTS: dts,
+			tracks:   make(map[*track]*fmp4.PartTrack),
+		}
+	}
+
+	pt, ok := s.part.tracks[t]
+	if !ok {
+		pt = &fmp4.PartTrack{
+			ID:       t.id,
+			BaseTime: uint64(smp.dts - durationToTimestamp(s.r.startDTS, t.clockRate)),
+		}
+		s.part.tracks[t] = pt
+	}
+
+	pt.Samples = append(pt.Samples, smp.PartSample)
+
+	end := dts + timestampToDuration(int64(smp.Duration), t.clockRate)
+	if end > s.endDTS {
+		s.endDTS = end
+	}
+
+	return nil
+}
+
+func (s *segment) flushPart() error {
+	if s.part == nil {
+		return nil
+	}
+
+	p := fmp4.Part{
+		SequenceNumber: s.r.nextSequenceNumber,
+	}
+	s.r.nextSequenceNumber++
+
+	for _, t := range s.tracks {
+		if pt, ok := s.part.tracks[t]; ok {
+			p.Tracks = append(p.Tracks, pt)
+		}
+	}
+
+	s.part = nil
+
+	var buf seekablebuffer.Buffer
+	err := p.Marshal(&buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.f.Write(buf.Bytes())
+	return err
+}
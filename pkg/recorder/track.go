@@ -0,0 +1,48 @@
+package recorder
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4"
+)
+
+// sample is a fMP4 sample with its decoding timestamp.
+type sample struct {
+	*fmp4.PartSample
+	dts   int64
+	codec fmp4.Codec
+}
+
+type track struct {
+	r         *Recorder
+	id        int
+	clockRate int
+	isVideo   bool
+	decode    trackDecodeFunc
+	lastCodec fmp4.Codec // codec of the last decoded sample
+	codec     fmp4.Codec // codec of the current segment
+
+	next *sample
+}
+
+// writeSample stores a sample until the next one is received,
+// in order to compute its duration.
+func (t *track) writeSample(s *sample) error {
+	t.lastCodec = s.codec
+
+	prev := t.next
+	if prev != nil && s.dts < prev.dts {
+		return fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			prev.dts, s.dts)
+	}
+
+	t.next = s
+
+	if prev == nil {
+		return nil
+	}
+
+	prev.Duration = uint32(s.dts - prev.dts)
+
+	return t.r.writeCompletedSample(t, prev)
+}
@@ -0,0 +1,815 @@
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"image/jpeg"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac3"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/g711"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1audio"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4video"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/vp9"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4"
+	"github.com/pion/rtp"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpac3"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpav1"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtph264"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtph265"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmjpeg"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmpeg1audio"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmpeg1video"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmpeg4audio"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpmpeg4video"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpvp9"
+)
+
+// trackDecodeFunc decodes fMP4 samples from a RTP packet.
+type trackDecodeFunc func(pkt *rtp.Packet, pts int64) ([]*sample, error)
+
+func newSample(dts int64, codec fmp4.Codec) *sample {
+	return &sample{
+		PartSample: &fmp4.PartSample{},
+		dts:        dts,
+		codec:      codec,
+	}
+}
+
+// extractParamsMPEG4Video extracts the configuration that precedes the first GOV or VOP.
+func extractParamsMPEG4Video(frame []byte) []byte {
+	if !bytes.HasPrefix(frame, []byte{0, 0, 1, byte(mpeg4video.VisualObjectSequenceStartCode)}) {
+		return nil
+	}
+
+	end := bytes.Index(frame[4:], []byte{0, 0, 1, byte(mpeg4video.GroupOfVOPStartCode)})
+	if end < 0 {
+		end = bytes.Index(frame[4:], []byte{0, 0, 1, byte(mpeg4video.VOPStartCode)})
+		if end < 0 {
+			return nil
+		}
+	}
+
+	return frame[:4+end]
+}
+
+// extractParamsMPEG1Video extracts the sequence header.
+func extractParamsMPEG1Video(frame []byte) []byte {
+	if !bytes.HasPrefix(frame, []byte{0, 0, 1, 0xB3}) {
+		return nil
+	}
+
+	end := bytes.Index(frame[4:], []byte{0, 0, 1})
+	if end < 0 {
+		return nil
+	}
+
+	return frame[:4+end]
+}
+
+func newTrackDecoderH264(forma *format.H264) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecH264
+	sps, pps := forma.SafeParams()
+	if sps != nil && pps != nil {
+		codec = &fmp4.CodecH264{SPS: sps, PPS: pps}
+	}
+
+	var initialCodec fmp4.Codec
+	if codec != nil {
+		initialCodec = codec
+	}
+
+	var dtsExtractor *h264.DTSExtractor
+
+	// processAU converts an access unit into a sample.
+	// It returns nil when the access unit cannot be decoded yet.
+	processAU := func(au [][]byte, pts int64) (*sample, error) {
+		var sps, pps []byte
+		if codec != nil {
+			sps, pps = codec.SPS, codec.PPS
+		}
+
+		paramsFound := false
+
+		for _, nalu := range au {
+			switch h264.NALUType(nalu[0] & 0x1F) {
+			case h264.NALUTypeSPS:
+				sps = nalu
+				paramsFound = true
+			case h264.NALUTypePPS:
+				pps = nalu
+			}
+		}
+
+		if sps == nil || pps == nil {
+			return nil, nil
+		}
+
+		if codec == nil || !bytes.Equal(sps, codec.SPS) || !bytes.Equal(pps, codec.PPS) {
+			codec = &fmp4.CodecH264{SPS: sps, PPS: pps}
+			dtsExtractor = nil
+		}
+
+		randomAccess := h264.IsRandomAccess(au)
+
+		// the DTS extractor needs parameters to be present in random access units.
+		if randomAccess && !paramsFound {
+			au = append([][]byte{codec.SPS, codec.PPS}, au...)
+		}
+
+		if dtsExtractor == nil {
+			if !randomAccess {
+				return nil, nil
+			}
+			dtsExtractor = &h264.DTSExtractor{}
+			dtsExtractor.Initialize()
+		}
+
+		dts, err := dtsExtractor.Extract(au, pts)
+		if err != nil {
+			dtsExtractor = nil
+			return nil, err
+		}
+
+		s := newSample(dts, codec)
+		err = s.FillH264(int32(pts-dts), au)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+
+	if forma.PacketizationMode == 2 {
+		return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+			aus, err := rtpDec.DecodeInterleaved(pkt)
+			if err != nil {
+				if errors.Is(err, rtph264.ErrNonStartingPacketAndNoPrevious) ||
+					errors.Is(err, rtph264.ErrMorePacketsNeeded) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			var samples []*sample
+
+			for _, au := range aus {
+				// access units can be completed by packets with a different timestamp,
+				// therefore their PTS is computed from the timestamp difference.
+				s, err2 := processAU(au.NALUs, pts+int64(int32(au.Timestamp-pkt.Timestamp)))
+				if err2 != nil {
+					return nil, err2
+				}
+
+				if s != nil {
+					samples = append(samples, s)
+				}
+			}
+
+			return samples, nil
+		}, initialCodec, nil
+	}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		au, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtph264.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtph264.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		s, err := processAU(au, pts)
+		if err != nil || s == nil {
+			return nil, err
+		}
+
+		return []*sample{s}, nil
+	}, initialCodec, nil
+}
+
+func newTrackDecoderH265(forma *format.H265) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecH265
+	vps, sps, pps := forma.SafeParams()
+	if vps != nil && sps != nil && pps != nil {
+		codec = &fmp4.CodecH265{VPS: vps, SPS: sps, PPS: pps}
+	}
+
+	var initialCodec fmp4.Codec
+	if codec != nil {
+		initialCodec = codec
+	}
+
+	var dtsExtractor *h265.DTSExtractor
+
+	// processAU converts an access unit into a sample.
+	// It returns nil when the access unit cannot be decoded yet.
+	processAU := func(au [][]byte, pts int64) (*sample, error) {
+		var vps, sps, pps []byte
+		if codec != nil {
+			vps, sps, pps = codec.VPS, codec.SPS, codec.PPS
+		}
+
+		paramsFound := false
+
+		for _, nalu := range au {
+			switch h265.NALUType((nalu[0] >> 1) & 0b111111) {
+			case h265.NALUType_VPS_NUT:
+				vps = nalu
+			case h265.NALUType_SPS_NUT:
+				sps = nalu
+				paramsFound = true
+			case h265.NALUType_PPS_NUT:
+				pps = nalu
+			}
+		}
+
+		if vps == nil || sps == nil || pps == nil {
+			return nil, nil
+		}
+
+		if codec == nil || !bytes.Equal(vps, codec.VPS) ||
+			!bytes.Equal(sps, codec.SPS) || !bytes.Equal(pps, codec.PPS) {
+			codec = &fmp4.CodecH265{VPS: vps, SPS: sps, PPS: pps}
+			dtsExtractor = nil
+		}
+
+		randomAccess := h265.IsRandomAccess(au)
+
+		// the DTS extractor needs parameters to be present in random access units.
+		if randomAccess && !paramsFound {
+			au = append([][]byte{codec.VPS, codec.SPS, codec.PPS}, au...)
+		}
+
+		if dtsExtractor == nil {
+			if !randomAccess {
+				return nil, nil
+			}
+			dtsExtractor = &h265.DTSExtractor{}
+			dtsExtractor.Initialize()
+		}
+
+		dts, err := dtsExtractor.Extract(au, pts)
+		if err != nil {
+			dtsExtractor = nil
+			return nil, err
+		}
+
+		s := newSample(dts, codec)
+		err = s.FillH265(int32(pts-dts), au)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+
+	if forma.DepackBufNALUs != 0 {
+		return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+			aus, err := rtpDec.DecodeReordered(pkt)
+			if err != nil {
+				if errors.Is(err, rtph265.ErrNonStartingPacketAndNoPrevious) ||
+					errors.Is(err, rtph265.ErrMorePacketsNeeded) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			var samples []*sample
+
+			for _, au := range aus {
+				// access units can be completed by packets with a different timestamp,
+				// therefore their PTS is computed from the timestamp difference.
+				s, err2 := processAU(au.NALUs, pts+int64(int32(au.Timestamp-pkt.Timestamp)))
+				if err2 != nil {
+					return nil, err2
+				}
+
+				if s != nil {
+					samples = append(samples, s)
+				}
+			}
+
+			return samples, nil
+		}, initialCodec, nil
+	}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		au, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtph265.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtph265.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		s, err := processAU(au, pts)
+		if err != nil || s == nil {
+			return nil, err
+		}
+
+		return []*sample{s}, nil
+	}, initialCodec, nil
+}
+
+func newTrackDecoderAV1(forma *format.AV1) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecAV1
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		tu, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpav1.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpav1.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		for _, obu := range tu {
+			typ := av1.OBUType((obu[0] >> 3) & 0b1111)
+
+			if typ == av1.OBUTypeSequenceHeader &&
+				(codec == nil || !bytes.Equal(obu, codec.SequenceHeader)) {
+				codec = &fmp4.CodecAV1{SequenceHeader: obu}
+			}
+		}
+
+		if codec == nil {
+			return nil, nil
+		}
+
+		s := newSample(pts, codec)
+		err = s.FillAV1(tu)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*sample{s}, nil
+	}, nil, nil
+}
+
+func newTrackDecoderVP9(forma *format.VP9) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecVP9
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frame, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpvp9.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpvp9.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		var h vp9.Header
+		err = h.Unmarshal(frame)
+		if err != nil {
+			return nil, err
+		}
+
+		if !h.NonKeyFrame {
+			newCodec := &fmp4.CodecVP9{
+				Width:             h.Width(),
+				Height:            h.Height(),
+				Profile:           h.Profile,
+				BitDepth:          h.ColorConfig.BitDepth,
+				ChromaSubsampling: h.ChromaSubsampling(),
+				ColorRange:        h.ColorConfig.ColorRange,
+			}
+
+			if codec == nil || *newCodec != *codec {
+				codec = newCodec
+			}
+		}
+
+		if codec == nil {
+			return nil, nil
+		}
+
+		s := newSample(pts, codec)
+		s.IsNonSyncSample = h.NonKeyFrame
+		s.Payload = frame
+
+		return []*sample{s}, nil
+	}, nil, nil
+}
+
+func newTrackDecoderMJPEG(forma *format.MJPEG) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecMJPEG
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frame, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpmjpeg.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpmjpeg.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		conf, err := jpeg.DecodeConfig(bytes.NewReader(frame))
+		if err != nil {
+			return nil, err
+		}
+
+		if codec == nil || conf.Width != codec.Width || conf.Height != codec.Height {
+			codec = &fmp4.CodecMJPEG{
+				Width:  conf.Width,
+				Height: conf.Height,
+			}
+		}
+
+		s := newSample(pts, codec)
+		s.Payload = frame
+
+		return []*sample{s}, nil
+	}, nil, nil
+}
+
+func newTrackDecoderMPEG1Video(forma *format.MPEG1Video) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecMPEG1Video
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frame, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpmpeg1video.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpmpeg1video.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		if config := extractParamsMPEG1Video(frame); config != nil &&
+			(codec == nil || !bytes.Equal(config, codec.Config)) {
+			codec = &fmp4.CodecMPEG1Video{Config: config}
+		}
+
+		if codec == nil {
+			return nil, nil
+		}
+
+		s := newSample(pts, codec)
+		s.IsNonSyncSample = !bytes.Contains(frame, []byte{0, 0, 1, 0xB8})
+		s.Payload = frame
+
+		return []*sample{s}, nil
+	}, nil, nil
+}
+
+func newTrackDecoderMPEG4Video(forma *format.MPEG4Video) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecMPEG4Video
+	if config := forma.SafeParams(); config != nil {
+		codec = &fmp4.CodecMPEG4Video{Config: config}
+	}
+
+	var initialCodec fmp4.Codec
+	if codec != nil {
+		initialCodec = codec
+	}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frame, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpmpeg4video.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		if config := extractParamsMPEG4Video(frame); config != nil &&
+			(codec == nil || !bytes.Equal(config, codec.Config)) {
+			codec = &fmp4.CodecMPEG4Video{Config: config}
+		}
+
+		if codec == nil {
+			return nil, nil
+		}
+
+		s := newSample(pts, codec)
+		s.IsNonSyncSample = !bytes.Contains(frame, []byte{0, 0, 1, byte(mpeg4video.GroupOfVOPStartCode)})
+		s.Payload = frame
+
+		return []*sample{s}, nil
+	}, initialCodec, nil
+}
+
+func newTrackDecoderMPEG4Audio(forma *format.MPEG4Audio) (trackDecodeFunc, fmp4.Codec, error) {
+	config := forma.GetConfig()
+	if config == nil {
+		return nil, nil, nil
+	}
+
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codec := &fmp4.CodecMPEG4Audio{Config: *config}
+	clockRate := int64(forma.ClockRate())
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		aus, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpmpeg4audio.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		samples := make([]*sample, len(aus))
+
+		for i, au := range aus {
+			dts := pts + int64(i)*mpeg4audio.SamplesPerAccessUnit*clockRate/int64(config.SampleRate)
+			samples[i] = newSample(dts, codec)
+			samples[i].Payload = au
+		}
+
+		return samples, nil
+	}, codec, nil
+}
+
+func newTrackDecoderOpus(forma *format.Opus) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codec := &fmp4.CodecOpus{ChannelCount: forma.ChannelCount}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		packet, err := rtpDec.Decode(pkt)
+		if err != nil {
+			return nil, err
+		}
+
+		s := newSample(pts, codec)
+		s.Payload = packet
+
+		return []*sample{s}, nil
+	}, codec, nil
+}
+
+func newTrackDecoderMPEG1Audio(forma *format.MPEG1Audio) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecMPEG1Audio
+	clockRate := int64(forma.ClockRate())
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frames, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpmpeg1audio.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpmpeg1audio.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		samples := make([]*sample, 0, len(frames))
+		dts := pts
+
+		for _, frame := range frames {
+			var h mpeg1audio.FrameHeader
+			err = h.Unmarshal(frame)
+			if err != nil {
+				return nil, err
+			}
+
+			channelCount := 2
+			if h.ChannelMode == mpeg1audio.ChannelModeMono {
+				channelCount = 1
+			}
+
+			if codec == nil || h.SampleRate != codec.SampleRate || channelCount != codec.ChannelCount {
+				codec = &fmp4.CodecMPEG1Audio{
+					SampleRate:   h.SampleRate,
+					ChannelCount: channelCount,
+				}
+			}
+
+			s := newSample(dts, codec)
+			s.Payload = frame
+			samples = append(samples, s)
+
+			dts += int64(h.SampleCount()) * clockRate / int64(h.SampleRate)
+		}
+
+		return samples, nil
+	}, nil, nil
+}
+
+func newTrackDecoderAC3(forma *format.AC3) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var codec *fmp4.CodecAC3
+	clockRate := int64(forma.ClockRate())
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		frames, err := rtpDec.Decode(pkt)
+		if err != nil {
+			if errors.Is(err, rtpac3.ErrNonStartingPacketAndNoPrevious) ||
+				errors.Is(err, rtpac3.ErrMorePacketsNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		samples := make([]*sample, 0, len(frames))
+		dts := pts
+
+		for _, frame := range frames {
+			var syncInfo ac3.SyncInfo
+			err = syncInfo.Unmarshal(frame)
+			if err != nil {
+				return nil, err
+			}
+
+			var bsi ac3.BSI
+			err = bsi.Unmarshal(frame[5:])
+			if err != nil {
+				return nil, err
+			}
+
+			newCodec := &fmp4.CodecAC3{
+				SampleRate:   syncInfo.SampleRate(),
+				ChannelCount: bsi.ChannelCount(),
+				Fscod:        syncInfo.Fscod,
+				Bsid:         bsi.Bsid,
+				Bsmod:        bsi.Bsmod,
+				Acmod:        bsi.Acmod,
+				LfeOn:        bsi.LfeOn,
+				BitRateCode:  syncInfo.Frmsizecod >> 1,
+			}
+
+			if codec == nil || *newCodec != *codec {
+				codec = newCodec
+			}
+
+			s := newSample(dts, codec)
+			s.Payload = frame
+			samples = append(samples, s)
+
+			dts += ac3.SamplesPerFrame * clockRate / int64(codec.SampleRate)
+		}
+
+		return samples, nil
+	}, nil, nil
+}
+
+func newTrackDecoderLPCM(forma *format.LPCM) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codec := &fmp4.CodecLPCM{
+		LittleEndian: false,
+		BitDepth:     forma.BitDepth,
+		SampleRate:   forma.SampleRate,
+		ChannelCount: forma.ChannelCount,
+	}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		samples, err := rtpDec.Decode(pkt)
+		if err != nil {
+			return nil, err
+		}
+
+		s := newSample(pts, codec)
+		s.Payload = samples
+
+		return []*sample{s}, nil
+	}, codec, nil
+}
+
+func newTrackDecoderG711(forma *format.G711) (trackDecodeFunc, fmp4.Codec, error) {
+	rtpDec, err := forma.CreateDecoder()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// G711 is not supported by fMP4, therefore samples are converted into LPCM.
+	codec := &fmp4.CodecLPCM{
+		LittleEndian: false,
+		BitDepth:     16,
+		SampleRate:   forma.SampleRate,
+		ChannelCount: forma.ChannelCount,
+	}
+
+	return func(pkt *rtp.Packet, pts int64) ([]*sample, error) {
+		enc, err := rtpDec.Decode(pkt)
+		if err != nil {
+			return nil, err
+		}
+
+		s := newSample(pts, codec)
+
+		if forma.MULaw {
+			var lpcm g711.Mulaw
+			lpcm.Unmarshal(enc)
+			s.Payload = lpcm
+		} else {
+			var lpcm g711.Alaw
+			lpcm.Unmarshal(enc)
+			s.Payload = lpcm
+		}
+
+		return []*sample{s}, nil
+	}, codec, nil
+}
+
+// newTrackDecoder returns a function that decodes samples of the format,
+// and the codec of the format, if it is already known.
+// If the format cannot be stored into fMP4, it returns a nil function.
+func newTrackDecoder(forma format.Format) (trackDecodeFunc, fmp4.Codec, error) {
+	switch forma := forma.(type) {
+	case *format.H264:
+		return newTrackDecoderH264(forma)
+
+	case *format.H265:
+		return newTrackDecoderH265(forma)
+
+	case *format.AV1:
+		return newTrackDecoderAV1(forma)
+
+	case *format.VP9:
+		return newTrackDecoderVP9(forma)
+
+	case *format.MJPEG:
+		return newTrackDecoderMJPEG(forma)
+
+	case *format.MPEG1Video:
+		return newTrackDecoderMPEG1Video(forma)
+
+	case *format.MPEG4Video:
+		return newTrackDecoderMPEG4Video(forma)
+
+	case *format.MPEG4Audio:
+		return newTrackDecoderMPEG4Audio(forma)
+
+	case *format.Opus:
+		return newTrackDecoderOpus(forma)
+
+	case *format.MPEG1Audio:
+		return newTrackDecoderMPEG1Audio(forma)
+
+	case *format.AC3:
+		return newTrackDecoderAC3(forma)
+
+	case *format.LPCM:
+		return newTrackDecoderLPCM(forma)
+
+	case *format.G711:
+		return newTrackDecoderG711(forma)
+	}
+
+	return nil, nil, nil
+}
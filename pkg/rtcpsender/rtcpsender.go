@@ -16,6 +16,32 @@ func ntpTimeGoToRTCP(v time.Time) uint64 {
 	return (s/1000000000)<<32 | (s % 1000000000)
 }
 
+const (
+	// number of sent reports that are kept in order to compute the round-trip time
+	sentReportsHistory = 8
+)
+
+type sentReport struct {
+	ntpMiddle  uint32
+	timeSystem time.Time
+}
+
+// ReceiverStats are statistics of a remote receiver, extracted from its receiver reports.
+type ReceiverStats struct {
+	// SSRC of the receiver
+	SSRC uint32
+	// fraction of packets lost since the previous report, between 0 and 1
+	FractionLost float64
+	// cumulative number of packets lost
+	TotalLost uint32
+	// interarrival jitter, in RTP timestamp units
+	Jitter float64
+	// round-trip time. It is zero when the receiver has not received any sender report yet
+	RTT time.Duration
+	// time of the last receiver report
+	LastReport time.Time
+}
+
 // RTCPSender is a utility to generate RTCP sender reports.
 type RTCPSender struct {
 	ClockRate       int
@@ -35,6 +61,11 @@ type RTCPSender struct {
 	packetCount        uint32
 	octetCount         uint32
 
+	// data from RTCP receiver reports
+	sentReports    [sentReportsHistory]sentReport
+	sentReportsPos int
+	receivers      map[uint32]*ReceiverStats
+
 	terminate chan struct{}
 	done      chan struct{}
 }
@@ -65,6 +96,8 @@ func (rs *RTCPSender) Initialize() {
 		rs.TimeNow = time.Now
 	}
 
+	rs.receivers = make(map[uint32]*ReceiverStats)
+
 	rs.terminate = make(chan struct{})
 	rs.done = make(chan struct{})
 
@@ -105,13 +138,22 @@ func (rs *RTCPSender) report() rtcp.Packet {
 		return nil
 	}
 
-	systemTimeDiff := rs.TimeNow().Sub(rs.lastTimeSystem)
-	ntpTime := rs.lastTimeNTP.Add(systemTimeDiff)
+	now := rs.TimeNow()
+	systemTimeDiff := now.Sub(rs.lastTimeSystem)
+	ntpTime := ntpTimeGoToRTCP(rs.lastTimeNTP.Add(systemTimeDiff))
 	rtpTime := rs.lastTimeRTP + uint32(systemTimeDiff.Seconds()*float64(rs.ClockRate))
 
+	// store the middle 32 bits of the NTP timestamp, that are sent back by receivers
+	// in the LSR field, in order to compute the round-trip time.
+	rs.sentReports[rs.sentReportsPos] = sentReport{
+		ntpMiddle:  uint32(ntpTime >> 16),
+		timeSystem: now,
+	}
+	rs.sentReportsPos = (rs.sentReportsPos + 1) % sentReportsHistory
+
 	return &rtcp.SenderReport{
 		SSRC:        rs.localSSRC,
-		NTPTime:     ntpTimeGoToRTCP(ntpTime),
+		NTPTime:     ntpTime,
 		RTPTime:     rtpTime,
 		PacketCount: rs.packetCount,
 		OctetCount:  rs.octetCount,
@@ -137,6 +179,60 @@ func (rs *RTCPSender) ProcessPacket(pkt *rtp.Packet, ntp time.Time, ptsEqualsDTS
 	rs.octetCount += uint32(len(pkt.Payload))
 }
 
+// ProcessReceiverReport extracts data from a RTCP receiver report.
+// It returns statistics of the receiver,
+// or nil if the report does not refer to outgoing packets.
+func (rs *RTCPSender) ProcessReceiverReport(rr *rtcp.ReceiverReport, now time.Time) *ReceiverStats {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	if !rs.firstRTPPacketSent {
+		return nil
+	}
+
+	for _, report := range rr.Reports {
+		if report.SSRC != rs.localSSRC {
+			continue
+		}
+
+		stats := &ReceiverStats{
+			SSRC:         rr.SSRC,
+			FractionLost: float64(report.FractionLost) / 256,
+			TotalLost:    report.TotalLost,
+			Jitter:       float64(report.Jitter),
+			LastReport:   now,
+		}
+
+		if report.LastSenderReport != 0 {
+			for _, sent := range rs.sentReports {
+				if !sent.timeSystem.IsZero() && sent.ntpMiddle == report.LastSenderReport {
+					delay := time.Duration(report.Delay) * time.Second / 65536
+					rtt := now.Sub(sent.timeSystem) - delay
+					if rtt > 0 {
+						stats.RTT = rtt
+					}
+					break
+				}
+			}
+		}
+
+		rs.receivers[rr.SSRC] = stats
+
+		ret := *stats
+		return &ret
+	}
+
+	return nil
+}
+
+// RemoveReceiver removes statistics of a receiver.
+func (rs *RTCPSender) RemoveReceiver(ssrc uint32) {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	delete(rs.receivers, ssrc)
+}
+
 // SenderSSRC returns the SSRC of outgoing RTP packets.
 //
 // Deprecated: replaced by Stats().
@@ -167,6 +263,7 @@ type Stats struct {
 	LastSequenceNumber uint16
 	LastRTP            uint32
 	LastNTP            time.Time
+	Receivers          map[uint32]ReceiverStats
 }
 
 // Stats returns statistics.
@@ -178,10 +275,20 @@ func (rs *RTCPSender) Stats() *Stats {
 		return nil
 	}
 
+	var receivers map[uint32]ReceiverStats
+
+	if len(rs.receivers) != 0 {
+		receivers = make(map[uint32]ReceiverStats, len(rs.receivers))
+		for ssrc, stats := range rs.receivers {
+			receivers[ssrc] = *stats
+		}
+	}
+
 	return &Stats{
 		LocalSSRC:          rs.localSSRC,
 		LastSequenceNumber: rs.lastSequenceNumber,
 		LastRTP:            rs.lastTimeRTP,
 		LastNTP:            rs.lastTimeNTP,
+		Receivers:          receivers,
 	}
 }
@@ -167,3 +167,85 @@ func TestRTCPSenderZeroClockRate(t *testing.T) {
 		LastNTP:            time.Date(2008, time.May, 20, 22, 15, 20, 0, time.UTC),
 	}, stats)
 }
+
+func TestRTCPSenderReceiverReport(t *testing.T) {
+	var curTime time.Time
+	var mutex sync.Mutex
+
+	setCurTime := func(v time.Time) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		curTime = v
+	}
+
+	pktGenerated := make(chan rtcp.Packet, 1)
+
+	rs := &RTCPSender{
+		ClockRate: 90000,
+		Period:    100 * time.Millisecond,
+		TimeNow: func() time.Time {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return curTime
+		},
+		WritePacketRTCP: func(pkt rtcp.Packet) {
+			select {
+			case pktGenerated <- pkt:
+			default:
+			}
+		},
+	}
+	rs.Initialize()
+	defer rs.Close()
+
+	setCurTime(time.Date(2008, 5, 20, 22, 16, 20, 0, time.UTC))
+	rs.ProcessPacket(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 946,
+			Timestamp:      1287987768,
+			SSRC:           0xba9da416,
+		},
+		Payload: []byte("\x00\x00"),
+	}, time.Date(2008, 0o5, 20, 22, 15, 20, 0, time.UTC), true)
+
+	pkt := <-pktGenerated
+	sr := pkt.(*rtcp.SenderReport)
+
+	stats := rs.ProcessReceiverReport(&rtcp.ReceiverReport{
+		SSRC: 0x12345678,
+		Reports: []rtcp.ReceptionReport{{
+			SSRC: 0x11111111,
+		}},
+	}, time.Date(2008, 5, 20, 22, 16, 21, 0, time.UTC))
+	require.Nil(t, stats)
+
+	stats = rs.ProcessReceiverReport(&rtcp.ReceiverReport{
+		SSRC: 0x12345678,
+		Reports: []rtcp.ReceptionReport{{
+			SSRC:             0xba9da416,
+			FractionLost:     64,
+			TotalLost:        12,
+			Jitter:           450,
+			LastSenderReport: uint32(sr.NTPTime >> 16),
+			Delay:            65536 / 2,
+		}},
+	}, time.Date(2008, 5, 20, 22, 16, 21, 0, time.UTC))
+	require.Equal(t, &ReceiverStats{
+		SSRC:         0x12345678,
+		FractionLost: 0.25,
+		TotalLost:    12,
+		Jitter:       450,
+		RTT:          500 * time.Millisecond,
+		LastReport:   time.Date(2008, 5, 20, 22, 16, 21, 0, time.UTC),
+	}, stats)
+
+	require.Equal(t, map[uint32]ReceiverStats{
+		0x12345678: *stats,
+	}, rs.Stats().Receivers)
+
+	rs.RemoveReceiver(0x12345678)
+	require.Nil(t, rs.Stats().Receivers)
+}
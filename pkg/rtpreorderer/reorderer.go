@@ -18,9 +18,14 @@ type Reorderer struct {
 	buffer         []*rtp.Packet
 	absPos         uint16
 	negativeCount  int
+	highestSeqNum  uint16
 
 	// Maximum number of packets to buffer for reordering
 	BufferSize int
+
+	// (optional) called when a gap is detected, with the sequence numbers of missing packets.
+	// It can be used to request retransmissions.
+	OnMissing func(seqNums []uint16)
 }
 
 // New allocates a Reorderer.
@@ -46,6 +51,7 @@ func (r *Reorderer) Process(pkt *rtp.Packet) ([]*rtp.Packet, uint) {
 	if !r.initialized {
 		r.initialized = true
 		r.expectedSeqNum = pkt.SequenceNumber + 1
+		r.highestSeqNum = pkt.SequenceNumber
 		return []*rtp.Packet{pkt}, 0
 	}
 
@@ -69,6 +75,7 @@ func (r *Reorderer) Process(pkt *rtp.Packet) ([]*rtp.Packet, uint) {
 
 			// reset position
 			r.expectedSeqNum = pkt.SequenceNumber + 1
+			r.highestSeqNum = pkt.SequenceNumber
 			return []*rtp.Packet{pkt}, 0
 		}
 
@@ -101,6 +108,7 @@ func (r *Reorderer) Process(pkt *rtp.Packet) ([]*rtp.Packet, uint) {
 		ret[pos] = pkt
 
 		r.expectedSeqNum = pkt.SequenceNumber + 1
+		r.highestSeqNum = pkt.SequenceNumber
 		return ret, uint(int(relPos) - n + 1)
 	}
 
@@ -115,6 +123,19 @@ func (r *Reorderer) Process(pkt *rtp.Packet) ([]*rtp.Packet, uint) {
 
 		// put current packet in buffer
 		r.buffer[p] = pkt
+
+		// report packets between the highest received one and the current one
+		if diff := int16(pkt.SequenceNumber - r.highestSeqNum); diff > 0 {
+			if r.OnMissing != nil && diff > 1 {
+				seqNums := make([]uint16, diff-1)
+				for i := range seqNums {
+					seqNums[i] = r.highestSeqNum + 1 + uint16(i)
+				}
+				r.OnMissing(seqNums)
+			}
+			r.highestSeqNum = pkt.SequenceNumber
+		}
+
 		return nil, 0
 	}
 
@@ -144,5 +165,9 @@ func (r *Reorderer) Process(pkt *rtp.Packet) ([]*rtp.Packet, uint) {
 
 	r.expectedSeqNum = pkt.SequenceNumber + n
 
+	if int16(r.expectedSeqNum-1-r.highestSeqNum) > 0 {
+		r.highestSeqNum = r.expectedSeqNum - 1
+	}
+
 	return ret, 0
 }
@@ -301,3 +301,27 @@ func TestCustomBufferSize(t *testing.T) {
 		},
 	}}, out)
 }
+
+func TestOnMissing(t *testing.T) {
+	var missing [][]uint16
+
+	r := &Reorderer{
+		OnMissing: func(seqNums []uint16) {
+			missing = append(missing, seqNums)
+		},
+	}
+	r.Initialize()
+
+	for _, sn := range []uint16{65533, 65534, 2, 1, 3, 5, 65535, 0, 4, 6} {
+		r.Process(&rtp.Packet{
+			Header: rtp.Header{
+				SequenceNumber: sn,
+			},
+		})
+	}
+
+	require.Equal(t, [][]uint16{
+		{65535, 0, 1},
+		{4},
+	}, missing)
+}
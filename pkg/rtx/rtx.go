@@ -0,0 +1,150 @@
+// Package rtx contains utilities to handle RTP retransmissions (RFC4588).
+package rtx
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+const (
+	defaultHistorySize = 512
+)
+
+func randUint32() (uint32, error) {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
+
+// Sender keeps a history of sent RTP packets and
+// generates retransmissions in response to NACKs.
+type Sender struct {
+	// payload type of retransmissions.
+	PayloadType uint8
+
+	// SSRC of retransmissions (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// number of packets to keep in history (optional).
+	// It must be a power of two.
+	// It defaults to 512.
+	HistorySize int
+
+	mutex          sync.Mutex
+	history        []*rtp.Packet
+	sequenceNumber uint16
+}
+
+// Initialize initializes a Sender.
+func (s *Sender) Initialize() error {
+	if s.SSRC == nil {
+		v, err := randUint32()
+		if err != nil {
+			return err
+		}
+		s.SSRC = &v
+	}
+
+	if s.HistorySize == 0 {
+		s.HistorySize = defaultHistorySize
+	}
+
+	if (s.HistorySize & (s.HistorySize - 1)) != 0 {
+		return fmt.Errorf("history size must be a power of two")
+	}
+
+	v, err := randUint32()
+	if err != nil {
+		return err
+	}
+	s.sequenceNumber = uint16(v)
+
+	s.history = make([]*rtp.Packet, s.HistorySize)
+
+	return nil
+}
+
+// ProcessPacket adds a sent RTP packet to the history.
+func (s *Sender) ProcessPacket(pkt *rtp.Packet) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.history[pkt.SequenceNumber&uint16(s.HistorySize-1)] = pkt.Clone()
+}
+
+// ProcessNACK returns retransmissions of packets requested by a NACK.
+// Packets that are not in history anymore are skipped.
+func (s *Sender) ProcessNACK(nack *rtcp.TransportLayerNack) []*rtp.Packet {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.processNACK(nack, &s.sequenceNumber)
+}
+
+// ProcessNACKWithSequenceNumber is like ProcessNACK, but sequence numbers of
+// retransmissions are taken from seqNum, that is increased accordingly.
+// It allows to share the history between receivers that need a separate retransmission stream.
+func (s *Sender) ProcessNACKWithSequenceNumber(nack *rtcp.TransportLayerNack, seqNum *uint16) []*rtp.Packet {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.processNACK(nack, seqNum)
+}
+
+func (s *Sender) processNACK(nack *rtcp.TransportLayerNack, rtxSeqNum *uint16) []*rtp.Packet {
+	var ret []*rtp.Packet
+
+	for _, pair := range nack.Nacks {
+		for _, seqNum := range pair.PacketList() {
+			orig := s.history[seqNum&uint16(s.HistorySize-1)]
+			if orig == nil || orig.SequenceNumber != seqNum || orig.SSRC != nack.MediaSSRC {
+				continue
+			}
+
+			payload := make([]byte, 2+len(orig.Payload))
+			payload[0] = byte(seqNum >> 8)
+			payload[1] = byte(seqNum)
+			copy(payload[2:], orig.Payload)
+
+			pkt := &rtp.Packet{
+				Header:  orig.Header.Clone(),
+				Payload: payload,
+			}
+			pkt.PayloadType = s.PayloadType
+			pkt.SSRC = *s.SSRC
+			pkt.SequenceNumber = *rtxSeqNum
+			pkt.Padding = false
+			pkt.PaddingSize = 0
+			*rtxSeqNum++
+
+			ret = append(ret, pkt)
+		}
+	}
+
+	return ret
+}
+
+// Decode restores the original packet from a retransmission.
+func Decode(pkt *rtp.Packet, associatedPayloadType uint8, ssrc uint32) (*rtp.Packet, error) {
+	if len(pkt.Payload) < 2 {
+		return nil, fmt.Errorf("invalid retransmission size")
+	}
+
+	ret := &rtp.Packet{
+		Header:  pkt.Header.Clone(),
+		Payload: pkt.Payload[2:],
+	}
+	ret.PayloadType = associatedPayloadType
+	ret.SSRC = ssrc
+	ret.SequenceNumber = uint16(pkt.Payload[0])<<8 | uint16(pkt.Payload[1])
+
+	return ret, nil
+}
@@ -0,0 +1,123 @@
+package rtx
+
+import (
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestSender(t *testing.T) {
+	s := &Sender{
+		PayloadType: 97,
+		SSRC:        uint32Ptr(0x55667788),
+		HistorySize: 4,
+	}
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	for i := uint16(0); i < 6; i++ {
+		s.ProcessPacket(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         true,
+				PayloadType:    96,
+				SequenceNumber: 65533 + i,
+				Timestamp:      45343 + uint32(i),
+				SSRC:           0x11223344,
+			},
+			Payload: []byte{1, 2, 3, byte(i)},
+		})
+	}
+
+	pkts := s.ProcessNACK(&rtcp.TransportLayerNack{
+		MediaSSRC: 0x11223344,
+		Nacks:     rtcp.NackPairsFromSequenceNumbers([]uint16{65533, 65535, 1, 2}),
+	})
+	require.Len(t, pkts, 3)
+
+	require.Equal(t, uint8(97), pkts[0].PayloadType)
+	require.Equal(t, uint32(0x55667788), pkts[0].SSRC)
+	require.Equal(t, pkts[0].SequenceNumber+1, pkts[1].SequenceNumber)
+	require.Equal(t, []byte{0xff, 0xff, 1, 2, 3, 2}, pkts[0].Payload)
+	require.Equal(t, []byte{0x00, 0x01, 1, 2, 3, 4}, pkts[1].Payload)
+	require.Equal(t, []byte{0x00, 0x02, 1, 2, 3, 5}, pkts[2].Payload)
+
+	orig, err := Decode(pkts[1], 96, 0x11223344)
+	require.NoError(t, err)
+	require.Equal(t, &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         true,
+			PayloadType:    96,
+			SequenceNumber: 1,
+			Timestamp:      45347,
+			SSRC:           0x11223344,
+		},
+		Payload: []byte{1, 2, 3, 4},
+	}, orig)
+
+	pkts = s.ProcessNACK(&rtcp.TransportLayerNack{
+		MediaSSRC: 0x01020304,
+		Nacks:     rtcp.NackPairsFromSequenceNumbers([]uint16{1}),
+	})
+	require.Len(t, pkts, 0)
+}
+
+func TestSenderWithSequenceNumber(t *testing.T) {
+	s := &Sender{
+		PayloadType: 97,
+	}
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	s.ProcessPacket(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 123,
+			SSRC:           0x11223344,
+		},
+		Payload: []byte{1, 2, 3},
+	})
+
+	nack := &rtcp.TransportLayerNack{
+		MediaSSRC: 0x11223344,
+		Nacks:     rtcp.NackPairsFromSequenceNumbers([]uint16{123}),
+	}
+
+	seqNum1 := uint16(65535)
+	seqNum2 := uint16(500)
+
+	pkts := s.ProcessNACKWithSequenceNumber(nack, &seqNum1)
+	require.Len(t, pkts, 1)
+	require.Equal(t, uint16(65535), pkts[0].SequenceNumber)
+	require.Equal(t, uint16(0), seqNum1)
+
+	pkts = s.ProcessNACKWithSequenceNumber(nack, &seqNum2)
+	require.Len(t, pkts, 1)
+	require.Equal(t, uint16(500), pkts[0].SequenceNumber)
+	require.Equal(t, uint16(501), seqNum2)
+
+	pkts = s.ProcessNACKWithSequenceNumber(nack, &seqNum1)
+	require.Len(t, pkts, 1)
+	require.Equal(t, uint16(0), pkts[0].SequenceNumber)
+}
+
+func TestSenderInvalidHistorySize(t *testing.T) {
+	s := &Sender{
+		HistorySize: 3,
+	}
+	err := s.Initialize()
+	require.EqualError(t, err, "history size must be a power of two")
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode(&rtp.Packet{Payload: []byte{1}}, 96, 0)
+	require.EqualError(t, err, "invalid retransmission size")
+}
@@ -0,0 +1,508 @@
+package srtp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	labelRTPEncryption  = 0x00
+	labelRTPAuth        = 0x01
+	labelRTPSalt        = 0x02
+	labelRTCPEncryption = 0x03
+	labelRTCPAuth       = 0x04
+	labelRTCPSalt       = 0x05
+
+	// labels used to derive the master key of the opposite direction.
+	// they are outside the range used by RFC3711.
+	labelReverseMasterKey  = 0x10
+	labelReverseMasterSalt = 0x11
+
+	authKeyLen = 20
+
+	rtcpHeaderLen = 8
+	rtcpIndexLen  = 4
+	rtcpIndexMask = 0x7FFFFFFF
+
+	replayWindowSize = 64
+)
+
+// deriveKey implements the AES-CM key derivation function (RFC3711, section 4.3).
+func deriveKey(masterKey []byte, masterSalt []byte, label byte, l int) ([]byte, error) {
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var iv [16]byte
+	copy(iv[:], masterSalt)
+	iv[7] ^= label
+
+	out := make([]byte, l)
+	cipher.NewCTR(block, iv[:]).XORKeyStream(out, out)
+	return out, nil
+}
+
+type sessionKeys struct {
+	block   cipher.Block
+	aead    cipher.AEAD
+	authKey []byte
+	salt    []byte
+}
+
+func newSessionKeys(k *Key, labelEnc byte, labelAuth byte, labelSalt byte) (*sessionKeys, error) {
+	encKey, err := deriveKey(k.MasterKey, k.MasterSalt, labelEnc, k.Profile.KeyLen())
+	if err != nil {
+		return nil, err
+	}
+
+	sk := &sessionKeys{}
+
+	sk.salt, err = deriveKey(k.MasterKey, k.MasterSalt, labelSalt, k.Profile.SaltLen())
+	if err != nil {
+		return nil, err
+	}
+
+	sk.block, err = aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.Profile.isAEAD() {
+		sk.aead, err = cipher.NewGCM(sk.block)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sk.authKey, err = deriveKey(k.MasterKey, k.MasterSalt, labelAuth, authKeyLen)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sk, nil
+}
+
+// replayWindow is a sliding window that detects replayed packets (RFC3711, section 3.3.2).
+type replayWindow struct {
+	initialized bool
+	highest     uint64
+	mask        uint64
+}
+
+func (w *replayWindow) check(index uint64) bool {
+	if !w.initialized || index > w.highest {
+		return true
+	}
+
+	diff := w.highest - index
+	if diff >= replayWindowSize {
+		return false
+	}
+
+	return (w.mask & (1 << diff)) == 0
+}
+
+func (w *replayWindow) add(index uint64) {
+	if !w.initialized {
+		w.initialized = true
+		w.highest = index
+		w.mask = 1
+		return
+	}
+
+	if index > w.highest {
+		diff := index - w.highest
+		if diff >= replayWindowSize {
+			w.mask = 0
+		} else {
+			w.mask <<= diff
+		}
+		w.mask |= 1
+		w.highest = index
+		return
+	}
+
+	w.mask |= 1 << (w.highest - index)
+}
+
+type rtpSenderState struct {
+	initialized bool
+	roc         uint32
+	lastSeq     uint16
+}
+
+func (s *rtpSenderState) index(seq uint16) uint32 {
+	if !s.initialized {
+		s.initialized = true
+	} else if seq < s.lastSeq && (s.lastSeq-seq) > 0x8000 {
+		s.roc++
+	}
+	s.lastSeq = seq
+	return s.roc
+}
+
+type rtpReceiverState struct {
+	initialized bool
+	roc         uint32
+	lastSeq     uint16
+	replay      replayWindow
+}
+
+// estimateROC implements the index estimation algorithm (RFC3711, appendix A).
+func (s *rtpReceiverState) estimateROC(seq uint16) uint32 {
+	if !s.initialized {
+		return s.roc
+	}
+
+	if s.lastSeq < 0x8000 {
+		if seq > s.lastSeq && (seq-s.lastSeq) > 0x8000 {
+			return s.roc - 1
+		}
+		return s.roc
+	}
+
+	if (s.lastSeq - 0x8000) > seq {
+		return s.roc + 1
+	}
+	return s.roc
+}
+
+func (s *rtpReceiverState) update(roc uint32, seq uint16) {
+	switch {
+	case !s.initialized:
+		s.initialized = true
+		s.roc = roc
+		s.lastSeq = seq
+
+	case roc == s.roc+1:
+		s.roc = roc
+		s.lastSeq = seq
+
+	case roc == s.roc && seq > s.lastSeq:
+		s.lastSeq = seq
+	}
+}
+
+// Context is a SRTP/SRTCP cryptographic context.
+// A single context can be used to protect outgoing packets and unprotect incoming packets
+// of multiple SSRCs that share the same master key.
+type Context struct {
+	// master key.
+	Key *Key
+
+	rtpKeys  *sessionKeys
+	rtcpKeys *sessionKeys
+
+	mutex             sync.Mutex
+	rtpSenders        map[uint32]*rtpSenderState
+	rtpReceivers      map[uint32]*rtpReceiverState
+	rtcpSenderIndexes map[uint32]uint32
+	rtcpReceivers     map[uint32]*replayWindow
+}
+
+// Initialize initializes a Context.
+func (c *Context) Initialize() error {
+	if c.Key == nil {
+		return fmt.Errorf("key not provided")
+	}
+
+	err := c.Key.Validate()
+	if err != nil {
+		return err
+	}
+
+	c.rtpKeys, err = newSessionKeys(c.Key, labelRTPEncryption, labelRTPAuth, labelRTPSalt)
+	if err != nil {
+		return err
+	}
+
+	c.rtcpKeys, err = newSessionKeys(c.Key, labelRTCPEncryption, labelRTCPAuth, labelRTCPSalt)
+	if err != nil {
+		return err
+	}
+
+	c.rtpSenders = make(map[uint32]*rtpSenderState)
+	c.rtpReceivers = make(map[uint32]*rtpReceiverState)
+	c.rtcpSenderIndexes = make(map[uint32]uint32)
+	c.rtcpReceivers = make(map[uint32]*replayWindow)
+
+	return nil
+}
+
+func (c *Context) ctrIV(salt []byte, ssrc uint32, index uint64) []byte {
+	iv := make([]byte, 16)
+	copy(iv, salt)
+
+	var tmp [8]byte
+	binary.BigEndian.PutUint32(tmp[:4], ssrc)
+	subtle.XORBytes(iv[4:8], iv[4:8], tmp[:4])
+
+	binary.BigEndian.PutUint64(tmp[:], index<<16)
+	subtle.XORBytes(iv[8:16], iv[8:16], tmp[:])
+
+	return iv
+}
+
+func (c *Context) gcmIV(salt []byte, ssrc uint32, roc uint32, seq uint16) []byte {
+	iv := make([]byte, 12)
+	binary.BigEndian.PutUint32(iv[2:], ssrc)
+	binary.BigEndian.PutUint32(iv[6:], roc)
+	binary.BigEndian.PutUint16(iv[10:], seq)
+	subtle.XORBytes(iv, iv, salt)
+	return iv
+}
+
+func (c *Context) gcmRTCPIV(salt []byte, ssrc uint32, index uint32) []byte {
+	iv := make([]byte, 12)
+	binary.BigEndian.PutUint32(iv[2:], ssrc)
+	binary.BigEndian.PutUint32(iv[8:], index)
+	subtle.XORBytes(iv, iv, salt)
+	return iv
+}
+
+func (c *Context) hmacTag(authKey []byte, tagLen int, parts ...[]byte) []byte {
+	mac := hmac.New(sha1.New, authKey)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)[:tagLen]
+}
+
+// EncryptRTP protects a RTP packet.
+func (c *Context) EncryptRTP(buf []byte) ([]byte, error) {
+	var h rtp.Header
+	headerLen, err := h.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	st, ok := c.rtpSenders[h.SSRC]
+	if !ok {
+		st = &rtpSenderState{}
+		c.rtpSenders[h.SSRC] = st
+	}
+	roc := st.index(h.SequenceNumber)
+	c.mutex.Unlock()
+
+	if c.rtpKeys.aead != nil {
+		iv := c.gcmIV(c.rtpKeys.salt, h.SSRC, roc, h.SequenceNumber)
+		out := make([]byte, headerLen, len(buf)+c.Key.Profile.rtpTagLen())
+		copy(out, buf[:headerLen])
+		return c.rtpKeys.aead.Seal(out, iv, buf[headerLen:], buf[:headerLen]), nil
+	}
+
+	out := make([]byte, len(buf), len(buf)+c.Key.Profile.rtpTagLen())
+	copy(out, buf[:headerLen])
+
+	index := uint64(roc)<<16 | uint64(h.SequenceNumber)
+	iv := c.ctrIV(c.rtpKeys.salt, h.SSRC, index)
+	cipher.NewCTR(c.rtpKeys.block, iv).XORKeyStream(out[headerLen:], buf[headerLen:])
+
+	var rocByts [4]byte
+	binary.BigEndian.PutUint32(rocByts[:], roc)
+
+	return append(out, c.hmacTag(c.rtpKeys.authKey, c.Key.Profile.rtpTagLen(), out, rocByts[:])...), nil
+}
+
+// DecryptRTP unprotects a SRTP packet.
+func (c *Context) DecryptRTP(buf []byte) ([]byte, error) {
+	var h rtp.Header
+	headerLen, err := h.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	tagLen := c.Key.Profile.rtpTagLen()
+
+	if len(buf) < (headerLen + tagLen) {
+		return nil, fmt.Errorf("SRTP packet is too short")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	st, ok := c.rtpReceivers[h.SSRC]
+	if !ok {
+		st = &rtpReceiverState{}
+	}
+
+	roc := st.estimateROC(h.SequenceNumber)
+	index := uint64(roc)<<16 | uint64(h.SequenceNumber)
+
+	if !st.replay.check(index) {
+		return nil, fmt.Errorf("SRTP packet is a replay")
+	}
+
+	var out []byte
+
+	if c.rtpKeys.aead != nil {
+		iv := c.gcmIV(c.rtpKeys.salt, h.SSRC, roc, h.SequenceNumber)
+		out = make([]byte, headerLen, len(buf)-tagLen)
+		copy(out, buf[:headerLen])
+
+		out, err = c.rtpKeys.aead.Open(out, iv, buf[headerLen:], buf[:headerLen])
+		if err != nil {
+			return nil, fmt.Errorf("SRTP authentication failed")
+		}
+	} else {
+		authenticated := buf[:len(buf)-tagLen]
+
+		var rocByts [4]byte
+		binary.BigEndian.PutUint32(rocByts[:], roc)
+
+		tag := c.hmacTag(c.rtpKeys.authKey, tagLen, authenticated, rocByts[:])
+		if !hmac.Equal(tag, buf[len(buf)-tagLen:]) {
+			return nil, fmt.Errorf("SRTP authentication failed")
+		}
+
+		out = make([]byte, len(authenticated))
+		copy(out, buf[:headerLen])
+
+		iv := c.ctrIV(c.rtpKeys.salt, h.SSRC, index)
+		cipher.NewCTR(c.rtpKeys.block, iv).XORKeyStream(out[headerLen:], authenticated[headerLen:])
+	}
+
+	if !ok {
+		c.rtpReceivers[h.SSRC] = st
+	}
+	st.update(roc, h.SequenceNumber)
+	st.replay.add(index)
+
+	return out, nil
+}
+
+// EncryptRTCP protects a RTCP packet.
+func (c *Context) EncryptRTCP(buf []byte) ([]byte, error) {
+	if len(buf) < rtcpHeaderLen {
+		return nil, fmt.Errorf("RTCP packet is too short")
+	}
+
+	ssrc := binary.BigEndian.Uint32(buf[4:])
+
+	c.mutex.Lock()
+	index := c.rtcpSenderIndexes[ssrc]
+	c.rtcpSenderIndexes[ssrc] = (index + 1) & rtcpIndexMask
+	c.mutex.Unlock()
+
+	var eIndex [4]byte
+	binary.BigEndian.PutUint32(eIndex[:], index|(1<<31))
+
+	if c.rtcpKeys.aead != nil {
+		iv := c.gcmRTCPIV(c.rtcpKeys.salt, ssrc, index)
+		out := make([]byte, rtcpHeaderLen, len(buf)+c.Key.Profile.rtcpTagLen()+rtcpIndexLen)
+		copy(out, buf[:rtcpHeaderLen])
+
+		aad := make([]byte, rtcpHeaderLen+rtcpIndexLen)
+		copy(aad, buf[:rtcpHeaderLen])
+		copy(aad[rtcpHeaderLen:], eIndex[:])
+
+		out = c.rtcpKeys.aead.Seal(out, iv, buf[rtcpHeaderLen:], aad)
+		return append(out, eIndex[:]...), nil
+	}
+
+	out := make([]byte, len(buf), len(buf)+rtcpIndexLen+c.Key.Profile.rtcpTagLen())
+	copy(out, buf[:rtcpHeaderLen])
+
+	iv := c.ctrIV(c.rtcpKeys.salt, ssrc, uint64(index))
+	cipher.NewCTR(c.rtcpKeys.block, iv).XORKeyStream(out[rtcpHeaderLen:], buf[rtcpHeaderLen:])
+
+	out = append(out, eIndex[:]...)
+
+	return append(out, c.hmacTag(c.rtcpKeys.authKey, c.Key.Profile.rtcpTagLen(), out)...), nil
+}
+
+// DecryptRTCP unprotects a SRTCP packet.
+func (c *Context) DecryptRTCP(buf []byte) ([]byte, error) {
+	tagLen := c.Key.Profile.rtcpTagLen()
+
+	if len(buf) < (rtcpHeaderLen + rtcpIndexLen + tagLen) {
+		return nil, fmt.Errorf("SRTCP packet is too short")
+	}
+
+	ssrc := binary.BigEndian.Uint32(buf[4:])
+
+	var eIndexPos int
+	if c.rtcpKeys.aead != nil {
+		eIndexPos = len(buf) - rtcpIndexLen
+	} else {
+		eIndexPos = len(buf) - tagLen - rtcpIndexLen
+	}
+
+	eIndex := binary.BigEndian.Uint32(buf[eIndexPos:])
+	encrypted := (eIndex >> 31) != 0
+	index := eIndex & rtcpIndexMask
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	replay, ok := c.rtcpReceivers[ssrc]
+	if !ok {
+		replay = &replayWindow{}
+	}
+
+	if !replay.check(uint64(index)) {
+		return nil, fmt.Errorf("SRTCP packet is a replay")
+	}
+
+	var out []byte
+
+	if c.rtcpKeys.aead != nil {
+		aad := make([]byte, rtcpHeaderLen+rtcpIndexLen)
+		copy(aad, buf[:rtcpHeaderLen])
+		copy(aad[rtcpHeaderLen:], buf[eIndexPos:])
+
+		iv := c.gcmRTCPIV(c.rtcpKeys.salt, ssrc, index)
+
+		if encrypted {
+			out = make([]byte, rtcpHeaderLen, eIndexPos-tagLen)
+			copy(out, buf[:rtcpHeaderLen])
+
+			var err error
+			out, err = c.rtcpKeys.aead.Open(out, iv, buf[rtcpHeaderLen:eIndexPos], aad)
+			if err != nil {
+				return nil, fmt.Errorf("SRTCP authentication failed")
+			}
+		} else {
+			// the whole packet is authenticated, but not encrypted
+			aad = append(append([]byte(nil), buf[:eIndexPos-tagLen]...), buf[eIndexPos:]...)
+			_, err := c.rtcpKeys.aead.Open(nil, iv, buf[eIndexPos-tagLen:eIndexPos], aad)
+			if err != nil {
+				return nil, fmt.Errorf("SRTCP authentication failed")
+			}
+
+			out = append([]byte(nil), buf[:eIndexPos-tagLen]...)
+		}
+	} else {
+		authenticated := buf[:len(buf)-tagLen]
+
+		tag := c.hmacTag(c.rtcpKeys.authKey, tagLen, authenticated)
+		if !hmac.Equal(tag, buf[len(buf)-tagLen:]) {
+			return nil, fmt.Errorf("SRTCP authentication failed")
+		}
+
+		out = make([]byte, eIndexPos)
+		copy(out, buf[:eIndexPos])
+
+		if encrypted {
+			iv := c.ctrIV(c.rtcpKeys.salt, ssrc, uint64(index))
+			cipher.NewCTR(c.rtcpKeys.block, iv).XORKeyStream(out[rtcpHeaderLen:], buf[rtcpHeaderLen:eIndexPos])
+		}
+	}
+
+	if !ok {
+		c.rtcpReceivers[ssrc] = replay
+	}
+	replay.add(uint64(index))
+
+	return out, nil
+}
@@ -0,0 +1,209 @@
+package srtp
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecodeHex(s string) []byte {
+	byts, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return byts
+}
+
+func TestDeriveKey(t *testing.T) {
+	// RFC3711, appendix B.3
+	masterKey := mustDecodeHex("E1F97A0D3E018BE0D64FA32C06DE4139")
+	masterSalt := mustDecodeHex("0EC675AD498AFEEBB6960B3AABE6")
+
+	encKey, err := deriveKey(masterKey, masterSalt, labelRTPEncryption, 16)
+	require.NoError(t, err)
+	require.Equal(t, mustDecodeHex("C61E7A93744F39EE10734AFE3FF7A087"), encKey)
+
+	salt, err := deriveKey(masterKey, masterSalt, labelRTPSalt, 14)
+	require.NoError(t, err)
+	require.Equal(t, mustDecodeHex("30CBBC08863D8C85D49DB34A9AE1"), salt)
+
+	authKey, err := deriveKey(masterKey, masterSalt, labelRTPAuth, 20)
+	require.NoError(t, err)
+	require.Equal(t, mustDecodeHex("CEBE321F6FF7716B6FD4AB49AF256A156D38BAA4"), authKey)
+}
+
+var casesProfiles = []ProtectionProfile{
+	ProtectionProfileAESCM128HMACSHA180,
+	ProtectionProfileAESCM128HMACSHA132,
+	ProtectionProfileAEADAES128GCM,
+	ProtectionProfileAEADAES256GCM,
+}
+
+func newTestContexts(t *testing.T, profile ProtectionProfile) (*Context, *Context) {
+	key, err := GenerateKey(profile)
+	require.NoError(t, err)
+
+	enc := &Context{Key: key}
+	err = enc.Initialize()
+	require.NoError(t, err)
+
+	dec := &Context{Key: key}
+	err = dec.Initialize()
+	require.NoError(t, err)
+
+	return enc, dec
+}
+
+func TestRTP(t *testing.T) {
+	for _, profile := range casesProfiles {
+		t.Run(profile.String(), func(t *testing.T) {
+			enc, dec := newTestContexts(t, profile)
+
+			for _, seqNum := range []uint16{65534, 65535, 0, 1} {
+				pkt := &rtp.Packet{
+					Header: rtp.Header{
+						Version:        2,
+						PayloadType:    96,
+						SequenceNumber: seqNum,
+						Timestamp:      45343,
+						SSRC:           563423,
+						CSRC:           []uint32{1234},
+					},
+					Payload: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				}
+				byts, err := pkt.Marshal()
+				require.NoError(t, err)
+
+				encrypted, err := enc.EncryptRTP(byts)
+				require.NoError(t, err)
+				require.Equal(t, len(byts)+profile.rtpTagLen(), len(encrypted))
+				require.NotEqual(t, byts[len(byts)-8:], encrypted[len(byts)-8:len(byts)])
+
+				decrypted, err := dec.DecryptRTP(encrypted)
+				require.NoError(t, err)
+				require.Equal(t, byts, decrypted)
+
+				_, err = dec.DecryptRTP(encrypted)
+				require.EqualError(t, err, "SRTP packet is a replay")
+			}
+
+			require.Equal(t, uint32(1), dec.rtpReceivers[563423].roc)
+		})
+	}
+}
+
+func TestRTPAuthError(t *testing.T) {
+	for _, profile := range casesProfiles {
+		t.Run(profile.String(), func(t *testing.T) {
+			enc, dec := newTestContexts(t, profile)
+
+			byts, err := (&rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 123,
+					SSRC:           563423,
+				},
+				Payload: []byte{1, 2, 3, 4},
+			}).Marshal()
+			require.NoError(t, err)
+
+			encrypted, err := enc.EncryptRTP(byts)
+			require.NoError(t, err)
+
+			encrypted[len(encrypted)-1] ^= 0xFF
+
+			_, err = dec.DecryptRTP(encrypted)
+			require.EqualError(t, err, "SRTP authentication failed")
+		})
+	}
+}
+
+func TestRTCP(t *testing.T) {
+	for _, profile := range casesProfiles {
+		t.Run(profile.String(), func(t *testing.T) {
+			enc, dec := newTestContexts(t, profile)
+
+			for i := 0; i < 3; i++ {
+				byts, err := (&rtcp.SenderReport{
+					SSRC:        563423,
+					NTPTime:     0xe8f3fd0e6b851eb8,
+					RTPTime:     1234,
+					PacketCount: uint32(i),
+					OctetCount:  5678,
+				}).Marshal()
+				require.NoError(t, err)
+
+				encrypted, err := enc.EncryptRTCP(byts)
+				require.NoError(t, err)
+				require.Equal(t, len(byts)+rtcpIndexLen+profile.rtcpTagLen(), len(encrypted))
+
+				decrypted, err := dec.DecryptRTCP(encrypted)
+				require.NoError(t, err)
+				require.Equal(t, byts, decrypted)
+
+				_, err = dec.DecryptRTCP(encrypted)
+				require.EqualError(t, err, "SRTCP packet is a replay")
+
+				encrypted[9] ^= 0xFF
+				_, err = dec.DecryptRTCP(encrypted)
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	var w replayWindow
+
+	w.add(100)
+	require.False(t, w.check(100))
+	require.True(t, w.check(99))
+	require.True(t, w.check(101))
+
+	w.add(200)
+	require.False(t, w.check(100))
+	require.True(t, w.check(199))
+
+	w.add(199)
+	require.False(t, w.check(199))
+	require.False(t, w.check(136))
+	require.True(t, w.check(137))
+}
+
+func TestProtectionProfileUnmarshal(t *testing.T) {
+	for _, profile := range casesProfiles {
+		var dec ProtectionProfile
+		err := dec.Unmarshal(profile.String())
+		require.NoError(t, err)
+		require.Equal(t, profile, dec)
+	}
+
+	var dec ProtectionProfile
+	err := dec.Unmarshal("F8_128_HMAC_SHA1_80")
+	require.EqualError(t, err, "unsupported protection profile: 'F8_128_HMAC_SHA1_80'")
+}
+
+func TestKeyDeriveReverse(t *testing.T) {
+	for _, profile := range casesProfiles {
+		t.Run(profile.String(), func(t *testing.T) {
+			key, err := GenerateKey(profile)
+			require.NoError(t, err)
+
+			rev, err := key.DeriveReverse()
+			require.NoError(t, err)
+			require.NoError(t, rev.Validate())
+			require.Equal(t, profile, rev.Profile)
+			require.NotEqual(t, key.MasterKey, rev.MasterKey)
+			require.NotEqual(t, key.MasterSalt, rev.MasterSalt)
+
+			// derivation is deterministic, so that both sides obtain the same key
+			rev2, err := key.DeriveReverse()
+			require.NoError(t, err)
+			require.Equal(t, rev, rev2)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package srtp
+
+import (
+	"fmt"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/mikey"
+)
+
+func mikeyProfile(msg *mikey.Message) ProtectionProfile {
+	if len(msg.Policies) == 0 {
+		return ProtectionProfileAESCM128HMACSHA180
+	}
+
+	p := msg.Policies[0]
+
+	if v, ok := p.Param(mikey.PolicyParamEncrAlg); ok && len(v) == 1 && v[0] == mikey.EncrAlgAESGCM {
+		if v, ok := p.Param(mikey.PolicyParamEncrKeyLen); ok && len(v) == 1 && v[0] == 32 {
+			return ProtectionProfileAEADAES256GCM
+		}
+		return ProtectionProfileAEADAES128GCM
+	}
+
+	if v, ok := p.Param(mikey.PolicyParamAuthTagLen); ok && len(v) == 1 && v[0] == 4 {
+		return ProtectionProfileAESCM128HMACSHA132
+	}
+
+	return ProtectionProfileAESCM128HMACSHA180
+}
+
+// UnmarshalMIKEY decodes a key from a MIKEY message.
+func (k *Key) UnmarshalMIKEY(msg *mikey.Message) error {
+	if len(msg.Keys) == 0 {
+		return fmt.Errorf("MIKEY message doesn't contain any key")
+	}
+
+	k.Profile = mikeyProfile(msg)
+	k.MasterKey = msg.Keys[0].Key
+	k.MasterSalt = msg.Keys[0].Salt
+
+	return k.Validate()
+}
+
+// MarshalMIKEY encodes the key into a MIKEY message.
+func (k Key) MarshalMIKEY() *mikey.Message {
+	var params []mikey.PolicyParam
+
+	if k.Profile.isAEAD() {
+		params = []mikey.PolicyParam{
+			{Type: mikey.PolicyParamEncrAlg, Value: []byte{mikey.EncrAlgAESGCM}},
+			{Type: mikey.PolicyParamEncrKeyLen, Value: []byte{byte(k.Profile.KeyLen())}},
+			{Type: mikey.PolicyParamAuthAlg, Value: []byte{mikey.AuthAlgNULL}},
+			{Type: mikey.PolicyParamSaltKeyLen, Value: []byte{byte(k.Profile.SaltLen())}},
+		}
+	} else {
+		params = []mikey.PolicyParam{
+			{Type: mikey.PolicyParamEncrAlg, Value: []byte{mikey.EncrAlgAESCM}},
+			{Type: mikey.PolicyParamEncrKeyLen, Value: []byte{byte(k.Profile.KeyLen())}},
+			{Type: mikey.PolicyParamAuthAlg, Value: []byte{mikey.AuthAlgHMACSHA1}},
+			{Type: mikey.PolicyParamAuthKeyLen, Value: []byte{20}},
+			{Type: mikey.PolicyParamSaltKeyLen, Value: []byte{byte(k.Profile.SaltLen())}},
+			{Type: mikey.PolicyParamAuthTagLen, Value: []byte{byte(k.Profile.rtpTagLen())}},
+		}
+	}
+
+	return &mikey.Message{
+		Policies: []mikey.Policy{{Params: params}},
+		Keys: []mikey.KeyData{{
+			Type: mikey.KeyDataTypeTEKSalt,
+			Key:  k.MasterKey,
+			Salt: k.MasterSalt,
+		}},
+	}
+}
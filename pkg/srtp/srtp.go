@@ -0,0 +1,159 @@
+// Package srtp contains a SRTP/SRTCP implementation.
+package srtp
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// ProtectionProfile is a SRTP protection profile (crypto-suite).
+type ProtectionProfile int
+
+// protection profiles.
+const (
+	ProtectionProfileAESCM128HMACSHA180 ProtectionProfile = iota
+	ProtectionProfileAESCM128HMACSHA132
+	ProtectionProfileAEADAES128GCM
+	ProtectionProfileAEADAES256GCM
+)
+
+var protectionProfileNames = map[ProtectionProfile]string{
+	ProtectionProfileAESCM128HMACSHA180: "AES_CM_128_HMAC_SHA1_80",
+	ProtectionProfileAESCM128HMACSHA132: "AES_CM_128_HMAC_SHA1_32",
+	ProtectionProfileAEADAES128GCM:      "AEAD_AES_128_GCM",
+	ProtectionProfileAEADAES256GCM:      "AEAD_AES_256_GCM",
+}
+
+// Unmarshal decodes a protection profile from its SDES crypto-suite name.
+func (p *ProtectionProfile) Unmarshal(v string) error {
+	for k, name := range protectionProfileNames {
+		if name == v {
+			*p = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported protection profile: '%s'", v)
+}
+
+// String implements fmt.Stringer.
+func (p ProtectionProfile) String() string {
+	if name, ok := protectionProfileNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// KeyLen returns the length of the master key.
+func (p ProtectionProfile) KeyLen() int {
+	if p == ProtectionProfileAEADAES256GCM {
+		return 32
+	}
+	return 16
+}
+
+// SaltLen returns the length of the master salt.
+func (p ProtectionProfile) SaltLen() int {
+	if p.isAEAD() {
+		return 12
+	}
+	return 14
+}
+
+func (p ProtectionProfile) isAEAD() bool {
+	return p == ProtectionProfileAEADAES128GCM || p == ProtectionProfileAEADAES256GCM
+}
+
+func (p ProtectionProfile) rtpTagLen() int {
+	switch p {
+	case ProtectionProfileAESCM128HMACSHA132:
+		return 4
+
+	case ProtectionProfileAEADAES128GCM, ProtectionProfileAEADAES256GCM:
+		return 16
+	}
+	return 10
+}
+
+func (p ProtectionProfile) rtcpTagLen() int {
+	// SRTCP always uses a 80-bit tag with HMAC-SHA1 (RFC4568, section 6.2).
+	if p.isAEAD() {
+		return 16
+	}
+	return 10
+}
+
+// Key is a SRTP master key.
+type Key struct {
+	// protection profile.
+	Profile ProtectionProfile
+
+	// master key.
+	MasterKey []byte
+
+	// master salt.
+	MasterSalt []byte
+}
+
+// GenerateKey generates a random master key.
+func GenerateKey(profile ProtectionProfile) (*Key, error) {
+	k := &Key{
+		Profile:    profile,
+		MasterKey:  make([]byte, profile.KeyLen()),
+		MasterSalt: make([]byte, profile.SaltLen()),
+	}
+
+	_, err := rand.Read(k.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = rand.Read(k.MasterSalt)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// DeriveReverse derives a master key for the opposite direction of a stream.
+// It is used when the counterpart doesn't provide a key of its own,
+// in order to avoid encrypting both directions with the same key.
+func (k *Key) DeriveReverse() (*Key, error) {
+	err := k.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := deriveKey(k.MasterKey, k.MasterSalt, labelReverseMasterKey, k.Profile.KeyLen())
+	if err != nil {
+		return nil, err
+	}
+
+	masterSalt, err := deriveKey(k.MasterKey, k.MasterSalt, labelReverseMasterSalt, k.Profile.SaltLen())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		Profile:    k.Profile,
+		MasterKey:  masterKey,
+		MasterSalt: masterSalt,
+	}, nil
+}
+
+// Validate checks whether the key is compatible with its protection profile.
+func (k *Key) Validate() error {
+	if _, ok := protectionProfileNames[k.Profile]; !ok {
+		return fmt.Errorf("unsupported protection profile")
+	}
+
+	if len(k.MasterKey) != k.Profile.KeyLen() {
+		return fmt.Errorf("invalid master key length: %d", len(k.MasterKey))
+	}
+
+	if len(k.MasterSalt) != k.Profile.SaltLen() {
+		return fmt.Errorf("invalid master salt length: %d", len(k.MasterSalt))
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package gortsplib
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+)
+
+func TestRTCPReceiverReportServerPlay(t *testing.T) {
+	var stream *ServerStream
+	var serverSession *ServerSession
+	sessionClosed := make(chan error, 1)
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onSessionClose: func(ctx *ServerHandlerOnSessionCloseCtx) {
+				sessionClosed <- ctx.Error
+			},
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				serverSession = ctx.Session
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		senderReportPeriod: 100 * time.Millisecond,
+		RTSPAddress:        "localhost:8554",
+		UDPRTPAddress:      "127.0.0.1:8000",
+		UDPRTCPAddress:     "127.0.0.1:8001",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc:   &description.Session{Medias: []*description.Media{testH264Media}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	desc := doDescribe(t, conn, false)
+
+	l1, err := net.ListenPacket("udp", "localhost:35466")
+	require.NoError(t, err)
+	defer l1.Close()
+
+	l2, err := net.ListenPacket("udp", "localhost:35467")
+	require.NoError(t, err)
+	defer l2.Close()
+
+	res, _ := doSetup(t, conn, mediaURL(t, desc.BaseURL, desc.Medias[0]).String(), &headers.Transport{
+		Protocol:    headers.TransportProtocolUDP,
+		Mode:        transportModePtr(headers.TransportModePlay),
+		Delivery:    deliveryPtr(headers.TransportDeliveryUnicast),
+		ClientPorts: &[2]int{35466, 35467},
+	}, "")
+
+	session := readSession(t, res)
+
+	doPlay(t, conn, "rtsp://localhost:8554/teststream", session)
+
+	err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 946,
+			Timestamp:      54352,
+			SSRC:           753621,
+		},
+		Payload: []byte{5, 1},
+	})
+	require.NoError(t, err)
+
+	buf := make([]byte, 2048)
+	_, _, err = l1.ReadFrom(buf)
+	require.NoError(t, err)
+
+	n, _, err := l2.ReadFrom(buf)
+	require.NoError(t, err)
+	packets, err := rtcp.Unmarshal(buf[:n])
+	require.NoError(t, err)
+	sr, ok := packets[0].(*rtcp.SenderReport)
+	require.True(t, ok)
+
+	_, err = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.ReceiverReport{
+		SSRC: 1234,
+		Reports: []rtcp.ReceptionReport{{
+			SSRC:             753621,
+			FractionLost:     128,
+			TotalLost:        3,
+			Jitter:           45,
+			LastSenderReport: uint32(sr.NTPTime >> 16),
+		}},
+	}), &net.UDPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 8001,
+	})
+	require.NoError(t, err)
+
+	var receivers map[uint32]ServerStreamStatsReceiver
+
+	for i := 0; i < 20 && receivers == nil; i++ {
+		time.Sleep(50 * time.Millisecond)
+		receivers = stream.Stats().Medias[stream.Desc.Medias[0]].Formats[stream.Desc.Medias[0].Formats[0]].Receivers
+	}
+
+	require.Len(t, receivers, 1)
+	require.Equal(t, 0.5, receivers[1234].RTPPacketsFractionLost)
+	require.Equal(t, uint64(3), receivers[1234].RTPPacketsLost)
+	require.Equal(t, float64(45), receivers[1234].RTPPacketsJitter)
+	require.NotZero(t, receivers[1234].RTT)
+
+	stats := serverSession.Stats().Medias[stream.Desc.Medias[0]].Formats[stream.Desc.Medias[0].Formats[0]]
+	require.Equal(t, 0.5, stats.RemoteRTPPacketsFractionLost)
+	require.Equal(t, uint64(3), stats.RemoteRTPPacketsLost)
+	require.Equal(t, float64(45), stats.RemoteRTPPacketsJitter)
+	require.Equal(t, receivers[1234].RTT, stats.RTT)
+
+	// BYE packets of unrelated sources are ignored
+	_, err = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.Goodbye{
+		Sources: []uint32{4567},
+	}), &net.UDPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 8001,
+	})
+	require.NoError(t, err)
+
+	select {
+	case err = <-sessionClosed:
+		t.Errorf("unexpected session close: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.Goodbye{
+		Sources: []uint32{1234},
+	}), &net.UDPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 8001,
+	})
+	require.NoError(t, err)
+
+	err = <-sessionClosed
+	require.Equal(t, liberrors.ErrServerSessionGoodbye{}, err)
+
+	require.Nil(t, stream.Stats().Medias[stream.Desc.Medias[0]].Formats[stream.Desc.Medias[0].Formats[0]].Receivers)
+}
+
+func TestRTCPReceiverReportClientRecord(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer l.Close()
+
+	serverDone := make(chan struct{})
+	defer func() { <-serverDone }()
+
+	go func() {
+		defer close(serverDone)
+
+		nconn, err2 := l.Accept()
+		require.NoError(t, err2)
+		defer nconn.Close()
+		conn := conn.NewConn(nconn)
+
+		req, err2 := conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Options, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"Public": base.HeaderValue{strings.Join([]string{
+					string(base.Announce),
+					string(base.Setup),
+					string(base.Record),
+				}, ", ")},
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Announce, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Setup, req.Method)
+
+		var inTH headers.Transport
+		err2 = inTH.Unmarshal(req.Header["Transport"])
+		require.NoError(t, err2)
+
+		l1, err2 := net.ListenPacket("udp", "localhost:34556")
+		require.NoError(t, err2)
+		defer l1.Close()
+
+		l2, err2 := net.ListenPacket("udp", "localhost:34557")
+		require.NoError(t, err2)
+		defer l2.Close()
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"Transport": headers.Transport{
+					Protocol:    headers.TransportProtocolUDP,
+					Delivery:    deliveryPtr(headers.TransportDeliveryUnicast),
+					ServerPorts: &[2]int{34556, 34557},
+					ClientPorts: inTH.ClientPorts,
+				}.Marshal(),
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Record, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+		})
+		require.NoError(t, err2)
+
+		buf := make([]byte, 2048)
+		_, _, err2 = l1.ReadFrom(buf)
+		require.NoError(t, err2)
+
+		clientRTCPAddr := &net.UDPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: inTH.ClientPorts[1],
+		}
+
+		_, err2 = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.ReceiverReport{
+			SSRC: 1234,
+			Reports: []rtcp.ReceptionReport{{
+				SSRC:         753621,
+				FractionLost: 64,
+				TotalLost:    2,
+				Jitter:       30,
+			}},
+		}), clientRTCPAddr)
+		require.NoError(t, err2)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err2 = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.Goodbye{
+			Sources: []uint32{1234},
+		}), clientRTCPAddr)
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Teardown, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+		})
+		require.NoError(t, err2)
+	}()
+
+	c := Client{
+		Transport: func() *Transport {
+			v := TransportUDP
+			return &v
+		}(),
+	}
+
+	medias := []*description.Media{testH264Media}
+
+	err = record(&c, "rtsp://localhost:8554/teststream", medias, nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.WritePacketRTP(medias[0], &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 946,
+			Timestamp:      54352,
+			SSRC:           753621,
+		},
+		Payload: []byte{5, 1},
+	})
+	require.NoError(t, err)
+
+	var stats StatsSessionFormat
+
+	for i := 0; i < 20 && stats.RemoteRTPPacketsLost == 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+		stats = c.Stats().Session.Medias[medias[0]].Formats[medias[0].Formats[0]]
+	}
+
+	require.Equal(t, 0.25, stats.RemoteRTPPacketsFractionLost)
+	require.Equal(t, uint64(2), stats.RemoteRTPPacketsLost)
+	require.Equal(t, float64(30), stats.RemoteRTPPacketsJitter)
+
+	err = c.Wait()
+	require.Equal(t, liberrors.ErrClientGoodbye{}, err)
+}
@@ -0,0 +1,287 @@
+package gortsplib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+)
+
+func TestRTSP20Play(t *testing.T) {
+	for _, transport := range []string{"udp", "tcp"} {
+		t.Run(transport, func(t *testing.T) {
+			var stream *ServerStream
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						require.Equal(t, base.Version20, ctx.Request.Version)
+						require.Len(t, ctx.Request.Header["Pipelined-Requests"], 1)
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						require.Len(t, ctx.Request.Header["Pipelined-Requests"], 1)
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+				},
+				UDPRTPAddress:  "127.0.0.1:8000",
+				UDPRTCPAddress: "127.0.0.1:8001",
+				RTSPAddress:    "127.0.0.1:8554",
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			stream = &ServerStream{
+				Server: s,
+				Desc:   &description.Session{Medias: []*description.Media{testH264Media}},
+			}
+			err = stream.Initialize()
+			require.NoError(t, err)
+			defer stream.Close()
+
+			c := Client{
+				ProtocolVersion: base.Version20,
+				Transport: func() *Transport {
+					if transport == "udp" {
+						v := TransportUDP
+						return &v
+					}
+					v := TransportTCP
+					return &v
+				}(),
+				OnRequest: func(req *base.Request) {
+					require.Equal(t, base.Version20, req.Version)
+				},
+				OnResponse: func(res *base.Response) {
+					require.Equal(t, base.Version20, res.Version)
+
+					if transport == "udp" {
+						if v, ok := res.Header["Transport"]; ok {
+							var th headers.Transport
+							err2 := th.Unmarshal(v)
+							require.NoError(t, err2)
+							require.Nil(t, th.ServerPorts)
+							require.Equal(t, []headers.TransportAddr{{Port: 8000}, {Port: 8001}}, th.SrcAddr)
+						}
+					}
+				},
+			}
+
+			err = c.Start("rtsp", "127.0.0.1:8554")
+			require.NoError(t, err)
+			defer c.Close()
+
+			desc, res, err := c.Describe(mustParseURL("rtsp://127.0.0.1:8554/teststream"))
+			require.NoError(t, err)
+			require.Equal(t, base.HeaderValue{"No-Seeking, Time-Progressing, Unlimited"},
+				res.Header["Media-Properties"])
+
+			err = c.SetupAll(desc.BaseURL, desc.Medias)
+			require.NoError(t, err)
+
+			seeking := headers.MediaPropertiesSeekingNoSeeking
+			modifications := headers.MediaPropertiesModificationsTimeProgressing
+			require.Equal(t, &headers.MediaProperties{
+				Seeking:       &seeking,
+				Modifications: &modifications,
+				Unlimited:     true,
+			}, c.MediaProperties())
+
+			recv := make(chan *rtp.Packet)
+
+			c.OnPacketRTP(desc.Medias[0], desc.Medias[0].Formats[0], func(pkt *rtp.Packet) {
+				recv <- pkt
+			})
+
+			// write a packet in order to fill RTP-Info
+			prev := testRTPPacket
+			prev.SequenceNumber--
+			prev.Payload = []byte{5, 1, 2, 3, 4} // IDR
+			err = stream.WritePacketRTP(stream.Desc.Medias[0], &prev)
+			require.NoError(t, err)
+
+			res, err = c.Play(nil)
+			require.NoError(t, err)
+
+			var ri headers.RTPInfo
+			err = ri.UnmarshalVersion(res.Header["RTP-Info"], base.Version20)
+			require.NoError(t, err)
+			require.Len(t, ri, 1)
+			require.NotNil(t, ri[0].SSRC)
+			require.Equal(t, testRTPPacket.SequenceNumber, *ri[0].SequenceNumber)
+
+			err = stream.WritePacketRTP(stream.Desc.Medias[0], &testRTPPacket)
+			require.NoError(t, err)
+
+			pkt := <-recv
+			require.Equal(t, testRTPPacket.Payload, pkt.Payload)
+		})
+	}
+}
+
+func TestRTSP20ClientFallback(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer l.Close()
+
+	serverDone := make(chan struct{})
+	defer func() { <-serverDone }()
+
+	go func() {
+		defer close(serverDone)
+
+		nconn, err2 := l.Accept()
+		require.NoError(t, err2)
+		defer nconn.Close()
+		conn := conn.NewConn(nconn)
+
+		req, err2 := conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Options, req.Method)
+		require.Equal(t, base.Version20, req.Version)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusRTSPVersionNotSupported,
+			Header: base.Header{
+				"CSeq": req.Header["CSeq"],
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Options, req.Method)
+		require.Equal(t, base.Version10, req.Version)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"CSeq":   req.Header["CSeq"],
+				"Public": base.HeaderValue{"DESCRIBE, SETUP, PLAY"},
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Describe, req.Method)
+		require.Equal(t, base.Version10, req.Version)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusNotFound,
+			Header: base.Header{
+				"CSeq": req.Header["CSeq"],
+			},
+		})
+		require.NoError(t, err2)
+	}()
+
+	c := Client{
+		ProtocolVersion: base.Version20,
+	}
+
+	err = c.Start("rtsp", "localhost:8554")
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, _, err = c.Describe(mustParseURL("rtsp://localhost:8554/teststream"))
+	require.EqualError(t, err, "bad status code: 404 (Not Found)")
+}
+
+func TestRTSP20ServerPipelinedRequests(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc:   &description.Session{Medias: []*description.Media{testH264Media}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	// send SETUP and PLAY without waiting for the session ID
+	err = conn.WriteRequest(&base.Request{
+		Method:  base.Setup,
+		URL:     mustParseURL("rtsp://localhost:8554/teststream/trackID=0"),
+		Version: base.Version20,
+		Header: base.Header{
+			"CSeq":               base.HeaderValue{"1"},
+			"Pipelined-Requests": base.HeaderValue{"7c9a"},
+			"Transport": headers.Transport{
+				Protocol:       headers.TransportProtocolTCP,
+				Delivery:       deliveryPtr(headers.TransportDeliveryUnicast),
+				Mode:           transportModePtr(headers.TransportModePlay),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+
+	err = conn.WriteRequest(&base.Request{
+		Method:  base.Play,
+		URL:     mustParseURL("rtsp://localhost:8554/teststream"),
+		Version: base.Version20,
+		Header: base.Header{
+			"CSeq":               base.HeaderValue{"2"},
+			"Pipelined-Requests": base.HeaderValue{"7c9a"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := conn.ReadResponse()
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.Version20, res.Version)
+	require.Equal(t, base.HeaderValue{"7c9a"}, res.Header["Pipelined-Requests"])
+	session := readSession(t, res)
+
+	res, err = conn.ReadResponse()
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.Version20, res.Version)
+	require.Equal(t, session, readSession(t, res))
+}
@@ -0,0 +1,303 @@
+package gortsplib
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+)
+
+var testH264MediaRTX = &description.Media{
+	Type: description.MediaTypeVideo,
+	Formats: []format.Format{
+		&format.H264{
+			PayloadTyp:        96,
+			PacketizationMode: 1,
+		},
+		&format.RTX{
+			PayloadTyp:            97,
+			ClockRat:              90000,
+			AssociatedPayloadType: 96,
+		},
+	},
+}
+
+func TestRTXServerPlay(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:    "localhost:8554",
+		UDPRTPAddress:  "127.0.0.1:8000",
+		UDPRTCPAddress: "127.0.0.1:8001",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc:   &description.Session{Medias: []*description.Media{testH264MediaRTX}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	desc := doDescribe(t, conn, false)
+	require.Equal(t, testH264MediaRTX.Formats, desc.Medias[0].Formats)
+
+	l1, err := net.ListenPacket("udp", "localhost:35466")
+	require.NoError(t, err)
+	defer l1.Close()
+
+	l2, err := net.ListenPacket("udp", "localhost:35467")
+	require.NoError(t, err)
+	defer l2.Close()
+
+	res, _ := doSetup(t, conn, mediaURL(t, desc.BaseURL, desc.Medias[0]).String(), &headers.Transport{
+		Protocol:    headers.TransportProtocolUDP,
+		Mode:        transportModePtr(headers.TransportModePlay),
+		Delivery:    deliveryPtr(headers.TransportDeliveryUnicast),
+		ClientPorts: &[2]int{35466, 35467},
+	}, "")
+
+	session := readSession(t, res)
+
+	doPlay(t, conn, "rtsp://localhost:8554/teststream", session)
+
+	for i := uint16(0); i < 3; i++ {
+		err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: 946 + i,
+				Timestamp:      54352,
+				SSRC:           753621,
+			},
+			Payload: []byte{0x05, byte(i)},
+		})
+		require.NoError(t, err)
+
+		buf := make([]byte, 2048)
+		_, _, err = l1.ReadFrom(buf)
+		require.NoError(t, err)
+	}
+
+	_, err = l2.WriteTo(mustMarshalPacketRTCP(&rtcp.TransportLayerNack{
+		SenderSSRC: 1234,
+		MediaSSRC:  753621,
+		Nacks:      rtcp.NackPairsFromSequenceNumbers([]uint16{947}),
+	}), &net.UDPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 8001,
+	})
+	require.NoError(t, err)
+
+	buf := make([]byte, 2048)
+	n, _, err := l1.ReadFrom(buf)
+	require.NoError(t, err)
+
+	var pkt rtp.Packet
+	err = pkt.Unmarshal(buf[:n])
+	require.NoError(t, err)
+	require.Equal(t, uint8(97), pkt.PayloadType)
+	require.NotEqual(t, uint32(753621), pkt.SSRC)
+	require.Equal(t, uint32(54352), pkt.Timestamp)
+	require.Equal(t, []byte{0x03, 0xb3, 0x05, 0x01}, pkt.Payload)
+}
+
+func TestRTXClientPlay(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer l.Close()
+
+	serverDone := make(chan struct{})
+	defer func() { <-serverDone }()
+
+	go func() {
+		defer close(serverDone)
+
+		nconn, err2 := l.Accept()
+		require.NoError(t, err2)
+		defer nconn.Close()
+		conn := conn.NewConn(nconn)
+
+		req, err2 := conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Options, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"Public": base.HeaderValue{strings.Join([]string{
+					string(base.Describe),
+					string(base.Setup),
+					string(base.Play),
+				}, ", ")},
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Describe, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"Content-Type": base.HeaderValue{"application/sdp"},
+				"Content-Base": base.HeaderValue{"rtsp://localhost:8554/teststream/"},
+			},
+			Body: mediasToSDP([]*description.Media{testH264MediaRTX}),
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Setup, req.Method)
+
+		var inTH headers.Transport
+		err2 = inTH.Unmarshal(req.Header["Transport"])
+		require.NoError(t, err2)
+
+		l1, err2 := net.ListenPacket("udp", "localhost:27556")
+		require.NoError(t, err2)
+		defer l1.Close()
+
+		l2, err2 := net.ListenPacket("udp", "localhost:27557")
+		require.NoError(t, err2)
+		defer l2.Close()
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+			Header: base.Header{
+				"Transport": headers.Transport{
+					Protocol:    headers.TransportProtocolUDP,
+					Delivery:    deliveryPtr(headers.TransportDeliveryUnicast),
+					ServerPorts: &[2]int{27556, 27557},
+					ClientPorts: inTH.ClientPorts,
+				}.Marshal(),
+			},
+		})
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Play, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+		})
+		require.NoError(t, err2)
+
+		// skip firewall opening
+		buf := make([]byte, 2048)
+		_, _, err2 = l2.ReadFrom(buf)
+		require.NoError(t, err2)
+
+		clientRTPAddr := &net.UDPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: inTH.ClientPorts[0],
+		}
+
+		for _, seqNum := range []uint16{946, 948} {
+			_, err2 = l1.WriteTo(mustMarshalPacketRTP(&rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: seqNum,
+					Timestamp:      54352,
+					SSRC:           753621,
+				},
+				Payload: []byte{0x05, byte(seqNum)},
+			}), clientRTPAddr)
+			require.NoError(t, err2)
+		}
+
+		buf = make([]byte, 2048)
+		n, _, err2 := l2.ReadFrom(buf)
+		require.NoError(t, err2)
+		packets, err2 := rtcp.Unmarshal(buf[:n])
+		require.NoError(t, err2)
+		nack, ok := packets[0].(*rtcp.TransportLayerNack)
+		require.True(t, ok)
+		require.Equal(t, uint32(753621), nack.MediaSSRC)
+		require.Equal(t, []rtcp.NackPair{{PacketID: 947}}, nack.Nacks)
+
+		_, err2 = l1.WriteTo(mustMarshalPacketRTP(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    97,
+				SequenceNumber: 123,
+				Timestamp:      54352,
+				SSRC:           4567,
+			},
+			Payload: []byte{0x03, 0xb3, 0x05, 0xb3},
+		}), clientRTPAddr)
+		require.NoError(t, err2)
+
+		req, err2 = conn.ReadRequest()
+		require.NoError(t, err2)
+		require.Equal(t, base.Teardown, req.Method)
+
+		err2 = conn.WriteResponse(&base.Response{
+			StatusCode: base.StatusOK,
+		})
+		require.NoError(t, err2)
+	}()
+
+	recv := make(chan *rtp.Packet, 3)
+
+	c := Client{
+		Transport: func() *Transport {
+			v := TransportUDP
+			return &v
+		}(),
+	}
+
+	err = readAll(&c, "rtsp://localhost:8554/teststream",
+		func(_ *description.Media, _ format.Format, pkt *rtp.Packet) {
+			recv <- pkt
+		})
+	require.NoError(t, err)
+	defer c.Close()
+
+	for _, seqNum := range []uint16{946, 947, 948} {
+		pkt := <-recv
+		require.Equal(t, uint8(96), pkt.PayloadType)
+		require.Equal(t, uint32(753621), pkt.SSRC)
+		require.Equal(t, seqNum, pkt.SequenceNumber)
+		require.Equal(t, []byte{0x05, byte(seqNum)}, pkt.Payload)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"strconv"
 	"sync"
 	"time"
@@ -58,7 +59,17 @@ type Server struct {
 	//
 	// the RTSP address of the server, to accept connections and send and receive
 	// packets with the TCP transport.
+	// RTSP-over-HTTP tunnels are accepted on this address too.
 	RTSPAddress string
+	// the address of a WebSocket listener, that accepts RTSP connections
+	// carried by binary WebSocket messages (RTSP-over-WebSocket).
+	// If TLSConfig is set, the listener accepts secure (wss) connections.
+	WebSocketAddress string
+	// function that decides whether to accept a WebSocket connection, given its handshake request.
+	// It can be used to check the Origin header sent by browsers.
+	// It defaults to a function that accepts requests without an Origin header
+	// (non-browser clients) and requests whose origin matches the Host header.
+	WebSocketCheckOrigin func(r *http.Request) bool
 	// a port to send and receive RTP packets with the UDP transport.
 	// If UDPRTPAddress and UDPRTCPAddress are filled, the server can support the UDP transport.
 	UDPRTPAddress string
@@ -94,6 +105,12 @@ type Server struct {
 	MaxPacketSize int
 	// disable automatic RTCP sender reports.
 	DisableRTCPSenderReports bool
+	// allow exchanging SRTP keys over connections that are not encrypted with TLS.
+	// Keys are sent in clear text by SDP and by the KeyMgmt header,
+	// therefore anyone that can read the RTSP connection can decrypt secure medias.
+	// This can be a security issue.
+	// It defaults to false.
+	InsecureSRTPKeyExchange bool
 	// authentication methods.
 	// It defaults to plain and digest+MD5.
 	AuthMethods []auth.VerifyMethod
@@ -131,10 +148,12 @@ type Server struct {
 	multicastNet    *net.IPNet
 	multicastNextIP net.IP
 	tcpListener     *serverTCPListener
+	wsListener      *serverWebSocketListener
 	udpRTPListener  *serverUDPListener
 	udpRTCPListener *serverUDPListener
 	sessions        map[string]*ServerSession
 	conns           map[*ServerConn]struct{}
+	httpTunnels     map[string]*ServerConn
 	closeError      error
 
 	// in
@@ -144,6 +163,8 @@ type Server struct {
 	chHandleRequest  chan sessionRequestReq
 	chCloseSession   chan *ServerSession
 	chGetMulticastIP chan chGetMulticastIPReq
+	chAddHTTPTunnel  chan *ServerConn
+	chHTTPTunnelPost chan httpTunnelPostReq
 }
 
 // Start starts the server.
@@ -308,12 +329,15 @@ func (s *Server) Start() error {
 
 	s.sessions = make(map[string]*ServerSession)
 	s.conns = make(map[*ServerConn]struct{})
+	s.httpTunnels = make(map[string]*ServerConn)
 	s.chNewConn = make(chan net.Conn)
 	s.chAcceptErr = make(chan error)
 	s.chCloseConn = make(chan *ServerConn)
 	s.chHandleRequest = make(chan sessionRequestReq)
 	s.chCloseSession = make(chan *ServerSession)
 	s.chGetMulticastIP = make(chan chGetMulticastIPReq)
+	s.chAddHTTPTunnel = make(chan *ServerConn)
+	s.chHTTPTunnelPost = make(chan httpTunnelPostReq)
 
 	s.tcpListener = &serverTCPListener{
 		s: s,
@@ -330,6 +354,24 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	if s.WebSocketAddress != "" {
+		s.wsListener = &serverWebSocketListener{
+			s: s,
+		}
+		err = s.wsListener.initialize()
+		if err != nil {
+			s.tcpListener.close()
+			if s.udpRTPListener != nil {
+				s.udpRTPListener.close()
+			}
+			if s.udpRTCPListener != nil {
+				s.udpRTCPListener.close()
+			}
+			s.ctxCancel()
+			return err
+		}
+	}
+
 	s.wg.Add(1)
 	go s.run()
 
@@ -364,6 +406,10 @@ func (s *Server) run() {
 		s.udpRTPListener.close()
 	}
 
+	if s.wsListener != nil {
+		s.wsListener.close()
+	}
+
 	s.tcpListener.close()
 }
 
@@ -386,6 +432,9 @@ func (s *Server) runInner() error {
 				continue
 			}
 			delete(s.conns, sc)
+			if s.httpTunnels[sc.httpTunnelCookie] == sc {
+				delete(s.httpTunnels, sc.httpTunnelCookie)
+			}
 			sc.Close()
 
 		case req := <-s.chHandleRequest:
@@ -462,6 +511,19 @@ func (s *Server) runInner() error {
 			s.multicastNextIP = ip
 			req.res <- ip
 
+		case sc := <-s.chAddHTTPTunnel:
+			s.httpTunnels[sc.httpTunnelCookie] = sc
+
+		case req := <-s.chHTTPTunnelPost:
+			sc, ok := s.httpTunnels[req.cookie]
+			if !ok {
+				req.res <- false
+				continue
+			}
+			delete(s.httpTunnels, req.cookie)
+
+			req.res <- sc.httpTunnelPost(req)
+
 		case <-s.ctx.Done():
 			return liberrors.ErrServerTerminated{}
 		}
@@ -479,6 +541,10 @@ func (s *Server) StartAndWait() error {
 	return s.Wait()
 }
 
+func (s *Server) srtpKeyExchangeAllowed() bool {
+	return s.TLSConfig != nil || s.InsecureSRTPKeyExchange
+}
+
 func (s *Server) getMulticastIP() (net.IP, error) {
 	res := make(chan net.IP)
 	select {
@@ -519,6 +585,23 @@ func (s *Server) closeSession(ss *ServerSession) {
 	}
 }
 
+func (s *Server) addHTTPTunnel(sc *ServerConn) {
+	select {
+	case s.chAddHTTPTunnel <- sc:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *Server) httpTunnelPost(req httpTunnelPostReq) bool {
+	req.res = make(chan bool)
+	select {
+	case s.chHTTPTunnelPost <- req:
+		return <-req.res
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
 func (s *Server) handleRequest(req sessionRequestReq) (*base.Response, *ServerSession, error) {
 	select {
 	case s.chHandleRequest <- req:
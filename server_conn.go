@@ -1,10 +1,13 @@
 package gortsplib
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
 	"errors"
+	"io"
 	"net"
+	"net/http"
 	gourl "net/url"
 	"strconv"
 	"strings"
@@ -26,6 +29,13 @@ func getSessionID(header base.Header) string {
 	return ""
 }
 
+func getPipelinedRequests(header base.Header) string {
+	if h, ok := header["Pipelined-Requests"]; ok && len(h) == 1 {
+		return h[0]
+	}
+	return ""
+}
+
 func checkMulticastEnabled(multicastIPRange string, query string) bool {
 	// VLC uses multicast if the SDP contains a multicast address.
 	// therefore, we introduce a special query (vlcmulticast) that allows
@@ -67,6 +77,9 @@ func prepareForDescribe(d *description.Session, multicast bool, backChannels boo
 				// we have to use trackID=number in order to support clients
 				// like the Grandstream GXV3500.
 				Control: "trackID=" + strconv.FormatInt(int64(i), 10),
+				Secure:  medi.Secure,
+				SRTPKey: medi.SRTPKey,
+				KeyMgmt: medi.KeyMgmt,
 				Formats: medi.Formats,
 			})
 		}
@@ -86,6 +99,19 @@ type readReq struct {
 	res chan error
 }
 
+type httpTunnelReadReq struct {
+	req *http.Request
+	br  *bufio.Reader
+	res chan error
+}
+
+type httpTunnelPostReq struct {
+	cookie string
+	nconn  net.Conn
+	br     *bufio.Reader
+	res    chan bool
+}
+
 // ServerConn is a server-side RTSP connection.
 type ServerConn struct {
 	s     *Server
@@ -101,8 +127,16 @@ type ServerConn struct {
 	reader     *serverConnReader
 	authNonce  string
 
+	pipelinedRequests string
+
+	httpTunnelCookie string
+	httpTunnelTimer  *time.Timer
+	httpTunnelRes    chan error
+	httpTunnelJoined bool
+
 	// in
-	chRemoveSession chan *ServerSession
+	chRemoveSession  chan *ServerSession
+	chHTTPTunnelPost chan httpTunnelPostReq
 
 	// out
 	done chan struct{}
@@ -111,7 +145,8 @@ type ServerConn struct {
 func (sc *ServerConn) initialize() {
 	ctx, ctxCancel := context.WithCancel(sc.s.ctx)
 
-	if sc.s.TLSConfig != nil {
+	// WebSocket connections are encrypted by their listener.
+	if _, ok := sc.nconn.(*serverWebSocketConn); !ok && sc.s.TLSConfig != nil {
 		sc.nconn = tls.Server(sc.nconn, sc.s.TLSConfig)
 	}
 
@@ -120,6 +155,8 @@ func (sc *ServerConn) initialize() {
 	sc.ctxCancel = ctxCancel
 	sc.remoteAddr = sc.nconn.RemoteAddr().(*net.TCPAddr)
 	sc.chRemoveSession = make(chan *ServerSession)
+	sc.chHTTPTunnelPost = make(chan httpTunnelPostReq)
+	sc.httpTunnelTimer = emptyTimer()
 	sc.done = make(chan struct{})
 
 	sc.s.wg.Add(1)
@@ -233,9 +270,14 @@ func (sc *ServerConn) run() {
 		})
 	}
 
-	sc.conn = conn.NewConn(sc.bc)
+	br := bufio.NewReaderSize(sc.bc, httpTunnelReadBufferSize)
+	sc.conn = conn.NewConn(struct {
+		io.Reader
+		io.Writer
+	}{br, sc.bc})
 	sc.reader = &serverConnReader{
 		sc: sc,
+		br: br,
 	}
 	sc.reader.initialize()
 
@@ -243,7 +285,17 @@ func (sc *ServerConn) run() {
 
 	sc.ctxCancel()
 
-	sc.nconn.Close()
+	// the POST half of a HTTP tunnel is owned by the GET half
+	// and is closed silently.
+	if sc.httpTunnelJoined {
+		err = nil
+	} else {
+		sc.nconn.Close()
+	}
+
+	if sc.httpTunnelRes != nil {
+		sc.httpTunnelRes <- liberrors.ErrServerTerminated{}
+	}
 
 	if sc.reader != nil {
 		sc.reader.wait()
@@ -273,9 +325,30 @@ func (sc *ServerConn) runInner() error {
 			sc.reader = nil
 			return err
 
+		case req := <-sc.reader.chHTTPTunnel:
+			err := sc.handleHTTPTunnel(req)
+			if err != nil {
+				return err
+			}
+
+		case post := <-sc.chHTTPTunnelPost:
+			sc.httpTunnelTimer.Stop()
+			sc.httpTunnelTimer = emptyTimer()
+
+			sc.nconn = newServerHTTPTunnelConn(sc.nconn, post.nconn, post.br)
+			sc.bc = bytecounter.New(sc.nconn, nil, nil)
+			sc.conn = conn.NewConn(sc.bc)
+
+			sc.httpTunnelRes <- nil
+			sc.httpTunnelRes = nil
+
+		case <-sc.httpTunnelTimer.C:
+			return liberrors.ErrServerHTTPTunnelTimeout{}
+
 		case ss := <-sc.chRemoveSession:
 			if sc.session == ss {
 				sc.session = nil
+				sc.pipelinedRequests = ""
 			}
 
 		case <-sc.ctx.Done():
@@ -284,6 +357,53 @@ func (sc *ServerConn) runInner() error {
 	}
 }
 
+func (sc *ServerConn) handleHTTPTunnel(req httpTunnelReadReq) error {
+	cookie := req.req.Header.Get(httpTunnelCookieName)
+
+	switch {
+	case cookie == "":
+		sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
+		writeHTTPTunnelResponse(sc.nconn, http.StatusBadRequest) //nolint:errcheck
+		req.res <- liberrors.ErrServerHTTPTunnelCookieMissing{}
+		return liberrors.ErrServerHTTPTunnelCookieMissing{}
+
+	case req.req.Method == http.MethodPost:
+		if !sc.s.httpTunnelPost(httpTunnelPostReq{
+			cookie: cookie,
+			nconn:  sc.nconn,
+			br:     req.br,
+		}) {
+			req.res <- liberrors.ErrServerHTTPTunnelNotFound{}
+			return liberrors.ErrServerHTTPTunnelNotFound{}
+		}
+
+		sc.httpTunnelJoined = true
+		req.res <- liberrors.ErrServerHTTPTunnelJoined{}
+		return liberrors.ErrServerHTTPTunnelJoined{}
+
+	case req.req.Method != http.MethodGet:
+		sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
+		writeHTTPTunnelResponse(sc.nconn, http.StatusMethodNotAllowed) //nolint:errcheck
+		req.res <- liberrors.ErrServerHTTPTunnelInvalidMethod{Method: req.req.Method}
+		return liberrors.ErrServerHTTPTunnelInvalidMethod{Method: req.req.Method}
+	}
+
+	sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
+	err := writeHTTPTunnelResponse(sc.nconn, http.StatusOK)
+	if err != nil {
+		req.res <- err
+		return err
+	}
+
+	// wait for the POST half, then unblock the reader.
+	sc.httpTunnelCookie = cookie
+	sc.httpTunnelRes = req.res
+	sc.httpTunnelTimer = time.NewTimer(sc.s.ReadTimeout)
+	sc.s.addHTTPTunnel(sc)
+
+	return nil
+}
+
 func (sc *ServerConn) handleRequestInner(req *base.Request) (*base.Response, error) {
 	if cseq, ok := req.Header["CSeq"]; !ok || len(cseq) != 1 {
 		return &base.Response{
@@ -299,6 +419,14 @@ func (sc *ServerConn) handleRequestInner(req *base.Request) (*base.Response, err
 
 	sxID := getSessionID(req.Header)
 
+	// RTSP 2.0 pipelined requests are sent before receiving the session ID,
+	// and are bound to the session created by the first request of the pipeline.
+	if sxID == "" && sc.session != nil && sc.pipelinedRequests != "" {
+		if v := getPipelinedRequests(req.Header); v == sc.pipelinedRequests {
+			sxID = sc.session.secretID
+		}
+	}
+
 	var path string
 	var query string
 
@@ -370,12 +498,23 @@ func (sc *ServerConn) handleRequestInner(req *base.Request) (*base.Response, err
 					return res, err
 				}
 
+				if _, ok2 := res.Header["Media-Properties"]; !ok2 && req.Version == base.Version20 {
+					res.Header["Media-Properties"] = stream.mediaProperties().Marshal()
+				}
+
 				desc := prepareForDescribe(
 					stream.Desc,
 					checkMulticastEnabled(sc.s.MulticastIPRange, query),
 					checkBackChannelsEnabled(req.Header),
 				)
 
+				// SRTP keys are part of the SDP
+				if hasSecureMedias(desc.Medias) && !sc.s.srtpKeyExchangeAllowed() {
+					return &base.Response{
+						StatusCode: base.StatusBadRequest,
+					}, liberrors.ErrServerSRTPWithoutTLS{}
+				}
+
 				byts, _ := desc.Marshal(false)
 				res.Body = byts
 			}
@@ -464,6 +603,16 @@ func (sc *ServerConn) handleRequestOuter(req *base.Request) error {
 		res.Header = make(base.Header)
 	}
 
+	// reply with the same protocol version of the request
+	res.Version = req.Version
+
+	if v := getPipelinedRequests(req.Header); v != "" {
+		if sc.session != nil && sc.pipelinedRequests == "" {
+			sc.pipelinedRequests = v
+		}
+		res.Header["Pipelined-Requests"] = base.HeaderValue{v}
+	}
+
 	// handle auth errors
 	var eerr1 liberrors.ErrServerAuth
 	if errors.As(err, &eerr1) {
@@ -541,6 +690,15 @@ func (sc *ServerConn) handleRequestInSession(
 	return res, err
 }
 
+func (sc *ServerConn) httpTunnelPost(req httpTunnelPostReq) bool {
+	select {
+	case sc.chHTTPTunnelPost <- req:
+		return true
+	case <-sc.ctx.Done():
+		return false
+	}
+}
+
 func (sc *ServerConn) removeSession(ss *ServerSession) {
 	select {
 	case sc.chRemoveSession <- ss:
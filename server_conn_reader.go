@@ -1,8 +1,10 @@
 package gortsplib
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/base"
@@ -24,13 +26,16 @@ func isSwitchReadFuncError(err error) bool {
 
 type serverConnReader struct {
 	sc *ServerConn
+	br *bufio.Reader
 
-	chRequest chan readReq
-	chError   chan error
+	chRequest    chan readReq
+	chHTTPTunnel chan httpTunnelReadReq
+	chError      chan error
 }
 
 func (cr *serverConnReader) initialize() {
 	cr.chRequest = make(chan readReq)
+	cr.chHTTPTunnel = make(chan httpTunnelReadReq)
 	cr.chError = make(chan error)
 
 	go cr.run()
@@ -44,11 +49,20 @@ func (cr *serverConnReader) wait() {
 
 		case req := <-cr.chRequest:
 			req.res <- fmt.Errorf("terminated")
+
+		case req := <-cr.chHTTPTunnel:
+			req.res <- fmt.Errorf("terminated")
 		}
 	}
 }
 
 func (cr *serverConnReader) run() {
+	err := cr.readHTTPTunnel()
+	if err != nil {
+		cr.chError <- err
+		return
+	}
+
 	readFunc := cr.readFuncStandard
 
 	for {
@@ -69,6 +83,28 @@ func (cr *serverConnReader) run() {
 	}
 }
 
+// readHTTPTunnel checks whether the connection is one of the two halves of a HTTP tunnel.
+func (cr *serverConnReader) readHTTPTunnel() error {
+	byts, err := cr.br.Peek(4)
+	if err != nil {
+		return err
+	}
+
+	if !isHTTPTunnelRequest(byts) {
+		return nil
+	}
+
+	req, err := http.ReadRequest(cr.br)
+	if err != nil {
+		return err
+	}
+
+	cres := make(chan error, 1)
+	cr.chHTTPTunnel <- httpTunnelReadReq{req: req, br: cr.br, res: cres}
+
+	return <-cres
+}
+
 func (cr *serverConnReader) readFuncStandard() error {
 	// reset deadline
 	cr.sc.nconn.SetReadDeadline(time.Time{})
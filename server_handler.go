@@ -1,6 +1,8 @@
 package gortsplib
 
 import (
+	"github.com/pion/rtcp"
+
 	"github.com/frostyfridge/gortsplib/v4/pkg/base"
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
 )
@@ -220,6 +222,21 @@ type ServerHandlerOnPacketsLost interface {
 	OnPacketsLost(*ServerHandlerOnPacketsLostCtx)
 }
 
+// ServerHandlerOnKeyFrameRequestCtx is the context of OnKeyFrameRequest.
+type ServerHandlerOnKeyFrameRequestCtx struct {
+	Session *ServerSession
+	Stream  *ServerStream
+	Media   *description.Media
+	Packet  rtcp.Packet
+}
+
+// ServerHandlerOnKeyFrameRequest can be implemented by a ServerHandler.
+type ServerHandlerOnKeyFrameRequest interface {
+	// called when a reader of a ServerStream requests a key frame
+	// with a RTCP Picture Loss Indication or Full Intra Request.
+	OnKeyFrameRequest(*ServerHandlerOnKeyFrameRequestCtx)
+}
+
 // ServerHandlerOnDecodeErrorCtx is the context of OnDecodeError.
 type ServerHandlerOnDecodeErrorCtx struct {
 	Session *ServerSession
@@ -18,12 +18,14 @@ import (
 	"github.com/frostyfridge/gortsplib/v4/pkg/base"
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpulpfec"
 	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
 	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpreceiver"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtptime"
 	"github.com/frostyfridge/gortsplib/v4/pkg/sdp"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 type readFunc func([]byte) bool
@@ -186,6 +188,7 @@ func generateRTPInfo(
 	setuppedStream *ServerStream,
 	setuppedPath string,
 	u *base.URL,
+	replayed map[*description.Media]*rtp.Packet,
 ) (headers.RTPInfo, bool) {
 	var ri headers.RTPInfo
 
@@ -193,6 +196,12 @@ func generateRTPInfo(
 		entry := setuppedStream.rtpInfoEntry(sm.media, now)
 		if entry == nil {
 			entry = &headers.RTPInfoEntry{}
+		} else if pkt, ok := replayed[sm.media]; ok {
+			// the first packet received by the reader is the first cached one
+			seqNum := pkt.SequenceNumber
+			ts := pkt.Timestamp
+			entry.SequenceNumber = &seqNum
+			entry.Timestamp = &ts
 		}
 		entry.URL = (&base.URL{
 			Scheme: u.Scheme,
@@ -267,11 +276,13 @@ type ServerSession struct {
 	timeDecoder           *rtptime.GlobalDecoder2
 	tcpFrame              *base.InterleavedFrame
 	tcpBuffer             []byte
+	goodbyeTracker        goodbyeTracker
 
 	// in
 	chHandleRequest    chan sessionRequestReq
 	chRemoveConn       chan *ServerConn
 	chAsyncStartWriter chan struct{}
+	chGoodbye          chan struct{}
 }
 
 func (ss *ServerSession) initialize() {
@@ -290,6 +301,7 @@ func (ss *ServerSession) initialize() {
 	ss.chHandleRequest = make(chan sessionRequestReq)
 	ss.chRemoveConn = make(chan *ServerConn)
 	ss.chAsyncStartWriter = make(chan struct{})
+	ss.chGoodbye = make(chan struct{}, 1)
 
 	ss.s.wg.Add(1)
 	go ss.run()
@@ -495,6 +507,7 @@ func (ss *ServerSession) Stats() *StatsSession {
 								}
 								return nil
 							}()
+							receiverStats := fo.remoteReceiverStats()
 
 							ret[fo.format] = StatsSessionFormat{ //nolint:dupl
 								RTPPacketsReceived: atomic.LoadUint64(fo.rtpPacketsReceived),
@@ -548,6 +561,30 @@ func (ss *ServerSession) Stats() *StatsSession {
 									}
 									return 0
 								}(),
+								RemoteRTPPacketsFractionLost: func() float64 {
+									if receiverStats != nil {
+										return receiverStats.FractionLost
+									}
+									return 0
+								}(),
+								RemoteRTPPacketsLost: func() uint64 {
+									if receiverStats != nil {
+										return uint64(receiverStats.TotalLost)
+									}
+									return 0
+								}(),
+								RemoteRTPPacketsJitter: func() float64 {
+									if receiverStats != nil {
+										return receiverStats.Jitter
+									}
+									return 0
+								}(),
+								RTT: func() time.Duration {
+									if receiverStats != nil {
+										return receiverStats.RTT
+									}
+									return 0
+								}(),
 							}
 						}
 
@@ -607,6 +644,23 @@ func (ss *ServerSession) createWriter() {
 	ss.writerMutex.Unlock()
 }
 
+// linkFECMedias links medias protected by FEC with the media that carries FEC packets.
+func (ss *ServerSession) linkFECMedias() {
+	medias := make([]*description.Media, 0, len(ss.setuppedMedias))
+	for medi, sm := range ss.setuppedMedias {
+		medias = append(medias, medi)
+		sm.fecDecoder = nil
+		sm.fecProtected = nil
+	}
+
+	for protected, fecMedia := range fecMediaPairs(ss.announcedDesc.FECGroups, medias) {
+		sm := ss.setuppedMedias[protected]
+		sm.fecDecoder = &rtpulpfec.Decoder{}
+		sm.fecDecoder.Init() //nolint:errcheck
+		ss.setuppedMedias[fecMedia].fecProtected = sm
+	}
+}
+
 func (ss *ServerSession) startWriter() {
 	ss.writer.start()
 }
@@ -773,6 +827,9 @@ func (ss *ServerSession) runInner() error {
 
 			ss.udpCheckStreamTimer = time.NewTimer(ss.s.checkStreamPeriod)
 
+		case <-ss.chGoodbye:
+			return liberrors.ErrServerSessionGoodbye{}
+
 		case <-chWriterError:
 			return ss.writer.stopError
 
@@ -878,6 +935,20 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 			}, liberrors.ErrServerSDPInvalid{Err: fmt.Errorf("back channels cannot be recorded")}
 		}
 
+		for _, medi := range desc.Medias {
+			if medi.Secure && medi.SRTPKey == nil {
+				return &base.Response{
+					StatusCode: base.StatusBadRequest,
+				}, liberrors.ErrServerSRTPKeyNotProvided{}
+			}
+		}
+
+		if hasSecureMedias(desc.Medias) && !ss.s.srtpKeyExchangeAllowed() {
+			return &base.Response{
+				StatusCode: base.StatusBadRequest,
+			}, liberrors.ErrServerSRTPWithoutTLS{}
+		}
+
 		res, err := ss.s.Handler.(ServerHandlerOnAnnounce).OnAnnounce(&ServerHandlerOnAnnounceCtx{
 			Session:     ss,
 			Conn:        sc,
@@ -965,6 +1036,11 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 
 		switch transport {
 		case TransportUDP:
+			// RTSP 2.0 clients provide ports through dest_addr
+			if inTH.ClientPorts == nil {
+				inTH.ClientPorts = transportAddrPorts(inTH.DestAddr)
+			}
+
 			if inTH.ClientPorts == nil {
 				return &base.Response{
 					StatusCode: base.StatusBadRequest,
@@ -1060,6 +1136,47 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 				}, liberrors.ErrServerMediaAlreadySetup{}
 			}
 
+			if inTH.Profile != mediaTransportProfile(medi) {
+				return &base.Response{
+					StatusCode: base.StatusUnsupportedTransport,
+				}, liberrors.ErrServerTransportHeaderInvalidProfile{}
+			}
+
+			var srtpInKey *srtp.Key
+			var srtpOutKey *srtp.Key
+			var srtpKeyMgmt bool
+
+			if medi.Secure {
+				if !ss.s.srtpKeyExchangeAllowed() {
+					return &base.Response{
+						StatusCode: base.StatusBadRequest,
+					}, liberrors.ErrServerSRTPWithoutTLS{}
+				}
+
+				var err2 error
+				srtpInKey, srtpOutKey, srtpKeyMgmt, err2 = serverSRTPKeys(req, medi,
+					ss.state == ServerSessionStatePreRecord)
+				if err2 != nil {
+					return &base.Response{
+						StatusCode: base.StatusBadRequest,
+					}, err2
+				}
+			}
+
+			srtpInCtx, err2 := newSRTPContext(srtpInKey)
+			if err2 != nil {
+				return &base.Response{
+					StatusCode: base.StatusBadRequest,
+				}, err2
+			}
+
+			srtpOutCtx, err2 := newSRTPContext(srtpOutKey)
+			if err2 != nil {
+				return &base.Response{
+					StatusCode: base.StatusBadRequest,
+				}, err2
+			}
+
 			ss.setuppedTransport = &transport
 
 			if ss.state == ServerSessionStateInitial {
@@ -1078,7 +1195,9 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 				ss.setuppedStream = stream
 			}
 
-			th := headers.Transport{}
+			th := headers.Transport{
+				Profile: inTH.Profile,
+			}
 
 			if ss.state == ServerSessionStatePrePlay {
 				if stream != ss.setuppedStream {
@@ -1095,10 +1214,21 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 				res.Header = make(base.Header)
 			}
 
+			if _, ok := res.Header["Media-Properties"]; !ok &&
+				req.Version == base.Version20 && ss.state == ServerSessionStatePrePlay {
+				res.Header["Media-Properties"] = stream.mediaProperties().Marshal()
+			}
+
+			if srtpKeyMgmt {
+				res.Header["KeyMgmt"] = marshalKeyMgmt(req.URL, srtpOutKey)
+			}
+
 			sm := &serverSessionMedia{
 				ss:           ss,
 				media:        medi,
 				onPacketRTCP: func(_ rtcp.Packet) {},
+				srtpInCtx:    srtpInCtx,
+				srtpOutCtx:   srtpOutCtx,
 			}
 			sm.initialize()
 
@@ -1122,8 +1252,20 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 				th.Protocol = headers.TransportProtocolUDP
 				de := headers.TransportDeliveryUnicast
 				th.Delivery = &de
-				th.ClientPorts = inTH.ClientPorts
-				th.ServerPorts = &[2]int{sc.s.udpRTPListener.port(), sc.s.udpRTCPListener.port()}
+
+				if req.Version == base.Version20 {
+					th.DestAddr = []headers.TransportAddr{
+						{Port: inTH.ClientPorts[0]},
+						{Port: inTH.ClientPorts[1]},
+					}
+					th.SrcAddr = []headers.TransportAddr{
+						{Port: sc.s.udpRTPListener.port()},
+						{Port: sc.s.udpRTCPListener.port()},
+					}
+				} else {
+					th.ClientPorts = inTH.ClientPorts
+					th.ServerPorts = &[2]int{sc.s.udpRTPListener.port(), sc.s.udpRTCPListener.port()}
+				}
 
 			case TransportUDPMulticast:
 				th.Protocol = headers.TransportProtocolUDP
@@ -1224,20 +1366,21 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 					// after the response has been sent
 				}
 
-				ss.setuppedStream.readerSetActive(ss)
+				replayed := ss.setuppedStream.readerSetActive(ss)
 
 				rtpInfo, ok := generateRTPInfo(
 					ss.s.timeNow(),
 					ss.setuppedMediasOrdered,
 					ss.setuppedStream,
 					ss.setuppedPath,
-					req.URL)
+					req.URL,
+					replayed)
 
 				if ok {
 					if res.Header == nil {
 						res.Header = make(base.Header)
 					}
-					res.Header["RTP-Info"] = rtpInfo.Marshal()
+					res.Header["RTP-Info"] = rtpInfo.MarshalVersion(req.Version)
 				}
 			}
 		} else {
@@ -1290,6 +1433,8 @@ func (ss *ServerSession) handleRequestInner(sc *ServerConn, req *base.Request) (
 			ss.timeDecoder = &rtptime.GlobalDecoder2{}
 			ss.timeDecoder.Initialize()
 
+			ss.linkFECMedias()
+
 			for _, sm := range ss.setuppedMedias {
 				sm.start()
 			}
@@ -1489,6 +1634,12 @@ func (ss *ServerSession) writePacketRTP(medi *description.Media, payloadType uin
 	sm := ss.setuppedMedias[medi]
 	sf := sm.formats[payloadType]
 
+	return ss.writeInQueue(func() error {
+		return sf.writePacketRTPInQueue(byts)
+	})
+}
+
+func (ss *ServerSession) writeInQueue(cb func() error) error {
 	ss.writerMutex.RLock()
 	defer ss.writerMutex.RUnlock()
 
@@ -1496,9 +1647,7 @@ func (ss *ServerSession) writePacketRTP(medi *description.Media, payloadType uin
 		return nil
 	}
 
-	ok := ss.writer.push(func() error {
-		return sf.writePacketRTPInQueue(byts)
-	})
+	ok := ss.writer.push(cb)
 	if !ok {
 		return liberrors.ErrServerWriteQueueFull{}
 	}
@@ -1515,6 +1664,13 @@ func (ss *ServerSession) WritePacketRTP(medi *description.Media, pkt *rtp.Packet
 	}
 	byts = byts[:n]
 
+	if sm := ss.setuppedMedias[medi]; sm.srtpOutCtx != nil {
+		byts, err = sm.srtpOutCtx.EncryptRTP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	return ss.writePacketRTP(medi, pkt.PayloadType, byts)
 }
 
@@ -1545,9 +1701,59 @@ func (ss *ServerSession) WritePacketRTCP(medi *description.Media, pkt rtcp.Packe
 		return err
 	}
 
+	if sm := ss.setuppedMedias[medi]; sm.srtpOutCtx != nil {
+		byts, err = sm.srtpOutCtx.EncryptRTCP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	return ss.writePacketRTCP(medi, byts)
 }
 
+// RequestKeyFrame asks the client to send a key frame of a media,
+// by sending a RTCP Picture Loss Indication.
+// This can be called only when the session is in state RECORD.
+func (ss *ServerSession) RequestKeyFrame(medi *description.Media) error {
+	return ss.requestKeyFrame(medi, false)
+}
+
+// RequestKeyFrameFIR asks the client to send a key frame of a media,
+// by sending a RTCP Full Intra Request.
+// This can be called only when the session is in state RECORD.
+func (ss *ServerSession) RequestKeyFrameFIR(medi *description.Media) error {
+	return ss.requestKeyFrame(medi, true)
+}
+
+func (ss *ServerSession) requestKeyFrame(medi *description.Media, fir bool) error {
+	sm, ok := ss.setuppedMedias[medi]
+	if !ok {
+		return liberrors.ErrServerMediaNotSetup{}
+	}
+
+	sent := false
+
+	// formats that have not received any packet yet are skipped
+	for _, sf := range sm.formats {
+		if sf.rtcpReceiver != nil {
+			err := sf.writeKeyFrameRequest(fir)
+			if err != nil {
+				if _, ok := err.(liberrors.ErrServerRemoteSSRCUnknown); ok {
+					continue
+				}
+				return err
+			}
+			sent = true
+		}
+	}
+
+	if !sent {
+		return liberrors.ErrServerRemoteSSRCUnknown{}
+	}
+
+	return nil
+}
+
 // PacketPTS returns the PTS of an incoming RTP packet.
 // It is computed by decoding the packet timestamp and sychronizing it with other tracks.
 //
@@ -1606,3 +1812,21 @@ func (ss *ServerSession) asyncStartWriter() {
 	case <-ss.ctx.Done():
 	}
 }
+
+// processGoodbye is called when the client sends a RTCP BYE packet.
+// The session is closed when all the SSRCs of the client have left.
+func (ss *ServerSession) processGoodbye(bye *rtcp.Goodbye) {
+	peerSSRCs := make(map[uint32]struct{})
+	for _, sm := range ss.setuppedMedias {
+		sm.addRemoteSSRCs(peerSSRCs)
+	}
+
+	if !ss.goodbyeTracker.process(bye, peerSSRCs) {
+		return
+	}
+
+	select {
+	case ss.chGoodbye <- struct{}{}:
+	default:
+	}
+}
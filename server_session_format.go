@@ -2,6 +2,7 @@ package gortsplib
 
 import (
 	"log"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -11,8 +12,10 @@ import (
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
 	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpreceiver"
+	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtplossdetector"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtpreorderer"
+	"github.com/frostyfridge/gortsplib/v4/pkg/rtx"
 )
 
 type serverSessionFormat struct {
@@ -20,6 +23,8 @@ type serverSessionFormat struct {
 	format      format.Format
 	onPacketRTP OnPacketRTPFunc
 
+	rtxFormat             *format.RTX             // retransmission format of this format
+	rtxAssociated         *serverSessionFormat    // format retransmitted by this format
 	udpReorderer          *rtpreorderer.Reorderer // publish or back channel
 	tcpLossDetector       *rtplossdetector.LossDetector
 	rtcpReceiver          *rtcpreceiver.RTCPReceiver
@@ -27,12 +32,38 @@ type serverSessionFormat struct {
 	rtpPacketsReceived    *uint64
 	rtpPacketsSent        *uint64
 	rtpPacketsLost        *uint64
+	firSeqNum             *uint32
+	rtxSeqNum             *uint16 // play
+
+	receiverStatsMutex sync.RWMutex
+	receiverStats      *rtcpsender.ReceiverStats // play
 }
 
 func (sf *serverSessionFormat) initialize() {
 	sf.rtpPacketsReceived = new(uint64)
 	sf.rtpPacketsSent = new(uint64)
 	sf.rtpPacketsLost = new(uint64)
+	sf.firSeqNum = new(uint32)
+
+	// each reader receives retransmissions in a separate sequence
+	v, err := randInRange(0xFFFF)
+	if err != nil {
+		panic(err)
+	}
+	v2 := uint16(v)
+	sf.rtxSeqNum = &v2
+}
+
+func (sf *serverSessionFormat) setRemoteReceiverStats(stats *rtcpsender.ReceiverStats) {
+	sf.receiverStatsMutex.Lock()
+	defer sf.receiverStatsMutex.Unlock()
+	sf.receiverStats = stats
+}
+
+func (sf *serverSessionFormat) remoteReceiverStats() *rtcpsender.ReceiverStats {
+	sf.receiverStatsMutex.RLock()
+	defer sf.receiverStatsMutex.RUnlock()
+	return sf.receiverStats
 }
 
 func (sf *serverSessionFormat) start() {
@@ -47,6 +78,9 @@ func (sf *serverSessionFormat) start() {
 	if sf.sm.ss.state == ServerSessionStateRecord || sf.sm.media.IsBackChannel {
 		if *sf.sm.ss.setuppedTransport == TransportUDP || *sf.sm.ss.setuppedTransport == TransportUDPMulticast {
 			sf.udpReorderer = &rtpreorderer.Reorderer{}
+			if sf.rtxFormat != nil && *sf.sm.ss.setuppedTransport == TransportUDP {
+				sf.udpReorderer.OnMissing = sf.writeNACK
+			}
 			sf.udpReorderer.Initialize()
 		} else {
 			sf.tcpLossDetector = &rtplossdetector.LossDetector{}
@@ -77,6 +111,15 @@ func (sf *serverSessionFormat) stop() {
 }
 
 func (sf *serverSessionFormat) readPacketRTPUDP(pkt *rtp.Packet, now time.Time) {
+	if sf.rtxAssociated != nil {
+		sf.readRetransmissionUDP(pkt, now)
+		return
+	}
+
+	if sf.sm.fecDecoder != nil {
+		sf.sm.fecDecoder.AddMedia(pkt)
+	}
+
 	packets, lost := sf.udpReorderer.Process(pkt)
 	if lost != 0 {
 		sf.onPacketRTPLost(uint64(lost))
@@ -88,7 +131,64 @@ func (sf *serverSessionFormat) readPacketRTPUDP(pkt *rtp.Packet, now time.Time)
 	}
 }
 
+func (sf *serverSessionFormat) readRetransmissionUDP(pkt *rtp.Packet, now time.Time) {
+	stats := sf.rtxAssociated.rtcpReceiver.Stats()
+	if stats == nil {
+		return
+	}
+
+	orig, err := rtx.Decode(pkt, sf.rtxAssociated.format.PayloadType(), stats.RemoteSSRC)
+	if err != nil {
+		sf.sm.onPacketRTPDecodeError(err)
+		return
+	}
+
+	sf.rtxAssociated.readPacketRTPUDP(orig, now)
+}
+
+func (sf *serverSessionFormat) writeNACK(seqNums []uint16) {
+	stats := sf.rtcpReceiver.Stats()
+	if stats == nil {
+		return
+	}
+
+	sf.sm.ss.WritePacketRTCP(sf.sm.media, &rtcp.TransportLayerNack{ //nolint:errcheck
+		SenderSSRC: *sf.rtcpReceiver.LocalSSRC,
+		MediaSSRC:  stats.RemoteSSRC,
+		Nacks:      rtcp.NackPairsFromSequenceNumbers(seqNums),
+	})
+}
+
+func (sf *serverSessionFormat) writeKeyFrameRequest(fir bool) error {
+	stats := sf.rtcpReceiver.Stats()
+	if stats == nil {
+		return liberrors.ErrServerRemoteSSRCUnknown{}
+	}
+
+	if fir {
+		// RFC5104, the sequence number is increased by one for each new request
+		seqNum := uint8(atomic.AddUint32(sf.firSeqNum, 1))
+
+		return sf.sm.ss.WritePacketRTCP(sf.sm.media, &rtcp.FullIntraRequest{
+			SenderSSRC: *sf.rtcpReceiver.LocalSSRC,
+			FIR: []rtcp.FIREntry{{
+				SSRC:           stats.RemoteSSRC,
+				SequenceNumber: seqNum,
+			}},
+		})
+	}
+
+	return sf.sm.ss.WritePacketRTCP(sf.sm.media, &rtcp.PictureLossIndication{
+		SenderSSRC: *sf.rtcpReceiver.LocalSSRC,
+		MediaSSRC:  stats.RemoteSSRC,
+	})
+}
+
 func (sf *serverSessionFormat) readPacketRTPTCP(pkt *rtp.Packet) {
+	if sf.sm.fecDecoder != nil {
+		sf.sm.fecDecoder.AddMedia(pkt)
+	}
+
 	lost := sf.tcpLossDetector.Process(pkt)
 	if lost != 0 {
 		sf.onPacketRTPLost(uint64(lost))
@@ -110,6 +210,10 @@ func (sf *serverSessionFormat) handlePacketRTP(pkt *rtp.Packet, now time.Time) {
 	atomic.AddUint64(sf.rtpPacketsReceived, 1)
 
 	sf.onPacketRTP(pkt)
+
+	if sf.sm.fecProtected != nil {
+		sf.sm.fecProtected.readPacketFEC(pkt, now)
+	}
 }
 
 func (sf *serverSessionFormat) onPacketRTPLost(lost uint64) {
@@ -10,13 +10,20 @@ import (
 	"github.com/pion/rtp"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format/rtpulpfec"
 	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 type serverSessionMedia struct {
 	ss           *ServerSession
 	media        *description.Media
 	onPacketRTCP OnPacketRTCPFunc
+	srtpInCtx    *srtp.Context
+	srtpOutCtx   *srtp.Context
+	fecDecoder   *rtpulpfec.Decoder  // record, when the media is protected by FEC
+	fecProtected *serverSessionMedia // record, when the media carries FEC packets
 
 	tcpChannel             int
 	udpRTPReadPort         int
@@ -52,6 +59,15 @@ func (sm *serverSessionMedia) initialize() {
 		f.initialize()
 		sm.formats[forma.PayloadType()] = f
 	}
+
+	for _, forma := range sm.media.Formats {
+		if rtxFormat, ok := forma.(*format.RTX); ok {
+			if associated, ok2 := sm.formats[rtxFormat.AssociatedPayloadType]; ok2 {
+				associated.rtxFormat = rtxFormat
+				sm.formats[rtxFormat.PayloadTyp].rtxAssociated = associated
+			}
+		}
+	}
 }
 
 func (sm *serverSessionMedia) start() {
@@ -76,9 +92,15 @@ func (sm *serverSessionMedia) start() {
 			} else {
 				// open the firewall by sending empty packets to the counterpart.
 				byts, _ := (&rtp.Packet{Header: rtp.Header{Version: 2}}).Marshal()
+				if sm.srtpOutCtx != nil {
+					byts, _ = sm.srtpOutCtx.EncryptRTP(byts)
+				}
 				sm.ss.s.udpRTPListener.write(byts, sm.udpRTPWriteAddr) //nolint:errcheck
 
 				byts, _ = (&rtcp.ReceiverReport{}).Marshal()
+				if sm.srtpOutCtx != nil {
+					byts, _ = sm.srtpOutCtx.EncryptRTCP(byts)
+				}
 				sm.ss.s.udpRTCPListener.write(byts, sm.udpRTCPWriteAddr) //nolint:errcheck
 
 				sm.ss.s.udpRTPListener.addClient(sm.ss.author.ip(), sm.udpRTPReadPort, sm.readPacketRTPUDPRecord)
@@ -114,6 +136,21 @@ func (sm *serverSessionMedia) stop() {
 	}
 }
 
+// addRemoteSSRCs adds the SSRCs used by the client to send or receive packets.
+func (sm *serverSessionMedia) addRemoteSSRCs(ssrcs map[uint32]struct{}) {
+	for _, sf := range sm.formats {
+		if sf.rtcpReceiver != nil {
+			if stats := sf.rtcpReceiver.Stats(); stats != nil {
+				ssrcs[stats.RemoteSSRC] = struct{}{}
+			}
+		}
+
+		if stats := sf.remoteReceiverStats(); stats != nil {
+			ssrcs[stats.SSRC] = struct{}{}
+		}
+	}
+}
+
 func (sm *serverSessionMedia) findFormatBySSRC(ssrc uint32) *serverSessionFormat {
 	for _, format := range sm.formats {
 		stats := format.rtcpReceiver.Stats()
@@ -124,6 +161,28 @@ func (sm *serverSessionMedia) findFormatBySSRC(ssrc uint32) *serverSessionFormat
 	return nil
 }
 
+func (sm *serverSessionMedia) readPacketFEC(pkt *rtp.Packet, now time.Time) {
+	recovered, err := sm.fecDecoder.Decode(pkt)
+	if err != nil {
+		sm.onPacketRTPDecodeError(err)
+		return
+	}
+
+	for _, pkt := range recovered {
+		forma, ok := sm.formats[pkt.PayloadType]
+		if !ok {
+			sm.onPacketRTPDecodeError(liberrors.ErrServerRTPPacketUnknownPayloadType{PayloadType: pkt.PayloadType})
+			continue
+		}
+
+		if forma.udpReorderer != nil {
+			forma.readPacketRTPUDP(pkt, now)
+		} else {
+			forma.readPacketRTPTCP(pkt)
+		}
+	}
+}
+
 func (sm *serverSessionMedia) writePacketRTCPInQueueUDP(payload []byte) error {
 	err := sm.ss.s.udpRTCPListener.write(payload, sm.udpRTCPWriteAddr)
 	if err != nil {
@@ -157,6 +216,11 @@ func (sm *serverSessionMedia) readPacketRTPUDPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -185,6 +249,11 @@ func (sm *serverSessionMedia) readPacketRTCPUDPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		sm.onPacketRTCPDecodeError(err)
@@ -197,6 +266,20 @@ func (sm *serverSessionMedia) readPacketRTCPUDPPlay(payload []byte) bool {
 	atomic.AddUint64(sm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
+		switch pkt := pkt.(type) {
+		case *rtcp.TransportLayerNack:
+			sm.ss.setuppedStream.writeRetransmissions(sm.ss, sm.media, pkt)
+
+		case *rtcp.ReceiverReport:
+			sm.ss.setuppedStream.processReceiverReport(sm.ss, sm.media, pkt, now)
+
+		case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+			sm.onKeyFrameRequest(pkt)
+
+		case *rtcp.Goodbye:
+			sm.ss.processGoodbye(pkt)
+		}
+
 		sm.onPacketRTCP(pkt)
 	}
 
@@ -211,6 +294,11 @@ func (sm *serverSessionMedia) readPacketRTPUDPRecord(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -240,6 +328,11 @@ func (sm *serverSessionMedia) readPacketRTCPUDPRecord(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		sm.onPacketRTCPDecodeError(err)
@@ -252,11 +345,15 @@ func (sm *serverSessionMedia) readPacketRTCPUDPRecord(payload []byte) bool {
 	atomic.AddUint64(sm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
-		if sr, ok := pkt.(*rtcp.SenderReport); ok {
-			format := sm.findFormatBySSRC(sr.SSRC)
+		switch pkt := pkt.(type) {
+		case *rtcp.SenderReport:
+			format := sm.findFormatBySSRC(pkt.SSRC)
 			if format != nil {
-				format.rtcpReceiver.ProcessSenderReport(sr, now)
+				format.rtcpReceiver.ProcessSenderReport(pkt, now)
 			}
+
+		case *rtcp.Goodbye:
+			sm.ss.processGoodbye(pkt)
 		}
 
 		sm.onPacketRTCP(pkt)
@@ -272,6 +369,11 @@ func (sm *serverSessionMedia) readPacketRTPTCPPlay(payload []byte) bool {
 
 	atomic.AddUint64(sm.bytesReceived, uint64(len(payload)))
 
+	payload, ok := sm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -298,15 +400,33 @@ func (sm *serverSessionMedia) readPacketRTCPTCPPlay(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		sm.onPacketRTCPDecodeError(err)
 		return false
 	}
 
+	now := sm.ss.s.timeNow()
+
 	atomic.AddUint64(sm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
+		switch pkt := pkt.(type) {
+		case *rtcp.ReceiverReport:
+			sm.ss.setuppedStream.processReceiverReport(sm.ss, sm.media, pkt, now)
+
+		case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+			sm.onKeyFrameRequest(pkt)
+
+		case *rtcp.Goodbye:
+			sm.ss.processGoodbye(pkt)
+		}
+
 		sm.onPacketRTCP(pkt)
 	}
 
@@ -316,6 +436,11 @@ func (sm *serverSessionMedia) readPacketRTCPTCPPlay(payload []byte) bool {
 func (sm *serverSessionMedia) readPacketRTPTCPRecord(payload []byte) bool {
 	atomic.AddUint64(sm.bytesReceived, uint64(len(payload)))
 
+	payload, ok := sm.decryptPacketRTP(payload)
+	if !ok {
+		return false
+	}
+
 	pkt := &rtp.Packet{}
 	err := pkt.Unmarshal(payload)
 	if err != nil {
@@ -342,6 +467,11 @@ func (sm *serverSessionMedia) readPacketRTCPTCPRecord(payload []byte) bool {
 		return false
 	}
 
+	payload, ok := sm.decryptPacketRTCP(payload)
+	if !ok {
+		return false
+	}
+
 	packets, err := rtcp.Unmarshal(payload)
 	if err != nil {
 		sm.onPacketRTCPDecodeError(err)
@@ -353,11 +483,15 @@ func (sm *serverSessionMedia) readPacketRTCPTCPRecord(payload []byte) bool {
 	atomic.AddUint64(sm.rtcpPacketsReceived, uint64(len(packets)))
 
 	for _, pkt := range packets {
-		if sr, ok := pkt.(*rtcp.SenderReport); ok {
-			format := sm.findFormatBySSRC(sr.SSRC)
+		switch pkt := pkt.(type) {
+		case *rtcp.SenderReport:
+			format := sm.findFormatBySSRC(pkt.SSRC)
 			if format != nil {
-				format.rtcpReceiver.ProcessSenderReport(sr, now)
+				format.rtcpReceiver.ProcessSenderReport(pkt, now)
 			}
+
+		case *rtcp.Goodbye:
+			sm.ss.processGoodbye(pkt)
 		}
 
 		sm.onPacketRTCP(pkt)
@@ -366,6 +500,45 @@ func (sm *serverSessionMedia) readPacketRTCPTCPRecord(payload []byte) bool {
 	return true
 }
 
+func (sm *serverSessionMedia) onKeyFrameRequest(pkt rtcp.Packet) {
+	if h, ok := sm.ss.s.Handler.(ServerHandlerOnKeyFrameRequest); ok {
+		h.OnKeyFrameRequest(&ServerHandlerOnKeyFrameRequestCtx{
+			Session: sm.ss,
+			Stream:  sm.ss.setuppedStream,
+			Media:   sm.media,
+			Packet:  pkt,
+		})
+	}
+}
+
+func (sm *serverSessionMedia) decryptPacketRTP(payload []byte) ([]byte, bool) {
+	if sm.srtpInCtx == nil {
+		return payload, true
+	}
+
+	payload, err := sm.srtpInCtx.DecryptRTP(payload)
+	if err != nil {
+		sm.onPacketRTPDecodeError(err)
+		return nil, false
+	}
+
+	return payload, true
+}
+
+func (sm *serverSessionMedia) decryptPacketRTCP(payload []byte) ([]byte, bool) {
+	if sm.srtpInCtx == nil {
+		return payload, true
+	}
+
+	payload, err := sm.srtpInCtx.DecryptRTCP(payload)
+	if err != nil {
+		sm.onPacketRTCPDecodeError(err)
+		return nil, false
+	}
+
+	return payload, true
+}
+
 func (sm *serverSessionMedia) onPacketRTPDecodeError(err error) {
 	atomic.AddUint64(sm.rtpPacketsInError, 1)
 
@@ -49,6 +49,18 @@ type ServerStream struct {
 	Server *Server
 	Desc   *description.Session
 
+	// cache RTP packets since the last key frame of video formats,
+	// and send them to readers right after PLAY, allowing them to start decoding immediately.
+	// It supports H264, H265, AV1, VP8 and VP9.
+	// It is not available with secure medias.
+	// WriteQueueSize of the server must be big enough to contain a whole GOP.
+	GOPCache bool
+
+	// properties of the stream, advertised to RTSP 2.0 clients
+	// through the Media-Properties header of DESCRIBE and SETUP responses (optional).
+	// It defaults to the properties of a live stream.
+	MediaProperties *headers.MediaProperties
+
 	mutex                sync.RWMutex
 	readers              map[*ServerSession]struct{}
 	multicastReaderCount int
@@ -57,12 +69,32 @@ type ServerStream struct {
 	closed               bool
 }
 
+func (st *ServerStream) mediaProperties() headers.MediaProperties {
+	if st.MediaProperties != nil {
+		return *st.MediaProperties
+	}
+
+	seeking := headers.MediaPropertiesSeekingNoSeeking
+	modifications := headers.MediaPropertiesModificationsTimeProgressing
+
+	return headers.MediaProperties{
+		Seeking:       &seeking,
+		Modifications: &modifications,
+		Unlimited:     true,
+	}
+}
+
 // Initialize initializes a ServerStream.
 func (st *ServerStream) Initialize() error {
 	if st.Server == nil || st.Server.sessions == nil {
 		return fmt.Errorf("server not present or not initialized")
 	}
 
+	err := generateSRTPKeys(st.Desc.Medias)
+	if err != nil {
+		return err
+	}
+
 	st.readers = make(map[*ServerSession]struct{})
 	st.activeUnicastReaders = make(map[*ServerSession]struct{})
 
@@ -73,6 +105,14 @@ func (st *ServerStream) Initialize() error {
 			media:   medi,
 			trackID: i,
 		}
+
+		if medi.Secure {
+			sm.srtpCtx, err = newSRTPContext(medi.SRTPKey)
+			if err != nil {
+				return err
+			}
+		}
+
 		sm.initialize()
 		st.medias[medi] = sm
 	}
@@ -152,6 +192,25 @@ func (st *ServerStream) Stats() *ServerStreamStats {
 						for _, fo := range sm.formats {
 							ret[fo.format] = ServerStreamStatsFormat{
 								RTPPacketsSent: atomic.LoadUint64(fo.rtpPacketsSent),
+								Receivers: func() map[uint32]ServerStreamStatsReceiver {
+									stats := fo.rtcpSender.Stats()
+									if stats == nil || stats.Receivers == nil {
+										return nil
+									}
+
+									ret := make(map[uint32]ServerStreamStatsReceiver, len(stats.Receivers))
+
+									for ssrc, rs := range stats.Receivers {
+										ret[ssrc] = ServerStreamStatsReceiver{
+											RTPPacketsFractionLost: rs.FractionLost,
+											RTPPacketsLost:         uint64(rs.TotalLost),
+											RTPPacketsJitter:       rs.Jitter,
+											RTT:                    rs.RTT,
+										}
+									}
+
+									return ret
+								}(),
 							}
 						}
 
@@ -221,7 +280,10 @@ func (st *ServerStream) rtpInfoEntry(medi *description.Media, now time.Time) *he
 		uint64(now.Sub(stats.LastNTP).Seconds()*float64(clockRate)) -
 		uint64(clockRate)/10)
 
+	ssrc := stats.LocalSSRC
+
 	return &headers.RTPInfoEntry{
+		SSRC:           &ssrc,
 		SequenceNumber: &seqNum,
 		Timestamp:      &ts,
 	}
@@ -284,6 +346,14 @@ func (st *ServerStream) readerRemove(ss *ServerSession) {
 
 	delete(st.readers, ss)
 
+	for medi, sm := range ss.setuppedMedias {
+		for pt, sf := range sm.formats {
+			if stats := sf.remoteReceiverStats(); stats != nil {
+				st.medias[medi].formats[pt].rtcpSender.RemoveReceiver(stats.SSRC)
+			}
+		}
+	}
+
 	if *ss.setuppedTransport == TransportUDPMulticast {
 		st.multicastReaderCount--
 		if st.multicastReaderCount == 0 {
@@ -295,12 +365,14 @@ func (st *ServerStream) readerRemove(ss *ServerSession) {
 	}
 }
 
-func (st *ServerStream) readerSetActive(ss *ServerSession) {
+// readerSetActive starts sending packets to a reader.
+// It returns, for each media, the first packet replayed from the GOP cache.
+func (st *ServerStream) readerSetActive(ss *ServerSession) map[*description.Media]*rtp.Packet {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
 	if st.closed {
-		return
+		return nil
 	}
 
 	if *ss.setuppedTransport == TransportUDPMulticast {
@@ -309,9 +381,31 @@ func (st *ServerStream) readerSetActive(ss *ServerSession) {
 			streamMedia.multicastWriter.rtcpl.addClient(
 				ss.author.ip(), streamMedia.multicastWriter.rtcpl.port(), sm.readPacketRTCPUDPPlay)
 		}
-	} else {
-		st.activeUnicastReaders[ss] = struct{}{}
+		return nil
+	}
+
+	var replayed map[*description.Media]*rtp.Packet
+
+	// cached packets are queued before activating the reader,
+	// in order to precede live packets.
+	if st.GOPCache {
+		for medi := range ss.setuppedMedias {
+			for _, sf := range st.medias[medi].formats {
+				if sf.gopCache != nil {
+					if first := sf.writeGOPCache(ss); first != nil {
+						if replayed == nil {
+							replayed = make(map[*description.Media]*rtp.Packet)
+						}
+						replayed[medi] = first
+					}
+				}
+			}
+		}
 	}
+
+	st.activeUnicastReaders[ss] = struct{}{}
+
+	return replayed
 }
 
 func (st *ServerStream) readerSetInactive(ss *ServerSession) {
@@ -356,6 +450,14 @@ func (st *ServerStream) WritePacketRTPWithNTP(medi *description.Media, pkt *rtp.
 
 	sm := st.medias[medi]
 	sf := sm.formats[pkt.PayloadType]
+
+	if sm.srtpCtx != nil {
+		byts, err = sm.srtpCtx.EncryptRTP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	return sf.writePacketRTP(byts, pkt, ntp)
 }
 
@@ -374,5 +476,56 @@ func (st *ServerStream) WritePacketRTCP(medi *description.Media, pkt rtcp.Packet
 	}
 
 	sm := st.medias[medi]
+
+	if sm.srtpCtx != nil {
+		byts, err = sm.srtpCtx.EncryptRTCP(byts)
+		if err != nil {
+			return err
+		}
+	}
+
 	return sm.writePacketRTCP(byts)
 }
+
+func (st *ServerStream) writeRetransmissions(r *ServerSession, medi *description.Media, nack *rtcp.TransportLayerNack) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if st.closed {
+		return
+	}
+
+	sm := st.medias[medi]
+
+	for _, sf := range sm.formats {
+		if sf.rtxSender != nil {
+			sf.writeRetransmissions(r, nack)
+		}
+	}
+}
+
+func (st *ServerStream) processReceiverReport(
+	r *ServerSession,
+	medi *description.Media,
+	rr *rtcp.ReceiverReport,
+	now time.Time,
+) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if st.closed {
+		return
+	}
+
+	if _, ok := st.readers[r]; !ok {
+		return
+	}
+
+	sm := st.medias[medi]
+
+	for pt, sf := range sm.formats {
+		if stats := sf.rtcpSender.ProcessReceiverReport(rr, now); stats != nil {
+			r.setuppedMedias[medi].formats[pt].setRemoteReceiverStats(stats)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
 	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
+	"github.com/frostyfridge/gortsplib/v4/pkg/rtx"
 )
 
 type serverStreamFormat struct {
@@ -16,6 +17,8 @@ type serverStreamFormat struct {
 	format format.Format
 
 	rtcpSender     *rtcpsender.RTCPSender
+	rtxSender      *rtx.Sender
+	gopCache       *gopCache
 	rtpPacketsSent *uint64
 }
 
@@ -33,11 +36,42 @@ func (sf *serverStreamFormat) initialize() {
 		},
 	}
 	sf.rtcpSender.Initialize()
+
+	// cached packets are sent with rewritten sequence numbers,
+	// that would reuse the SRTP keystream and alter the SRTP rollover counter
+	// shared by all readers. Therefore the cache is disabled with secure medias.
+	if sf.sm.st.GOPCache && !sf.sm.media.Secure {
+		sf.gopCache = newGOPCache(sf.format)
+	}
+
+	// retransmissions of each reader have their own sequence numbers,
+	// that would reuse the SRTP keystream shared by all readers.
+	// Therefore retransmissions are disabled with secure medias.
+	for _, forma := range sf.sm.media.Formats {
+		if rtxFormat, ok := forma.(*format.RTX); ok && rtxFormat.AssociatedPayloadType == sf.format.PayloadType() &&
+			!sf.sm.media.Secure {
+			sf.rtxSender = &rtx.Sender{
+				PayloadType: rtxFormat.PayloadTyp,
+			}
+			err := sf.rtxSender.Initialize()
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
 }
 
 func (sf *serverStreamFormat) writePacketRTP(byts []byte, pkt *rtp.Packet, ntp time.Time) error {
 	sf.rtcpSender.ProcessPacket(pkt, ntp, sf.format.PTSEqualsDTS(pkt))
 
+	if sf.rtxSender != nil {
+		sf.rtxSender.ProcessPacket(pkt)
+	}
+
+	if sf.gopCache != nil {
+		sf.gopCache.processPacket(pkt)
+	}
+
 	le := uint64(len(byts))
 
 	// send unicast
@@ -67,3 +101,78 @@ func (sf *serverStreamFormat) writePacketRTP(byts []byte, pkt *rtp.Packet, ntp t
 
 	return nil
 }
+
+func (sf *serverStreamFormat) writeRetransmissions(r *ServerSession, nack *rtcp.TransportLayerNack) {
+	rsf, ok := r.setuppedMedias[sf.sm.media].formats[sf.format.PayloadType()]
+	if !ok {
+		return
+	}
+
+	for _, pkt := range sf.rtxSender.ProcessNACKWithSequenceNumber(nack, rsf.rtxSeqNum) {
+		byts := make([]byte, sf.sm.st.Server.MaxPacketSize)
+		n, err := pkt.MarshalTo(byts)
+		if err != nil {
+			continue
+		}
+		byts = byts[:n]
+
+		if sf.sm.srtpCtx != nil {
+			byts, err = sf.sm.srtpCtx.EncryptRTP(byts)
+			if err != nil {
+				continue
+			}
+		}
+
+		err = r.writePacketRTP(sf.sm.media, pkt.PayloadType, byts)
+		if err != nil {
+			r.onStreamWriteError(err)
+			continue
+		}
+
+		atomic.AddUint64(sf.sm.bytesSent, uint64(len(byts)))
+		atomic.AddUint64(sf.sm.formats[pkt.PayloadType].rtpPacketsSent, 1)
+	}
+}
+
+// writeGOPCache queues the cached packets into the write queue of a reader,
+// and returns the first of them.
+// Packets are marshaled and sent by the writer of the reader,
+// after the PLAY response and outside the stream mutex.
+func (sf *serverStreamFormat) writeGOPCache(r *ServerSession) *rtp.Packet {
+	pkts := sf.gopCache.cachedPackets()
+
+	// the replay takes a single entry of the write queue,
+	// but it must not delay live packets more than a full queue.
+	if len(pkts) == 0 || len(pkts) > r.s.WriteQueueSize {
+		return nil
+	}
+
+	rsf := r.setuppedMedias[sf.sm.media].formats[sf.format.PayloadType()]
+
+	err := r.writeInQueue(func() error {
+		for _, pkt := range pkts {
+			byts := make([]byte, sf.sm.st.Server.MaxPacketSize)
+			n, err := pkt.MarshalTo(byts)
+			if err != nil {
+				continue
+			}
+			byts = byts[:n]
+
+			err = rsf.writePacketRTPInQueue(byts)
+			if err != nil {
+				return err
+			}
+
+			atomic.AddUint64(sf.sm.bytesSent, uint64(len(byts)))
+			atomic.AddUint64(sf.rtpPacketsSent, 1)
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.onStreamWriteError(err)
+		return nil
+	}
+
+	return pkts[0]
+}
@@ -0,0 +1,311 @@
+package gortsplib
+
+import (
+	"sync"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+)
+
+const (
+	// maximum number of packets stored by a GOP cache.
+	// when this is exceeded, the cache is cleared until the next key frame.
+	gopCacheMaxPackets = 4096
+)
+
+func h264ContainsIDR(payload []byte) bool {
+	if len(payload) < 1 {
+		return false
+	}
+
+	typ := h264.NALUType(payload[0] & 0x1F)
+
+	switch typ {
+	case h264.NALUTypeSTAPA, h264.NALUTypeSTAPB:
+		payload = payload[1:]
+
+		if typ == h264.NALUTypeSTAPB {
+			if len(payload) < 2 {
+				return false
+			}
+			payload = payload[2:] // DON
+		}
+
+		for len(payload) >= 2 {
+			size := int(payload[0])<<8 | int(payload[1])
+			payload = payload[2:]
+
+			if size == 0 || size > len(payload) {
+				return false
+			}
+
+			if h264.NALUType(payload[0]&0x1F) == h264.NALUTypeIDR {
+				return true
+			}
+
+			payload = payload[size:]
+		}
+
+	case h264.NALUTypeMTAP16, h264.NALUTypeMTAP24:
+		tsOffsetLen := 2
+		if typ == h264.NALUTypeMTAP24 {
+			tsOffsetLen = 3
+		}
+
+		if len(payload) < 3 {
+			return false
+		}
+		payload = payload[3:] // DONB
+
+		for len(payload) >= (3 + tsOffsetLen) {
+			size := int(payload[0])<<8 | int(payload[1])
+			payload = payload[3+tsOffsetLen:] // size, DOND, TS offset
+
+			if size == 0 || size > len(payload) {
+				return false
+			}
+
+			if h264.NALUType(payload[0]&0x1F) == h264.NALUTypeIDR {
+				return true
+			}
+
+			payload = payload[size:]
+		}
+
+	case h264.NALUTypeFUA, h264.NALUTypeFUB:
+		// NALU type is in the FU header of the first fragment
+		return len(payload) >= 2 &&
+			(payload[1]>>7) == 1 &&
+			h264.NALUType(payload[1]&0x1F) == h264.NALUTypeIDR
+
+	default:
+		return typ == h264.NALUTypeIDR
+	}
+
+	return false
+}
+
+func h265IsRandomAccess(typ h265.NALUType) bool {
+	switch typ {
+	case h265.NALUType_IDR_W_RADL, h265.NALUType_IDR_N_LP, h265.NALUType_CRA_NUT:
+		return true
+	}
+	return false
+}
+
+func h265ContainsRandomAccess(payload []byte, hasDON bool) bool {
+	if len(payload) < 2 {
+		return false
+	}
+
+	typ := h265.NALUType((payload[0] >> 1) & 0b111111)
+
+	switch typ {
+	case h265.NALUType_AggregationUnit:
+		payload = payload[2:]
+		first := true
+
+		for {
+			if hasDON {
+				// first aggregation unit has a DONL, the following ones have a DOND
+				n := 1
+				if first {
+					n = 2
+				}
+
+				if len(payload) < n {
+					return false
+				}
+				payload = payload[n:]
+			}
+			first = false
+
+			if len(payload) < 2 {
+				return false
+			}
+
+			size := int(payload[0])<<8 | int(payload[1])
+			payload = payload[2:]
+
+			if size == 0 || size > len(payload) {
+				return false
+			}
+
+			if h265IsRandomAccess(h265.NALUType((payload[0] >> 1) & 0b111111)) {
+				return true
+			}
+
+			payload = payload[size:]
+		}
+
+	case h265.NALUType_FragmentationUnit:
+		// NALU type is in the FU header of the first fragment
+		return len(payload) >= 3 &&
+			(payload[2]>>7) == 1 &&
+			h265IsRandomAccess(h265.NALUType(payload[2]&0b111111))
+
+	case h265.NALUType_PACI:
+		if len(payload) < 4 {
+			return false
+		}
+
+		ctype := (payload[2] >> 1) & 0b111111
+		phsSize := int(payload[2]&0x01)<<4 | int(payload[3]>>4)
+
+		if h265.NALUType(ctype) == h265.NALUType_PACI || len(payload) < (4+phsSize) {
+			return false
+		}
+
+		// rebuild the payload header of the contained packet
+		inner := make([]byte, 2+len(payload[4+phsSize:]))
+		inner[0] = ctype << 1
+		inner[1] = payload[1]
+		copy(inner[2:], payload[4+phsSize:])
+
+		return h265ContainsRandomAccess(inner, hasDON)
+
+	default:
+		return h265IsRandomAccess(typ)
+	}
+}
+
+// gopCacheKeyFrameDetector returns a function that checks whether a RTP packet
+// belongs to a key frame. Only RTP payload headers and NALU headers are inspected,
+// frames are never decoded.
+func gopCacheKeyFrameDetector(forma format.Format) func(*rtp.Packet) bool {
+	switch forma := forma.(type) {
+	case *format.H264:
+		return func(pkt *rtp.Packet) bool {
+			return h264ContainsIDR(pkt.Payload)
+		}
+
+	case *format.H265:
+		hasDON := (forma.MaxDONDiff != 0)
+
+		return func(pkt *rtp.Packet) bool {
+			return h265ContainsRandomAccess(pkt.Payload, hasDON)
+		}
+
+	case *format.AV1:
+		return func(pkt *rtp.Packet) bool {
+			// the N bit of the aggregation header is set
+			// in the first packet of a coded video sequence
+			return len(pkt.Payload) >= 1 && (pkt.Payload[0]&0x08) != 0
+		}
+
+	case *format.VP8:
+		return func(pkt *rtp.Packet) bool {
+			var vp8 codecs.VP8Packet
+			_, err := vp8.Unmarshal(pkt.Payload)
+			if err != nil || vp8.S != 1 || vp8.PID != 0 || len(vp8.Payload) == 0 {
+				return false
+			}
+
+			// the P bit of the frame tag is zero in key frames
+			return (vp8.Payload[0] & 0x01) == 0
+		}
+
+	case *format.VP9:
+		return func(pkt *rtp.Packet) bool {
+			var vp9 codecs.VP9Packet
+			_, err := vp9.Unmarshal(pkt.Payload)
+
+			// the P bit is zero in the first packet of frames that are not inter-predicted
+			return err == nil && vp9.B && !vp9.P
+		}
+	}
+
+	return nil
+}
+
+// gopCache stores the RTP packets of a video format since the last key frame.
+type gopCache struct {
+	isKeyFrame func(*rtp.Packet) bool
+
+	mutex      sync.Mutex
+	frame      []*rtp.Packet // packets of the frame that is being received
+	frameIsKey bool          // whether the frame that is being received is a key frame
+	packets    []*rtp.Packet // packets of complete frames, starting from a key frame
+}
+
+func newGOPCache(forma format.Format) *gopCache {
+	isKeyFrame := gopCacheKeyFrameDetector(forma)
+	if isKeyFrame == nil {
+		return nil
+	}
+
+	return &gopCache{
+		isKeyFrame: isKeyFrame,
+	}
+}
+
+func (c *gopCache) processFrame() {
+	if c.frameIsKey {
+		c.packets = c.frame
+	} else if c.packets != nil {
+		c.packets = append(c.packets, c.frame...)
+
+		if len(c.packets) > gopCacheMaxPackets {
+			c.packets = nil
+		}
+	}
+
+	c.frame = nil
+	c.frameIsKey = false
+}
+
+func (c *gopCache) processPacket(pkt *rtp.Packet) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.frame) != 0 && pkt.Timestamp != c.frame[0].Timestamp {
+		c.processFrame()
+	}
+
+	c.frame = append(c.frame, pkt.Clone())
+
+	if !c.frameIsKey && c.isKeyFrame(pkt) {
+		c.frameIsKey = true
+	}
+
+	if pkt.Marker {
+		c.processFrame()
+	}
+}
+
+// cachedPackets returns the cached packets, including the ones of the frame that is being received.
+// Sequence numbers are rewritten in order to be consecutive
+// and to end with the sequence number of the last packet.
+func (c *gopCache) cachedPackets() []*rtp.Packet {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.packets == nil {
+		return nil
+	}
+
+	ret := make([]*rtp.Packet, 0, len(c.packets)+len(c.frame))
+
+	// cached packets are never modified, therefore
+	// copies can share their payloads and extensions.
+	for _, pkt := range c.packets {
+		cpy := *pkt
+		ret = append(ret, &cpy)
+	}
+	for _, pkt := range c.frame {
+		cpy := *pkt
+		ret = append(ret, &cpy)
+	}
+
+	lastSeqNum := ret[len(ret)-1].SequenceNumber
+
+	for i, pkt := range ret {
+		pkt.SequenceNumber = lastSeqNum - uint16(len(ret)-1-i)
+	}
+
+	return ret
+}
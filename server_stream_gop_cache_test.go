@@ -0,0 +1,444 @@
+package gortsplib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+)
+
+func testGOPCachePackets() []*rtp.Packet {
+	var pkts []*rtp.Packet
+
+	for i, nalu := range [][]byte{
+		{0x01, 0x01}, // non-IDR
+		{0x05, 0x02}, // IDR
+		{0x01, 0x03}, // non-IDR
+	} {
+		pkts = append(pkts, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         true,
+				PayloadType:    96,
+				SequenceNumber: 1000 + uint16(i),
+				Timestamp:      3000 * uint32(i),
+				SSRC:           753621,
+			},
+			Payload: nalu,
+		})
+	}
+
+	return pkts
+}
+
+func TestGOPCache(t *testing.T) {
+	c := newGOPCache(&format.H264{PayloadTyp: 96, PacketizationMode: 1})
+	require.NotNil(t, c)
+
+	pkts := testGOPCachePackets()
+
+	c.processPacket(pkts[0])
+	require.Nil(t, c.cachedPackets())
+
+	c.processPacket(pkts[1])
+	require.Equal(t, []*rtp.Packet{pkts[1]}, c.cachedPackets())
+
+	// skip a sequence number
+	pkt := pkts[2].Clone()
+	pkt.SequenceNumber++
+	c.processPacket(pkt)
+
+	// packet without marker, that belongs to the frame that is being received
+	c.processPacket(&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 1004,
+			Timestamp:      9000,
+			SSRC:           753621,
+		},
+		Payload: []byte{0x01, 0x04},
+	})
+
+	cached := c.cachedPackets()
+	require.Len(t, cached, 3)
+
+	for i, pkt := range cached {
+		require.Equal(t, uint16(1002+i), pkt.SequenceNumber)
+	}
+
+	require.Equal(t, []byte{0x05, 0x02}, cached[0].Payload)
+	require.Equal(t, []byte{0x01, 0x03}, cached[1].Payload)
+	require.Equal(t, []byte{0x01, 0x04}, cached[2].Payload)
+
+	require.Nil(t, newGOPCache(&format.G711{}))
+}
+
+func TestGOPCacheKeyFrameDetector(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		format  format.Format
+		payload []byte
+		isKey   bool
+	}{
+		{
+			"h264 single idr",
+			&format.H264{PacketizationMode: 1},
+			[]byte{0x65, 0x01},
+			true,
+		},
+		{
+			"h264 single non-idr",
+			&format.H264{PacketizationMode: 1},
+			[]byte{0x41, 0x01},
+			false,
+		},
+		{
+			"h264 stap-a",
+			&format.H264{PacketizationMode: 1},
+			[]byte{0x18, 0x00, 0x02, 0x67, 0x01, 0x00, 0x02, 0x65, 0x01},
+			true,
+		},
+		{
+			"h264 stap-b",
+			&format.H264{PacketizationMode: 2},
+			[]byte{0x19, 0x00, 0x00, 0x00, 0x02, 0x67, 0x01, 0x00, 0x02, 0x65, 0x01},
+			true,
+		},
+		{
+			"h264 mtap16",
+			&format.H264{PacketizationMode: 2},
+			[]byte{0x1a, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x65, 0x01},
+			true,
+		},
+		{
+			"h264 fu-a start",
+			&format.H264{PacketizationMode: 1},
+			[]byte{0x7c, 0x85, 0x01},
+			true,
+		},
+		{
+			"h264 fu-a middle",
+			&format.H264{PacketizationMode: 1},
+			[]byte{0x7c, 0x05, 0x01},
+			false,
+		},
+		{
+			"h265 single idr",
+			&format.H265{},
+			[]byte{0x26, 0x01, 0x01},
+			true,
+		},
+		{
+			"h265 single trail",
+			&format.H265{},
+			[]byte{0x02, 0x01, 0x01},
+			false,
+		},
+		{
+			"h265 aggregation unit with donl",
+			&format.H265{MaxDONDiff: 2},
+			[]byte{0x60, 0x01, 0x00, 0x00, 0x00, 0x03, 0x40, 0x01, 0x01, 0x00, 0x00, 0x03, 0x2a, 0x01, 0x01},
+			true,
+		},
+		{
+			"h265 fragmentation unit start",
+			&format.H265{},
+			[]byte{0x62, 0x01, 0x93, 0x01},
+			true,
+		},
+		{
+			"av1 new coded video sequence",
+			&format.AV1{},
+			[]byte{0x18, 0x0a, 0x0b},
+			true,
+		},
+		{
+			"av1 other",
+			&format.AV1{},
+			[]byte{0x10, 0x32, 0x01},
+			false,
+		},
+		{
+			"vp8 key frame",
+			&format.VP8{},
+			[]byte{0x10, 0x00, 0x01},
+			true,
+		},
+		{
+			"vp8 inter frame",
+			&format.VP8{},
+			[]byte{0x10, 0x01, 0x01},
+			false,
+		},
+		{
+			"vp9 key frame",
+			&format.VP9{},
+			[]byte{0x08, 0x01},
+			true,
+		},
+		{
+			"vp9 inter frame",
+			&format.VP9{},
+			[]byte{0x48, 0x01},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			isKeyFrame := gopCacheKeyFrameDetector(ca.format)
+			require.NotNil(t, isKeyFrame)
+			require.Equal(t, ca.isKey, isKeyFrame(&rtp.Packet{Payload: ca.payload}))
+		})
+	}
+}
+
+func TestGOPCacheDisabledWithSecureMedia(t *testing.T) {
+	s := &Server{
+		Handler:     &testServerHandler{},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	medi := &description.Media{
+		Type:    description.MediaTypeVideo,
+		Secure:  true,
+		Formats: []format.Format{&format.H264{PayloadTyp: 96, PacketizationMode: 1}},
+	}
+
+	stream := &ServerStream{
+		Server:   s,
+		Desc:     &description.Session{Medias: []*description.Media{medi}},
+		GOPCache: true,
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.Nil(t, stream.medias[medi].formats[96].gopCache)
+}
+
+func TestServerPlayGOPCache(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:    "localhost:8554",
+		UDPRTPAddress:  "127.0.0.1:8000",
+		UDPRTCPAddress: "127.0.0.1:8001",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server:   s,
+		Desc:     &description.Session{Medias: []*description.Media{testH264Media}},
+		GOPCache: true,
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	for _, pkt := range testGOPCachePackets() {
+		err = stream.WritePacketRTP(stream.Desc.Medias[0], pkt)
+		require.NoError(t, err)
+	}
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	desc := doDescribe(t, conn, false)
+
+	l1, err := net.ListenPacket("udp", "localhost:35466")
+	require.NoError(t, err)
+	defer l1.Close()
+
+	l2, err := net.ListenPacket("udp", "localhost:35467")
+	require.NoError(t, err)
+	defer l2.Close()
+
+	res, _ := doSetup(t, conn, mediaURL(t, desc.BaseURL, desc.Medias[0]).String(), &headers.Transport{
+		Protocol:    headers.TransportProtocolUDP,
+		Mode:        transportModePtr(headers.TransportModePlay),
+		Delivery:    deliveryPtr(headers.TransportDeliveryUnicast),
+		ClientPorts: &[2]int{35466, 35467},
+	}, "")
+
+	session := readSession(t, res)
+
+	res = doPlay(t, conn, "rtsp://localhost:8554/teststream", session)
+
+	// RTP-Info points to the first cached packet
+	var ri headers.RTPInfo
+	err = ri.Unmarshal(res.Header["RTP-Info"])
+	require.NoError(t, err)
+	require.Equal(t, uint16(1001), *ri[0].SequenceNumber)
+	require.Equal(t, uint32(3000), *ri[0].Timestamp)
+
+	for _, payload := range [][]byte{{0x05, 0x02}, {0x01, 0x03}} {
+		buf := make([]byte, 2048)
+		n, _, err2 := l1.ReadFrom(buf)
+		require.NoError(t, err2)
+
+		var pkt rtp.Packet
+		err2 = pkt.Unmarshal(buf[:n])
+		require.NoError(t, err2)
+		require.Equal(t, payload, pkt.Payload)
+	}
+}
+
+func TestServerPlayGOPCacheQueueSize(t *testing.T) {
+	for _, ca := range []string{"fits", "exceeds"} {
+		t.Run(ca, func(t *testing.T) {
+			var stream *ServerStream
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+				},
+				RTSPAddress: "localhost:8554",
+				WriteQueueSize: func() int {
+					if ca == "fits" {
+						return 8
+					}
+					return 4
+				}(),
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			stream = &ServerStream{
+				Server:   s,
+				Desc:     &description.Session{Medias: []*description.Media{testH264Media}},
+				GOPCache: true,
+			}
+			err = stream.Initialize()
+			require.NoError(t, err)
+			defer stream.Close()
+
+			// a GOP of 6 packets
+			for i := range 6 {
+				nalu := []byte{0x01, byte(i)}
+				if i == 0 {
+					nalu[0] = 0x05
+				}
+
+				err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+					Header: rtp.Header{
+						Version:        2,
+						Marker:         true,
+						PayloadType:    96,
+						SequenceNumber: 1000 + uint16(i),
+						Timestamp:      3000 * uint32(i),
+						SSRC:           753621,
+					},
+					Payload: nalu,
+				})
+				require.NoError(t, err)
+			}
+
+			nconn, err := net.Dial("tcp", "localhost:8554")
+			require.NoError(t, err)
+			defer nconn.Close()
+			conn := conn.NewConn(nconn)
+
+			desc := doDescribe(t, conn, false)
+
+			res, _ := doSetup(t, conn, mediaURL(t, desc.BaseURL, desc.Medias[0]).String(), &headers.Transport{
+				Protocol:       headers.TransportProtocolTCP,
+				Mode:           transportModePtr(headers.TransportModePlay),
+				Delivery:       deliveryPtr(headers.TransportDeliveryUnicast),
+				InterleavedIDs: &[2]int{0, 1},
+			}, "")
+
+			session := readSession(t, res)
+
+			doPlay(t, conn, "rtsp://localhost:8554/teststream", session)
+
+			err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 1006,
+					Timestamp:      3000 * 6,
+					SSRC:           753621,
+				},
+				Payload: []byte{0x01, 0x06},
+			})
+			require.NoError(t, err)
+
+			var expected []uint16
+			if ca == "fits" {
+				// cached packets precede live ones
+				expected = []uint16{1000, 1001, 1002, 1003, 1004, 1005, 1006}
+			} else {
+				// the GOP is not replayed, since it doesn't fit into the queue
+				expected = []uint16{1006}
+			}
+
+			for _, seqNum := range expected {
+				var f *base.InterleavedFrame
+
+				for {
+					f, err = conn.ReadInterleavedFrame()
+					require.NoError(t, err)
+
+					if f.Channel == 0 {
+						break
+					}
+				}
+
+				var pkt rtp.Packet
+				err = pkt.Unmarshal(f.Payload)
+				require.NoError(t, err)
+				require.Equal(t, seqNum, pkt.SequenceNumber)
+			}
+		})
+	}
+}
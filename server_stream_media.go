@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
 )
 
 type serverStreamMedia struct {
@@ -12,6 +13,7 @@ type serverStreamMedia struct {
 	trackID int
 
 	formats         map[uint8]*serverStreamFormat
+	srtpCtx         *srtp.Context
 	multicastWriter *serverMulticastWriter
 	bytesSent       *uint64
 	rtcpPacketsSent *uint64
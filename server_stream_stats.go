@@ -1,14 +1,31 @@
 package gortsplib
 
 import (
+	"time"
+
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
 )
 
+// ServerStreamStatsReceiver are statistics of a stream receiver,
+// extracted from its RTCP receiver reports.
+type ServerStreamStatsReceiver struct {
+	// fraction of RTP packets lost since the previous report, between 0 and 1
+	RTPPacketsFractionLost float64
+	// number of lost RTP packets
+	RTPPacketsLost uint64
+	// jitter of RTP packets
+	RTPPacketsJitter float64
+	// round-trip time
+	RTT time.Duration
+}
+
 // ServerStreamStatsFormat are stream format statistics.
 type ServerStreamStatsFormat struct {
 	// number of sent RTP packets
 	RTPPacketsSent uint64
+	// receiver statistics, by SSRC of the receiver
+	Receivers map[uint32]ServerStreamStatsReceiver
 }
 
 // ServerStreamStatsMedia are stream media statistics.
@@ -129,6 +129,8 @@ type testServerHandler struct {
 	onGetParameter func(*ServerHandlerOnGetParameterCtx) (*base.Response, error)
 	onPacketsLost  func(*ServerHandlerOnPacketsLostCtx)
 	onDecodeError  func(*ServerHandlerOnDecodeErrorCtx)
+
+	onKeyFrameRequest func(*ServerHandlerOnKeyFrameRequestCtx)
 }
 
 func (sh *testServerHandler) OnConnOpen(ctx *ServerHandlerOnConnOpenCtx) {
@@ -223,6 +225,12 @@ func (sh *testServerHandler) OnDecodeError(ctx *ServerHandlerOnDecodeErrorCtx) {
 	}
 }
 
+func (sh *testServerHandler) OnKeyFrameRequest(ctx *ServerHandlerOnKeyFrameRequestCtx) {
+	if sh.onKeyFrameRequest != nil {
+		sh.onKeyFrameRequest(ctx)
+	}
+}
+
 func TestServerClose(t *testing.T) {
 	s := &Server{
 		Handler:     &testServerHandler{},
@@ -0,0 +1,189 @@
+package gortsplib
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// RTSP-over-WebSocket, as implemented by browser-based RTSP players.
+// RTSP requests, responses and interleaved frames are carried as binary messages.
+
+var webSocketProtocols = []string{"rtsp", "binary"}
+
+// serverWebSocketConn is a WebSocket connection that exposes the TCP addresses
+// of the underlying connection.
+type serverWebSocketConn struct {
+	*websocket.Conn
+
+	localAddr  net.Addr
+	remoteAddr net.Addr
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Close implements net.Conn.
+func (c *serverWebSocketConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.Conn.Close()
+		close(c.done)
+	})
+	return err
+}
+
+// LocalAddr implements net.Conn.
+func (c *serverWebSocketConn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+// RemoteAddr implements net.Conn.
+func (c *serverWebSocketConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// webSocketSameOrigin accepts requests without an Origin header,
+// that are sent by non-browser clients, and requests from the same origin.
+func webSocketSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+func webSocketSelectProtocol(config *websocket.Config) error {
+	if len(config.Protocol) == 0 {
+		return nil
+	}
+
+	for _, offered := range config.Protocol {
+		for _, supported := range webSocketProtocols {
+			if offered == supported {
+				config.Protocol = []string{offered}
+				return nil
+			}
+		}
+	}
+
+	// RFC6455: the server must not select a subprotocol that has not been offered
+	// or that it doesn't support.
+	return fmt.Errorf("unsupported WebSocket subprotocols: %v", config.Protocol)
+}
+
+type serverWebSocketListener struct {
+	s *Server
+
+	ln         net.Listener
+	httpServer *http.Server
+
+	mutex  sync.Mutex
+	closed bool
+}
+
+func (sl *serverWebSocketListener) initialize() error {
+	var err error
+	sl.ln, err = sl.s.Listen(restrictNetwork("tcp", sl.s.WebSocketAddress))
+	if err != nil {
+		return err
+	}
+
+	if sl.s.TLSConfig != nil {
+		sl.ln = tls.NewListener(sl.ln, sl.s.TLSConfig)
+	}
+
+	sl.httpServer = &http.Server{
+		Handler: websocket.Server{
+			Handshake: sl.handshake,
+			Handler:   sl.handleConn,
+		},
+		ReadHeaderTimeout: sl.s.ReadTimeout,
+		ErrorLog:          log.New(io.Discard, "", 0),
+	}
+
+	sl.s.wg.Add(1)
+	go sl.run()
+
+	return nil
+}
+
+func (sl *serverWebSocketListener) close() {
+	sl.httpServer.Close()
+
+	// handlers of hijacked connections are not stopped by the HTTP server,
+	// prevent new ones from being tracked.
+	sl.mutex.Lock()
+	sl.closed = true
+	sl.mutex.Unlock()
+}
+
+func (sl *serverWebSocketListener) run() {
+	defer sl.s.wg.Done()
+
+	err := sl.httpServer.Serve(sl.ln)
+	if !errors.Is(err, http.ErrServerClosed) {
+		sl.s.acceptErr(err)
+	}
+}
+
+func (sl *serverWebSocketListener) handshake(config *websocket.Config, r *http.Request) error {
+	checkOrigin := sl.s.WebSocketCheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = webSocketSameOrigin
+	}
+
+	if !checkOrigin(r) {
+		return fmt.Errorf("origin not allowed: '%s'", r.Header.Get("Origin"))
+	}
+
+	return webSocketSelectProtocol(config)
+}
+
+func (sl *serverWebSocketListener) handleConn(wc *websocket.Conn) {
+	sl.mutex.Lock()
+	if sl.closed {
+		sl.mutex.Unlock()
+		return
+	}
+	sl.s.wg.Add(1)
+	sl.mutex.Unlock()
+
+	defer sl.s.wg.Done()
+
+	wc.PayloadType = websocket.BinaryFrame
+
+	remoteAddr, err := net.ResolveTCPAddr("tcp", wc.Request().RemoteAddr)
+	if err != nil {
+		return
+	}
+
+	localAddr, _ := wc.Request().Context().Value(http.LocalAddrContextKey).(net.Addr)
+
+	nconn := &serverWebSocketConn{
+		Conn:       wc,
+		localAddr:  localAddr,
+		remoteAddr: remoteAddr,
+		done:       make(chan struct{}),
+	}
+
+	sl.s.newConn(nconn)
+
+	// the connection is closed by the WebSocket server when the handler returns.
+	<-nconn.done
+}
@@ -0,0 +1,149 @@
+package gortsplib
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+)
+
+func TestServerWebSocket(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(_ *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:      "localhost:8554",
+		WebSocketAddress: "localhost:8555",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc:   &description.Session{Medias: []*description.Media{testH264Media}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	wc, err := websocket.Dial("ws://localhost:8555/", "rtsp", "http://localhost:8555/")
+	require.NoError(t, err)
+	defer wc.Close()
+
+	require.Equal(t, "rtsp", wc.Config().Protocol[0])
+
+	wc.PayloadType = websocket.BinaryFrame
+	conn := conn.NewConn(wc)
+
+	desc := doDescribe(t, conn, false)
+
+	inTH := &headers.Transport{
+		Protocol:       headers.TransportProtocolTCP,
+		Delivery:       deliveryPtr(headers.TransportDeliveryUnicast),
+		Mode:           transportModePtr(headers.TransportModePlay),
+		InterleavedIDs: &[2]int{0, 1},
+	}
+
+	res, th := doSetup(t, conn, mediaURL(t, desc.BaseURL, desc.Medias[0]).String(), inTH, "")
+	require.Equal(t, headers.TransportProtocolTCP, th.Protocol)
+
+	session := readSession(t, res)
+
+	doPlay(t, conn, "rtsp://localhost:8554/teststream", session)
+
+	err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 1234,
+		},
+		Payload: []byte{5, 1, 2, 3, 4},
+	})
+	require.NoError(t, err)
+
+	f, err := conn.ReadInterleavedFrame()
+	require.NoError(t, err)
+	require.Equal(t, 0, f.Channel)
+
+	var pkt rtp.Packet
+	err = pkt.Unmarshal(f.Payload)
+	require.NoError(t, err)
+	require.Equal(t, []byte{5, 1, 2, 3, 4}, pkt.Payload)
+}
+
+func TestServerWebSocketUnsupportedProtocol(t *testing.T) {
+	s := &Server{
+		Handler:          &testServerHandler{},
+		RTSPAddress:      "localhost:8554",
+		WebSocketAddress: "localhost:8555",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = websocket.Dial("ws://localhost:8555/", "chat", "http://localhost:8555/")
+	require.Error(t, err)
+}
+
+func TestServerWebSocketOrigin(t *testing.T) {
+	for _, ca := range []string{
+		"default",
+		"custom",
+	} {
+		t.Run(ca, func(t *testing.T) {
+			s := &Server{
+				Handler:          &testServerHandler{},
+				RTSPAddress:      "localhost:8554",
+				WebSocketAddress: "localhost:8555",
+			}
+
+			if ca == "custom" {
+				s.WebSocketCheckOrigin = func(r *http.Request) bool {
+					return r.Header.Get("Origin") == "http://myplayer.example"
+				}
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			_, err = websocket.Dial("ws://localhost:8555/", "rtsp", "http://attacker.example/")
+			require.Error(t, err)
+
+			origin := "http://localhost:8555/"
+			if ca == "custom" {
+				origin = "http://myplayer.example"
+			}
+
+			wc, err := websocket.Dial("ws://localhost:8555/", "rtsp", origin)
+			require.NoError(t, err)
+			defer wc.Close()
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package gortsplib
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
+)
+
+// generateSRTPKeys generates a SRTP key for each secure media that doesn't have one.
+func generateSRTPKeys(medias []*description.Media) error {
+	for _, medi := range medias {
+		if medi.Secure && medi.SRTPKey == nil {
+			key, err := srtp.GenerateKey(srtp.ProtectionProfileAESCM128HMACSHA180)
+			if err != nil {
+				return err
+			}
+			medi.SRTPKey = key
+		}
+	}
+	return nil
+}
+
+func hasSecureMedias(medias []*description.Media) bool {
+	for _, medi := range medias {
+		if medi.Secure {
+			return true
+		}
+	}
+	return false
+}
+
+// newSRTPContext allocates a SRTP context that uses the given key.
+// Each direction of a secure media has its own key and therefore its own context.
+func newSRTPContext(key *srtp.Key) (*srtp.Context, error) {
+	if key == nil {
+		return nil, nil
+	}
+
+	ctx := &srtp.Context{
+		Key: key,
+	}
+	err := ctx.Initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// generateSRTPKeyFor generates a SRTP key with the same protection profile of the key of a media.
+func generateSRTPKeyFor(medi *description.Media) (*srtp.Key, error) {
+	return srtp.GenerateKey(medi.SRTPKey.Profile)
+}
+
+// unmarshalKeyMgmt decodes the SRTP key contained in a KeyMgmt header.
+func unmarshalKeyMgmt(v base.HeaderValue) (*srtp.Key, error) {
+	var h headers.KeyMgmt
+	err := h.Unmarshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var key srtp.Key
+	err = key.UnmarshalMIKEY(h.MikeyMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// marshalKeyMgmt encodes a SRTP key into a KeyMgmt header.
+func marshalKeyMgmt(u *base.URL, key *srtp.Key) base.HeaderValue {
+	return headers.KeyMgmt{
+		URL:          u.String(),
+		MikeyMessage: key.MarshalMIKEY(),
+	}.Marshal()
+}
+
+// serverSRTPKeys returns the keys used by a server to decrypt and encrypt packets of a secure media.
+// The key of the SDP is used by its author (the server when playing, the client when recording)
+// to encrypt outgoing packets. The key of the opposite direction is the one provided by the client
+// in the KeyMgmt header, or, if the header is missing, a key derived from the key of the SDP.
+func serverSRTPKeys(
+	req *base.Request,
+	medi *description.Media,
+	record bool,
+) (*srtp.Key, *srtp.Key, bool, error) {
+	v, ok := req.Header["KeyMgmt"]
+	if !ok {
+		reverseKey, err := medi.SRTPKey.DeriveReverse()
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if record {
+			return medi.SRTPKey, reverseKey, false, nil
+		}
+		return reverseKey, medi.SRTPKey, false, nil
+	}
+
+	inKey, err := unmarshalKeyMgmt(v)
+	if err != nil {
+		return nil, nil, false, liberrors.ErrServerKeyMgmtHeaderInvalid{Err: err}
+	}
+
+	outKey := medi.SRTPKey
+
+	if record {
+		outKey, err = generateSRTPKeyFor(medi)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	if bytes.Equal(inKey.MasterKey, outKey.MasterKey) {
+		return nil, nil, false, liberrors.ErrServerKeyMgmtHeaderInvalid{
+			Err: fmt.Errorf("the same key can't be used in both directions"),
+		}
+	}
+
+	return inKey, outKey, true, nil
+}
+
+// clientSRTPKeys returns the keys used by a client to decrypt and encrypt packets of a secure media,
+// given the key that the client sent in the KeyMgmt header.
+// When the server doesn't support the KeyMgmt header, the key of the opposite direction
+// is derived from the key of the SDP.
+func clientSRTPKeys(
+	res *base.Response,
+	medi *description.Media,
+	sentKey *srtp.Key,
+	record bool,
+) (*srtp.Key, *srtp.Key, error) {
+	v, ok := res.Header["KeyMgmt"]
+	if !ok {
+		reverseKey, err := medi.SRTPKey.DeriveReverse()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if record {
+			return reverseKey, medi.SRTPKey, nil
+		}
+		return medi.SRTPKey, reverseKey, nil
+	}
+
+	inKey, err := unmarshalKeyMgmt(v)
+	if err != nil {
+		return nil, nil, liberrors.ErrClientKeyMgmtHeaderInvalid{Err: err}
+	}
+
+	if bytes.Equal(inKey.MasterKey, sentKey.MasterKey) {
+		return nil, nil, liberrors.ErrClientKeyMgmtHeaderInvalid{
+			Err: fmt.Errorf("the same key can't be used in both directions"),
+		}
+	}
+
+	return inKey, sentKey, nil
+}
+
+func mediaTransportProfile(medi *description.Media) headers.TransportProfile {
+	if medi.Secure {
+		return headers.TransportProfileSAVP
+	}
+	return headers.TransportProfileAVP
+}
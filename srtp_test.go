@@ -0,0 +1,494 @@
+package gortsplib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frostyfridge/gortsplib/v4/pkg/base"
+	"github.com/frostyfridge/gortsplib/v4/pkg/conn"
+	"github.com/frostyfridge/gortsplib/v4/pkg/description"
+	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+	"github.com/frostyfridge/gortsplib/v4/pkg/liberrors"
+	"github.com/frostyfridge/gortsplib/v4/pkg/srtp"
+)
+
+func TestSRTPPlay(t *testing.T) {
+	for _, ca := range []struct {
+		transport string
+		keyMgmt   description.MediaKeyMgmt
+		profile   srtp.ProtectionProfile
+	}{
+		{"udp", description.MediaKeyMgmtSDES, srtp.ProtectionProfileAESCM128HMACSHA180},
+		{"tcp", description.MediaKeyMgmtSDES, srtp.ProtectionProfileAESCM128HMACSHA132},
+		{"udp", description.MediaKeyMgmtMIKEY, srtp.ProtectionProfileAEADAES128GCM},
+		{"tcp", description.MediaKeyMgmtMIKEY, srtp.ProtectionProfileAEADAES256GCM},
+	} {
+		t.Run(ca.transport+"_"+ca.profile.String(), func(t *testing.T) {
+			var stream *ServerStream
+			serverRecv := make(chan rtcp.Packet)
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						ctx.Session.OnPacketRTCPAny(func(_ *description.Media, pkt rtcp.Packet) {
+							if _, ok := pkt.(*rtcp.ReceiverReport); !ok {
+								serverRecv <- pkt
+							}
+						})
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onDecodeError: func(ctx *ServerHandlerOnDecodeErrorCtx) {
+						t.Errorf("unexpected error: %v", ctx.Error)
+					},
+				},
+				UDPRTPAddress:           "127.0.0.1:8000",
+				UDPRTCPAddress:          "127.0.0.1:8001",
+				RTSPAddress:             "127.0.0.1:8554",
+				InsecureSRTPKeyExchange: true,
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			key, err := srtp.GenerateKey(ca.profile)
+			require.NoError(t, err)
+
+			stream = &ServerStream{
+				Server: s,
+				Desc: &description.Session{Medias: []*description.Media{{
+					Type:    description.MediaTypeVideo,
+					Secure:  true,
+					SRTPKey: key,
+					KeyMgmt: ca.keyMgmt,
+					Formats: []format.Format{&format.H264{
+						PayloadTyp:        96,
+						PacketizationMode: 1,
+					}},
+				}}},
+			}
+			err = stream.Initialize()
+			require.NoError(t, err)
+			defer stream.Close()
+
+			c := Client{
+				InsecureSRTPKeyExchange: true,
+				Transport: func() *Transport {
+					if ca.transport == "udp" {
+						v := TransportUDP
+						return &v
+					}
+					v := TransportTCP
+					return &v
+				}(),
+				OnDecodeError: func(err error) {
+					t.Errorf("unexpected error: %v", err)
+				},
+			}
+
+			err = c.Start("rtsp", "127.0.0.1:8554")
+			require.NoError(t, err)
+			defer c.Close()
+
+			desc, _, err := c.Describe(mustParseURL("rtsp://127.0.0.1:8554/teststream"))
+			require.NoError(t, err)
+			require.True(t, desc.Medias[0].Secure)
+			require.Equal(t, key, desc.Medias[0].SRTPKey)
+			require.Equal(t, ca.keyMgmt, desc.Medias[0].KeyMgmt)
+
+			err = c.SetupAll(desc.BaseURL, desc.Medias)
+			require.NoError(t, err)
+
+			clientRecv := make(chan *rtp.Packet)
+
+			c.OnPacketRTP(desc.Medias[0], desc.Medias[0].Formats[0], func(pkt *rtp.Packet) {
+				clientRecv <- pkt
+			})
+
+			_, err = c.Play(nil)
+			require.NoError(t, err)
+
+			for i := 0; i < 3; i++ {
+				err = stream.WritePacketRTP(stream.Desc.Medias[0], &rtp.Packet{
+					Header: rtp.Header{
+						Version:        2,
+						PayloadType:    96,
+						SequenceNumber: 1234 + uint16(i),
+						SSRC:           5678,
+					},
+					Payload: []byte{5, 1, 2, 3, 4},
+				})
+				require.NoError(t, err)
+
+				pkt := <-clientRecv
+				require.Equal(t, []byte{5, 1, 2, 3, 4}, pkt.Payload)
+				require.Equal(t, 1234+uint16(i), pkt.SequenceNumber)
+			}
+
+			err = c.WritePacketRTCP(desc.Medias[0], &rtcp.PictureLossIndication{
+				SenderSSRC: 1234,
+				MediaSSRC:  5678,
+			})
+			require.NoError(t, err)
+
+			pkt := <-serverRecv
+			require.Equal(t, &rtcp.PictureLossIndication{
+				SenderSSRC: 1234,
+				MediaSSRC:  5678,
+			}, pkt)
+		})
+	}
+}
+
+func TestSRTPRecord(t *testing.T) {
+	for _, transport := range []string{"udp", "tcp"} {
+		t.Run(transport, func(t *testing.T) {
+			serverRecv := make(chan *rtp.Packet)
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onAnnounce: func(ctx *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+						require.True(t, ctx.Description.Medias[0].Secure)
+						require.NotNil(t, ctx.Description.Medias[0].SRTPKey)
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil, nil
+					},
+					onRecord: func(ctx *ServerHandlerOnRecordCtx) (*base.Response, error) {
+						ctx.Session.OnPacketRTPAny(func(_ *description.Media, _ format.Format, pkt *rtp.Packet) {
+							serverRecv <- pkt
+						})
+
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onDecodeError: func(ctx *ServerHandlerOnDecodeErrorCtx) {
+						t.Errorf("unexpected error: %v", ctx.Error)
+					},
+				},
+				UDPRTPAddress:           "127.0.0.1:8000",
+				UDPRTCPAddress:          "127.0.0.1:8001",
+				RTSPAddress:             "127.0.0.1:8554",
+				InsecureSRTPKeyExchange: true,
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			c := Client{
+				InsecureSRTPKeyExchange: true,
+				Transport: func() *Transport {
+					if transport == "udp" {
+						v := TransportUDP
+						return &v
+					}
+					v := TransportTCP
+					return &v
+				}(),
+				OnDecodeError: func(err error) {
+					t.Errorf("unexpected error: %v", err)
+				},
+			}
+
+			medi := &description.Media{
+				Type:   description.MediaTypeVideo,
+				Secure: true,
+				Formats: []format.Format{&format.H264{
+					PayloadTyp:        96,
+					PacketizationMode: 1,
+				}},
+			}
+
+			err = c.StartRecording("rtsp://127.0.0.1:8554/teststream",
+				&description.Session{Medias: []*description.Media{medi}})
+			require.NoError(t, err)
+			defer c.Close()
+
+			// the key is generated automatically
+			require.NotNil(t, medi.SRTPKey)
+
+			err = c.WritePacketRTP(medi, &rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					PayloadType:    96,
+					SequenceNumber: 1234,
+					SSRC:           5678,
+				},
+				Payload: []byte{5, 1, 2, 3, 4},
+			})
+			require.NoError(t, err)
+
+			pkt := <-serverRecv
+			require.Equal(t, []byte{5, 1, 2, 3, 4}, pkt.Payload)
+		})
+	}
+}
+
+func TestSRTPServerErrorInvalidProfile(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onConnClose: func(_ *ServerHandlerOnConnCloseCtx) {},
+			onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc: &description.Session{Medias: []*description.Media{{
+			Type:    description.MediaTypeVideo,
+			Secure:  true,
+			Formats: []format.Format{&format.H264{PayloadTyp: 96, PacketizationMode: 1}},
+		}}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	// the key is generated automatically
+	require.NotNil(t, stream.Desc.Medias[0].SRTPKey)
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream/trackID=0"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+			"Transport": headers.Transport{
+				Protocol:       headers.TransportProtocolTCP,
+				Delivery:       deliveryPtr(headers.TransportDeliveryUnicast),
+				Mode:           transportModePtr(headers.TransportModePlay),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusUnsupportedTransport, res.StatusCode)
+}
+
+func TestSRTPServerKeyMgmt(t *testing.T) {
+	for _, ca := range []string{"play", "record"} {
+		t.Run(ca, func(t *testing.T) {
+			var stream *ServerStream
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onAnnounce: func(_ *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onSetup: func(_ *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+				},
+				RTSPAddress:             "localhost:8554",
+				InsecureSRTPKeyExchange: true,
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			sdpKey, err := srtp.GenerateKey(srtp.ProtectionProfileAESCM128HMACSHA180)
+			require.NoError(t, err)
+
+			medi := &description.Media{
+				Type:    description.MediaTypeVideo,
+				Secure:  true,
+				SRTPKey: sdpKey,
+				Formats: []format.Format{&format.H264{PayloadTyp: 96, PacketizationMode: 1}},
+			}
+
+			nconn, err := net.Dial("tcp", "localhost:8554")
+			require.NoError(t, err)
+			defer nconn.Close()
+			conn := conn.NewConn(nconn)
+
+			var clientKey *srtp.Key
+			var mode headers.TransportMode
+
+			if ca == "play" {
+				stream = &ServerStream{
+					Server: s,
+					Desc:   &description.Session{Medias: []*description.Media{medi}},
+				}
+				err = stream.Initialize()
+				require.NoError(t, err)
+				defer stream.Close()
+
+				clientKey, err = srtp.GenerateKey(srtp.ProtectionProfileAESCM128HMACSHA180)
+				require.NoError(t, err)
+				mode = headers.TransportModePlay
+			} else {
+				doAnnounce(t, conn, "rtsp://localhost:8554/teststream", []*description.Media{medi})
+
+				clientKey = sdpKey
+				mode = headers.TransportModeRecord
+			}
+
+			u := "rtsp://localhost:8554/teststream/trackID=0"
+			if ca == "record" {
+				u = mediaURL(t, mustParseURL("rtsp://localhost:8554/teststream"), medi).String()
+			}
+
+			res, err := writeReqReadRes(conn, base.Request{
+				Method: base.Setup,
+				URL:    mustParseURL(u),
+				Header: base.Header{
+					"CSeq": base.HeaderValue{"2"},
+					"Transport": headers.Transport{
+						Profile:        headers.TransportProfileSAVP,
+						Protocol:       headers.TransportProtocolTCP,
+						Delivery:       deliveryPtr(headers.TransportDeliveryUnicast),
+						Mode:           transportModePtr(mode),
+						InterleavedIDs: &[2]int{0, 1},
+					}.Marshal(),
+					"KeyMgmt": headers.KeyMgmt{
+						URL:          u,
+						MikeyMessage: clientKey.MarshalMIKEY(),
+					}.Marshal(),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			var km headers.KeyMgmt
+			err = km.Unmarshal(res.Header["KeyMgmt"])
+			require.NoError(t, err)
+			require.Equal(t, u, km.URL)
+
+			var serverKey srtp.Key
+			err = serverKey.UnmarshalMIKEY(km.MikeyMessage)
+			require.NoError(t, err)
+
+			if ca == "play" {
+				// packets sent to readers are encrypted with the key of the stream
+				require.Equal(t, *sdpKey, serverKey)
+			} else {
+				// packets sent to publishers are encrypted with a dedicated key
+				require.Equal(t, sdpKey.Profile, serverKey.Profile)
+				require.NotEqual(t, sdpKey.MasterKey, serverKey.MasterKey)
+			}
+		})
+	}
+}
+
+func TestSRTPServerErrorWithoutTLS(t *testing.T) {
+	var stream *ServerStream
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onConnClose: func(_ *ServerHandlerOnConnCloseCtx) {},
+			onDescribe: func(_ *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream = &ServerStream{
+		Server: s,
+		Desc: &description.Session{Medias: []*description.Media{{
+			Type:    description.MediaTypeVideo,
+			Secure:  true,
+			Formats: []format.Format{&format.H264{PayloadTyp: 96, PacketizationMode: 1}},
+		}}},
+	}
+	err = stream.Initialize()
+	require.NoError(t, err)
+	defer stream.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusBadRequest, res.StatusCode)
+}
+
+func TestSRTPClientErrorWithoutTLS(t *testing.T) {
+	s := &Server{
+		Handler: &testServerHandler{
+			onConnClose: func(_ *ServerHandlerOnConnCloseCtx) {},
+			onAnnounce: func(_ *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+				t.Errorf("should not happen")
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress: "127.0.0.1:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	c := Client{}
+
+	err = c.StartRecording("rtsp://127.0.0.1:8554/teststream",
+		&description.Session{Medias: []*description.Media{{
+			Type:    description.MediaTypeVideo,
+			Secure:  true,
+			Formats: []format.Format{&format.H264{PayloadTyp: 96, PacketizationMode: 1}},
+		}}})
+	require.Error(t, err)
+	require.Equal(t, liberrors.ErrClientSRTPWithoutTLS{}, err)
+}
@@ -5,8 +5,23 @@ import (
 
 	"github.com/frostyfridge/gortsplib/v4/pkg/description"
 	"github.com/frostyfridge/gortsplib/v4/pkg/format"
+	"github.com/frostyfridge/gortsplib/v4/pkg/rtcpsender"
 )
 
+// latestReceiverStats returns the receiver statistics that have been updated most recently.
+func latestReceiverStats(receivers map[uint32]rtcpsender.ReceiverStats) *rtcpsender.ReceiverStats {
+	var ret *rtcpsender.ReceiverStats
+
+	for _, stats := range receivers {
+		if ret == nil || stats.LastReport.After(ret.LastReport) {
+			v := stats
+			ret = &v
+		}
+	}
+
+	return ret
+}
+
 // StatsSessionFormat are session format statistics.
 type StatsSessionFormat struct {
 	// number of RTP packets correctly received and processed
@@ -27,6 +42,14 @@ type StatsSessionFormat struct {
 	RTPPacketsLastRTP uint32
 	// last NTP time of incoming/outgoing NTP packets
 	RTPPacketsLastNTP time.Time
+	// fraction of outgoing RTP packets lost by the remote receiver since its previous report, between 0 and 1
+	RemoteRTPPacketsFractionLost float64
+	// number of outgoing RTP packets lost by the remote receiver
+	RemoteRTPPacketsLost uint64
+	// jitter of outgoing RTP packets measured by the remote receiver
+	RemoteRTPPacketsJitter float64
+	// round-trip time, computed from RTCP receiver reports
+	RTT time.Duration
 }
 
 // StatsSessionMedia are session media statistics.
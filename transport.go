@@ -1,5 +1,9 @@
 package gortsplib
 
+import (
+	"github.com/frostyfridge/gortsplib/v4/pkg/headers"
+)
+
 // Transport is a RTSP transport protocol.
 type Transport int
 
@@ -23,3 +27,12 @@ func (t Transport) String() string {
 	}
 	return "unknown"
 }
+
+// transportAddrPorts returns the RTP and RTCP ports contained in
+// the dest_addr or src_addr fields of a RTSP 2.0 Transport header.
+func transportAddrPorts(addrs []headers.TransportAddr) *[2]int {
+	if len(addrs) != 2 || addrs[0].Port == 0 || addrs[1].Port == 0 {
+		return nil
+	}
+	return &[2]int{addrs[0].Port, addrs[1].Port}
+}